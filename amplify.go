@@ -16,6 +16,7 @@ type AmplifyResult struct {
 	Content    string   `json:"content"`    // Final refined content
 	Iterations int      `json:"iterations"` // Number of iterations performed
 	Completed  bool     `json:"completed"`  // Whether completion criteria was met
+	Converged  bool     `json:"converged"`  // Whether refinement stopped because content plateaued
 	Reasoning  []string `json:"reasoning"`  // Reasoning from final completion check
 }
 
@@ -33,8 +34,13 @@ type Amplify struct {
 	maxIterations      int
 
 	// Configuration
+	convergenceThreshold  float32
 	refinementTemperature float32
 	completionTemperature float32
+	stream                func(token string)
+	sourceTag             string
+	locale                string
+	contextStrategy       ContextStrategy
 	provider              Provider
 	temperature           float32
 }
@@ -48,10 +54,17 @@ type Amplify struct {
 // The loop continues until either:
 //   - The completion criteria are satisfied (Binary returns true)
 //   - maxIterations is reached
+//   - The content converges, if a convergence threshold is configured
 //
 // Output Notes:
 //   - {key}: JSON-serialized AmplifyResult
 //
+// Each iteration starts by checkpointing the content so far to {key}, with
+// Completed false and partial=true metadata, and the final result replaces it.
+// If the context is canceled mid-refinement, Process returns an error wrapping the
+// context error and {key} holds the last good content; if the process dies, the
+// stored thought does. Scan the thought to use the partial result.
+//
 // Example:
 //
 //	refine := cogito.NewAmplify(
@@ -81,10 +94,11 @@ func NewAmplify(key, sourceKey, refinementPrompt, completionCriteria string, max
 
 // Process implements pipz.Chainable[*Thought].
 func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, a.provider)
+	provider, err := ResolveProviderForStep(ctx, "amplify", a.provider)
 	if err != nil {
 		return t, fmt.Errorf("amplify: %w", err)
 	}
@@ -96,7 +110,7 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	}
 
 	// Get unpublished notes for context
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := a.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -107,15 +121,24 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldUnpublishedCount.Field(len(unpublished)),
 		FieldTemperature.Field(a.temperature),
 	)
-
-	// Create synapses
-	transformSynapse, err := zyn.Transform(a.refinementPrompt, provider)
+	emitContextRendered(ctx, t, a.key, "amplify", noteContext, len(unpublished))
+
+	// Create synapses, streaming refinement output when the provider supports it
+	refineProvider := provider
+	streaming := false
+	if a.stream != nil {
+		if sp, ok := provider.(StreamingProvider); ok {
+			refineProvider = &streamProvider{StreamingProvider: sp, field: "output", onToken: a.stream}
+			streaming = true
+		}
+	}
+	transformSynapse, err := zyn.Transform(a.refinementPrompt, refineProvider)
 	if err != nil {
 		a.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("amplify: failed to create transform synapse: %w", err)
 	}
 
-	binarySynapse, err := zyn.Binary(a.completionCriteria, provider)
+	binarySynapse, err := zyn.Binary(localize(a.completionCriteria, a.locale), provider)
 	if err != nil {
 		a.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("amplify: failed to create binary synapse: %w", err)
@@ -133,10 +156,17 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 
 	// Iterative refinement loop
 	var completed bool
+	var converged bool
 	var reasoning []string
 	iteration := 0
 
 	for iteration < a.maxIterations {
+		// Checkpoint the best content so far, so even a crash mid-iteration leaves a result
+		checkpoint := AmplifyResult{Content: content, Iterations: iteration, Reasoning: reasoning}
+		if err := a.storeResult(ctx, t, unpublished, checkpoint, true); err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("amplify: failed to persist checkpoint: %w", err)
+		}
 		iteration++
 
 		// PHASE 1: REFINEMENT - Transform content
@@ -144,13 +174,21 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		refined, err = transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 			Text:        content,
 			Context:     noteContext,
-			Style:       a.refinementPrompt,
+			Style:       localizeOutput(a.refinementPrompt, a.locale),
 			Temperature: refinementTemp,
 		})
 		if err != nil {
 			a.emitFailed(ctx, t, start, err)
+			if ctx.Err() != nil {
+				// This iteration's checkpoint already holds the best content
+				return t, fmt.Errorf("amplify: canceled after %d iterations: %w", iteration-1, err)
+			}
 			return t, fmt.Errorf("amplify: refinement failed at iteration %d: %w", iteration, err)
 		}
+		if a.stream != nil && !streaming {
+			a.stream(refined)
+		}
+		previous := content
 		content = refined
 
 		// PHASE 2: COMPLETION CHECK - Binary decision
@@ -162,6 +200,9 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		})
 		if err != nil {
 			a.emitFailed(ctx, t, start, err)
+			if ctx.Err() != nil {
+				return a.storePartial(ctx, t, unpublished, content, iteration, reasoning, err)
+			}
 			return t, fmt.Errorf("amplify: completion check failed at iteration %d: %w", iteration, err)
 		}
 
@@ -185,6 +226,17 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 			)
 			break
 		}
+
+		// Stop early once refinements plateau
+		if a.convergenceThreshold > 0 && textSimilarity(previous, content) >= float64(a.convergenceThreshold) {
+			converged = true
+			capitan.Emit(ctx, AmplifyConverged,
+				FieldTraceID.Field(t.TraceID),
+				FieldStepName.Field(a.key),
+				FieldIterationCount.Field(iteration),
+			)
+			break
+		}
 	}
 
 	// Store result
@@ -192,20 +244,19 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		Content:    content,
 		Iterations: iteration,
 		Completed:  completed,
+		Converged:  converged,
 		Reasoning:  reasoning,
 	}
-	resultJSON, err := json.Marshal(result)
-	if err != nil {
-		a.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("amplify: failed to marshal result: %w", err)
-	}
-	if err := t.SetContent(ctx, a.key, string(resultJSON), "amplify"); err != nil {
+	if err := a.storeResult(ctx, t, unpublished, result, false); err != nil {
 		a.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("amplify: failed to persist note: %w", err)
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("amplify: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -214,16 +265,47 @@ func (a *Amplify) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(a.key),
 		FieldStepType.Field("amplify"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 		FieldIterationCount.Field(iteration),
 	)
 
 	return t, nil
 }
 
+// storeResult writes result as the {key} note, with partial=true metadata for a
+// checkpoint. Earlier unpublished {key} notes are superseded, so later steps see
+// only the newest checkpoint or the final result.
+func (a *Amplify) storeResult(ctx context.Context, t *Thought, unpublished []Note, result AmplifyResult, partial bool) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	metadata := contextKeysMetadata(unpublished)
+	if partial {
+		metadata["partial"] = "true"
+	}
+	if ids := t.unpublishedIDs(a.key); ids != "" {
+		metadata[SupersedesMetadataKey] = ids
+	}
+	return t.SetNote(ctx, a.key, string(resultJSON), noteSource("amplify", a.sourceTag), metadata)
+}
+
+// storePartial records content refined after the last checkpoint when the context
+// is canceled mid-iteration, so callers under a deadline can still use it. The
+// returned error wraps cause.
+func (a *Amplify) storePartial(ctx context.Context, t *Thought, unpublished []Note, content string, iterations int, reasoning []string, cause error) (*Thought, error) {
+	err := fmt.Errorf("amplify: canceled after %d iterations: %w", iterations, cause)
+
+	partial := AmplifyResult{Content: content, Iterations: iterations, Reasoning: reasoning}
+	if setErr := a.storeResult(context.WithoutCancel(ctx), t, unpublished, partial, true); setErr != nil {
+		return t, fmt.Errorf("%w (failed to persist partial result: %v)", err, setErr)
+	}
+	return t, err
+}
+
 // emitFailed emits a step failed event.
 func (a *Amplify) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(a.key),
 		FieldStepType.Field("amplify"),
@@ -239,7 +321,7 @@ func (a *Amplify) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (a *Amplify) Schema() pipz.Node {
-	return pipz.Node{Identity: a.identity, Type: "amplify"}
+	return stepSchema(a.identity, "amplify", true, []string{a.sourceKey}, []string{a.key})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -294,3 +376,70 @@ func (a *Amplify) WithMaxIterations(maxIter int) *Amplify {
 	a.maxIterations = maxIter
 	return a
 }
+
+// WithConvergenceThreshold stops refinement when an iteration's content is at least
+// this similar to the previous iteration's (0.0-1.0, by normalized edit distance).
+// Completed reflects the last completion check. Zero disables convergence detection.
+func (a *Amplify) WithConvergenceThreshold(threshold float32) *Amplify {
+	a.convergenceThreshold = threshold
+	return a
+}
+
+// WithStream delivers each refinement iteration's text to fn as it is generated.
+// With a StreamingProvider, fn receives incremental chunks; otherwise it receives
+// the whole refined text once per iteration. Every iteration restarts the text, so
+// UIs should reset on AmplifyIterationCompleted. The final content is still stored
+// in the {key} note on completion.
+func (a *Amplify) WithStream(fn func(token string)) *Amplify {
+	a.stream = fn
+	return a
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "amplify:<tag>",
+// distinguishing notes from multiple Amplify steps in the same pipeline.
+func (a *Amplify) WithSourceTag(tag string) *Amplify {
+	a.sourceTag = tag
+	return a
+}
+
+// WithLocale asks for the refined content and the completion check's reasoning in
+// the given language, such as "French" or "pt-BR".
+func (a *Amplify) WithLocale(lang string) *Amplify {
+	a.locale = lang
+	return a
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (a *Amplify) WithContextStrategy(strategy ContextStrategy) *Amplify {
+	a.contextStrategy = strategy
+	return a
+}
+
+// textSimilarity returns 1 minus the normalized Levenshtein distance between a and b.
+func textSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
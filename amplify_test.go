@@ -2,6 +2,7 @@ package cogito
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -100,6 +101,46 @@ func TestAmplifyCompletesFirstIteration(t *testing.T) {
 	}
 }
 
+// mockRecordingAmplifyProvider records each prompt and answers like mockAmplifyProvider.
+type mockRecordingAmplifyProvider struct {
+	mockAmplifyProvider
+	prompts []string
+}
+
+func (m *mockRecordingAmplifyProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	return m.mockAmplifyProvider.Call(ctx, messages, temperature)
+}
+
+func TestAmplifyWithLocale(t *testing.T) {
+	provider := &mockRecordingAmplifyProvider{mockAmplifyProvider: mockAmplifyProvider{completionResults: []bool{true}}}
+
+	amplify := NewAmplify("refined_output", "draft", "Improve clarity", "Is the content clear and concise?", 3).
+		WithProvider(provider).
+		WithLocale("French")
+
+	thought := newTestThought("test amplify locale")
+	thought.SetContent(context.Background(), "draft", "Brouillon initial", "initial")
+
+	result, err := amplify.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(provider.prompts) != 2 {
+		t.Fatalf("expected 2 provider calls, got %d", len(provider.prompts))
+	}
+	if !strings.Contains(provider.prompts[0], "Write the output in French") {
+		t.Errorf("expected locale directive in refinement prompt, got %q", provider.prompts[0])
+	}
+	if !strings.Contains(provider.prompts[1], "write reasoning in French") {
+		t.Errorf("expected locale directive in completion prompt, got %q", provider.prompts[1])
+	}
+	if _, err := amplify.Scan(result); err != nil {
+		t.Errorf("expected result to parse with a locale set: %v", err)
+	}
+}
+
 func TestAmplifyMultipleIterations(t *testing.T) {
 	provider := &mockAmplifyProvider{
 		completionResults: []bool{false, false, true}, // completes on third check
@@ -142,6 +183,42 @@ func TestAmplifyMultipleIterations(t *testing.T) {
 	}
 }
 
+func TestAmplifyCheckpointsEachIteration(t *testing.T) {
+	amplify := NewAmplify("refined", "draft", "Improve clarity", "Is the content clear?", 5).
+		WithProvider(&mockAmplifyProvider{completionResults: []bool{false, false, true}})
+
+	thought := newTestThought("test amplify checkpoints")
+	thought.SetContent(context.Background(), "draft", "Initial rough draft content", "initial")
+
+	result, err := amplify.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var notes []Note
+	for _, note := range result.AllNotes() {
+		if note.Key == "refined" {
+			notes = append(notes, note)
+		}
+	}
+	// One checkpoint per iteration, then the final result
+	if len(notes) != 4 {
+		t.Fatalf("expected 3 checkpoints and a final note, got %d", len(notes))
+	}
+	for i, note := range notes[:3] {
+		if note.Metadata["partial"] != "true" {
+			t.Errorf("checkpoint %d: expected partial=true metadata", i)
+		}
+	}
+	final := notes[3]
+	if final.Metadata["partial"] != "" {
+		t.Error("expected final note not to be partial")
+	}
+	if got := strings.Count(final.Metadata[SupersedesMetadataKey], ",") + 1; got != 3 {
+		t.Errorf("expected final note to supersede 3 checkpoints, got %q", final.Metadata[SupersedesMetadataKey])
+	}
+}
+
 func TestAmplifyMaxIterationsReached(t *testing.T) {
 	provider := &mockAmplifyProvider{
 		completionResults: []bool{false, false, false}, // never completes
@@ -334,3 +411,203 @@ func TestAmplifyReasoningPreserved(t *testing.T) {
 		t.Error("expected reasoning to be preserved")
 	}
 }
+
+func TestAmplifyConvergenceThreshold(t *testing.T) {
+	// Refinements differ only by iteration number, so they plateau after the first
+	provider := &mockAmplifyProvider{
+		completionResults: []bool{false, false, false, false, false},
+	}
+
+	amplify := NewAmplify(
+		"refined_output",
+		"draft",
+		"Improve clarity",
+		"Is the content clear and concise?",
+		5,
+	).WithProvider(provider).WithConvergenceThreshold(0.9)
+
+	thought := newTestThought("test amplify convergence")
+	thought.SetContent(context.Background(), "draft", "Initial rough draft content", "initial")
+
+	result, err := amplify.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, err := amplify.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	if output.Iterations != 2 {
+		t.Errorf("expected 2 iterations before convergence, got %d", output.Iterations)
+	}
+	if !output.Converged {
+		t.Error("expected converged to be true")
+	}
+	if output.Completed {
+		t.Error("expected completed to reflect the last check (false)")
+	}
+}
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"same", "same", 1},
+		{"abc", "", 0},
+		{"kitten", "sitting", 1 - 3.0/7.0},
+	}
+
+	for _, tt := range tests {
+		if got := textSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("textSimilarity(%q, %q) = %f, want %f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// cancelingAmplifyProvider refines normally but cancels the context on the
+// second refinement, simulating a deadline hit mid-Amplify.
+type cancelingAmplifyProvider struct {
+	cancel     context.CancelFunc
+	refinement int
+}
+
+func (m *cancelingAmplifyProvider) Call(ctx context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	if strings.Contains(messages[len(messages)-1].Content, "Transform:") {
+		m.refinement++
+		if m.refinement == 2 {
+			m.cancel()
+			return nil, ctx.Err()
+		}
+		return &zyn.ProviderResponse{
+			Content: `{"output": "First refinement", "confidence": 0.9, "changes": ["Tightened"], "reasoning": ["Refined"]}`,
+		}, nil
+	}
+	return &zyn.ProviderResponse{
+		Content: `{"decision": false, "confidence": 0.8, "reasoning": ["Not yet concise"]}`,
+	}, nil
+}
+
+func (m *cancelingAmplifyProvider) Name() string {
+	return "mock-canceling-amplify"
+}
+
+func TestAmplifyPartialResultOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	amplify := NewAmplify("refined", "draft", "Make it concise", "The text is concise", 5).
+		WithProvider(&cancelingAmplifyProvider{cancel: cancel})
+
+	thought := newTestThought("refine")
+	thought.SetContent(context.Background(), "draft", "Original draft", "initial")
+
+	result, err := amplify.Process(ctx, thought)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	output, scanErr := amplify.Scan(result)
+	if scanErr != nil {
+		t.Fatalf("expected partial result to be stored: %v", scanErr)
+	}
+	if output.Completed {
+		t.Error("expected partial result not to be completed")
+	}
+	if output.Content != "First refinement" {
+		t.Errorf("expected last good content, got %q", output.Content)
+	}
+	if output.Iterations != 1 {
+		t.Errorf("expected 1 completed iteration, got %d", output.Iterations)
+	}
+	if v, _ := result.GetMetadata("refined", "partial"); v != "true" {
+		t.Errorf("expected partial=true metadata, got %q", v)
+	}
+}
+
+// mockStreamingAmplifyProvider streams refinement responses in small chunks.
+type mockStreamingAmplifyProvider struct {
+	mockAmplifyProvider
+	streamCalls int
+}
+
+func (m *mockStreamingAmplifyProvider) CallStream(ctx context.Context, messages []zyn.Message, temperature float32, onChunk func(string)) (*zyn.ProviderResponse, error) {
+	m.streamCalls++
+	resp, err := m.Call(ctx, messages, temperature)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(resp.Content); i += 5 {
+		end := i + 5
+		if end > len(resp.Content) {
+			end = len(resp.Content)
+		}
+		onChunk(resp.Content[i:end])
+	}
+	return resp, nil
+}
+
+func TestAmplifyWithStream(t *testing.T) {
+	t.Run("streaming provider", func(t *testing.T) {
+		provider := &mockStreamingAmplifyProvider{
+			mockAmplifyProvider: mockAmplifyProvider{completionResults: []bool{true}},
+		}
+
+		var tokens []string
+		amplify := NewAmplify("refined", "draft", "Improve clarity", "Is it clear?", 3).
+			WithProvider(provider).
+			WithStream(func(token string) { tokens = append(tokens, token) })
+
+		thought := newTestThought("test amplify stream")
+		thought.SetContent(context.Background(), "draft", "Rough draft", "initial")
+
+		result, err := amplify.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if provider.streamCalls != 1 {
+			t.Errorf("expected only the refinement call to stream, got %d", provider.streamCalls)
+		}
+		if len(tokens) < 2 {
+			t.Errorf("expected incremental tokens, got %d", len(tokens))
+		}
+
+		resp, err := amplify.Scan(result)
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		if strings.Join(tokens, "") != resp.Content {
+			t.Errorf("expected streamed text %q to match stored content %q", strings.Join(tokens, ""), resp.Content)
+		}
+	})
+
+	t.Run("non-streaming provider", func(t *testing.T) {
+		provider := &mockAmplifyProvider{completionResults: []bool{false, true}}
+
+		var tokens []string
+		amplify := NewAmplify("refined", "draft", "Improve clarity", "Is it clear?", 3).
+			WithProvider(provider).
+			WithStream(func(token string) { tokens = append(tokens, token) })
+
+		thought := newTestThought("test amplify stream fallback")
+		thought.SetContent(context.Background(), "draft", "Rough draft", "initial")
+
+		result, err := amplify.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// One whole delivery per iteration
+		if len(tokens) != 2 {
+			t.Fatalf("expected 2 deliveries, got %d", len(tokens))
+		}
+		resp, _ := amplify.Scan(result)
+		if tokens[1] != resp.Content {
+			t.Errorf("expected final delivery to match stored content, got %q", tokens[1])
+		}
+	})
+}
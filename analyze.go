@@ -11,6 +11,17 @@ import (
 	"github.com/zoobzio/zyn"
 )
 
+// ValidationPolicy controls how Analyze handles extracted data that fails Validate.
+type ValidationPolicy int
+
+const (
+	// ValidationFail aborts the step when the extracted data fails validation. This is the default.
+	ValidationFail ValidationPolicy = iota
+	// ValidationWarn keeps the partial data, writes a {key}_validation_error note, emits
+	// AnalyzeValidationFailed at Warn, and continues.
+	ValidationWarn
+)
+
 // Analyze is a structured data extraction primitive that implements pipz.Chainable[*Thought].
 // It extracts typed data from unstructured input using generics.
 type Analyze[T zyn.Validator] struct {
@@ -19,10 +30,19 @@ type Analyze[T zyn.Validator] struct {
 	what                     string
 	summaryKey               string
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
 	provider                 Provider
 	temperature              float32
+	structuredOutput         bool
+	merge                    bool
+	validationPolicy         ValidationPolicy
 }
 
 // NewAnalyze creates a new structured data extraction primitive with introspection enabled by default.
@@ -33,7 +53,9 @@ type Analyze[T zyn.Validator] struct {
 //
 // Output Notes:
 //   - {key}: JSON-serialized T (the extracted data)
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
+//   - {key}_validation_error: Why the data failed validation (ValidationWarn only)
 //
 // Example:
 //
@@ -49,34 +71,76 @@ type Analyze[T zyn.Validator] struct {
 //	fmt.Println(data.Severity, data.Component)
 func NewAnalyze[T zyn.Validator](key, what string) *Analyze[T] {
 	return &Analyze[T]{
-		identity:         pipz.NewIdentity(key, "Structured data extraction primitive"),
-		key:              key,
-		what:             what,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Structured data extraction primitive"),
+		key:                  key,
+		what:                 what,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
+// NewAnalyzeMerge creates an extraction primitive that enriches an existing {key} note
+// instead of replacing it. The stored value is shown to the model alongside new
+// context, and the fields the response includes are merged over it, so fields a
+// first pass could not determine can be filled in later in the chain. The merged
+// value is what gets validated.
+//
+// Example:
+//
+//	enrich := cogito.NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata")
+//	result, _ := enrich.Process(ctx, thought) // keeps fields found by an earlier NewAnalyze
+func NewAnalyzeMerge[T zyn.Validator](key, what string) *Analyze[T] {
+	return NewAnalyze[T](key, what).WithMerge()
+}
+
 // Process implements pipz.Chainable[*Thought].
 func (a *Analyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, a.provider)
+	provider, err := ResolveProviderForStep(ctx, "analyze", a.provider)
 	if err != nil {
 		return t, fmt.Errorf("analyze: %w", err)
 	}
 
+	// Constrain extraction to the schema of T where the provider supports it
+	extractProvider := provider
+	if a.structuredOutput {
+		if sp, ok := provider.(StructuredOutputProvider); ok {
+			schema, err := generateJSONSchema[T]()
+			if err != nil {
+				return t, fmt.Errorf("analyze: %w", err)
+			}
+			extractProvider = &schemaProvider{StructuredOutputProvider: sp, schema: schema}
+		}
+	}
+
+	// Keep the raw response to tell a validation rejection from a parse or provider
+	// failure, and which fields the model returned for a merge
+	recorder := &responseRecorder{Provider: extractProvider}
+	extractProvider = recorder
+
 	// Create zyn extract synapse
-	extractSynapse, err := zyn.Extract[T](a.what, provider)
+	extractSynapse, err := zyn.Extract[T](a.what, extractProvider)
 	if err != nil {
 		return t, fmt.Errorf("analyze: failed to create extract synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := a.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
+	// In merge mode, show the model what is already known
+	var existing *Note
+	if a.merge {
+		if note, ok := t.GetNote(a.key); ok {
+			existing = &note
+			noteContext = fmt.Sprintf("Current %s (fill in missing fields, update any the context below changes):\n%s\n\n%s", a.what, note.Content, noteContext)
+		}
+	}
+
 	// Emit step started
 	capitan.Emit(ctx, StepStarted,
 		FieldTraceID.Field(t.TraceID),
@@ -92,14 +156,37 @@ func (a *Analyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error)
 		reasoningTemp = a.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, a.key, "analyze", noteContext, len(unpublished))
+
 	// PHASE 1: REASONING - Extract structured data
 	extracted, err := extractSynapse.FireWithInput(ctx, t.Session, zyn.ExtractionInput{
 		Text:        noteContext,
 		Temperature: reasoningTemp,
 	})
+	var validationErr error
 	if err != nil {
-		a.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("analyze: extract synapse execution failed: %w", err)
+		// A merge is validated as a whole below, so the stored value may complete it
+		keep := a.validationPolicy == ValidationWarn || existing != nil
+		if !keep || !rejectedByValidation[T](recorder.response) {
+			a.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("analyze: extract synapse execution failed: %w", err)
+		}
+		validationErr = extracted.Validate()
+	}
+
+	metadata := contextKeysMetadata(unpublished)
+	if existing != nil {
+		extracted, err = mergeExtracted[T](existing.Content, recorder.response)
+		if err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("analyze: failed to merge into existing note: %w", err)
+		}
+		metadata["merged"] = "true"
+		validationErr = extracted.Validate()
+		if validationErr != nil && a.validationPolicy != ValidationWarn {
+			a.emitFailed(ctx, t, start, validationErr)
+			return t, fmt.Errorf("analyze: merged data failed validation: %w", validationErr)
+		}
 	}
 
 	// Store extracted data as JSON
@@ -108,10 +195,23 @@ func (a *Analyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error)
 		a.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("analyze: failed to marshal extracted data: %w", err)
 	}
-	if err := t.SetContent(ctx, a.key, string(extractedJSON), "analyze"); err != nil {
+	if err := t.SetNote(ctx, a.key, string(extractedJSON), noteSource("analyze", a.sourceTag), metadata); err != nil {
 		a.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("analyze: failed to persist note: %w", err)
 	}
+	if validationErr != nil {
+		if err := a.recordValidationError(ctx, t, validationErr); err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+	// zyn does not record rejected responses in the session, so there is no raw response to capture
+	if a.captureRaw && validationErr == nil {
+		if err := captureRawResponse(ctx, t, "analyze", a.key, a.sourceTag); err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
 
 	// PHASE 2: INTROSPECTION - Semantic summary (optional)
 	if a.useIntrospection {
@@ -121,8 +221,11 @@ func (a *Analyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error)
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("analyze: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -131,12 +234,80 @@ func (a *Analyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error)
 		FieldStepName.Field(a.key),
 		FieldStepType.Field("analyze"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
 }
 
+// rejectedByValidation reports whether a failed extraction's response parsed as T
+// and was rejected only by Validate. zyn returns the parsed value alongside a
+// validation error. A parse error or a provider failure leaves a partial or zero
+// value, which is never kept.
+func rejectedByValidation[T zyn.Validator](response string) bool {
+	var parsed T
+	if err := json.Unmarshal([]byte(response), &parsed); err != nil {
+		return false
+	}
+	return parsed.Validate() != nil
+}
+
+// recordValidationError writes the {key}_validation_error note and emits
+// AnalyzeValidationFailed so the partial data can be flagged for review.
+func (a *Analyze[T]) recordValidationError(ctx context.Context, t *Thought, validationErr error) error {
+	capitan.Warn(ctx, AnalyzeValidationFailed,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(a.key),
+		FieldNoteKey.Field(a.key),
+		FieldError.Field(validationErr),
+	)
+	if err := t.SetNote(ctx, a.key+"_validation_error", validationErr.Error(), noteSource("analyze", a.sourceTag), nil); err != nil {
+		return fmt.Errorf("analyze: failed to persist validation error: %w", err)
+	}
+	return nil
+}
+
+// mergeExtracted overlays the fields present in the raw response onto the value
+// stored in existing, so a returned false, 0 or "" still updates a field. Fields the
+// model left out or returned as null keep their stored value. Nested objects are
+// merged field by field.
+func mergeExtracted[T any](existing, response string) (T, error) {
+	var merged T
+	if err := json.Unmarshal([]byte(existing), &merged); err != nil {
+		return merged, err
+	}
+
+	var fields any
+	if err := json.Unmarshal([]byte(response), &fields); err != nil {
+		return merged, err
+	}
+	update, err := json.Marshal(pruneNullFields(fields))
+	if err != nil {
+		return merged, err
+	}
+	if err := json.Unmarshal(update, &merged); err != nil {
+		return merged, err
+	}
+	return merged, nil
+}
+
+// pruneNullFields removes null values from decoded JSON objects, recursively, so
+// they do not clear known values.
+func pruneNullFields(v any) any {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	for k, field := range obj {
+		if field == nil {
+			delete(obj, k)
+			continue
+		}
+		obj[k] = pruneNullFields(field)
+	}
+	return obj
+}
+
 // runIntrospection executes the transform synapse for semantic summary.
 func (a *Analyze[T]) runIntrospection(ctx context.Context, t *Thought, extracted T, originalNotes []Note, provider Provider) error {
 	return runIntrospection(ctx, t, provider, a.buildIntrospectionInput(extracted, originalNotes), introspectionConfig{
@@ -145,6 +316,10 @@ func (a *Analyze[T]) runIntrospection(ctx context.Context, t *Thought, extracted
 		summaryKey:               a.summaryKey,
 		introspectionTemperature: a.introspectionTemperature,
 		synapsePrompt:            "Synthesize extracted data into context for next reasoning step",
+		style:                    a.introspectionStyle,
+		sourceTag:                a.sourceTag,
+		locale:                   a.locale,
+		sessionContext:           a.sessionIntrospection,
 	})
 }
 
@@ -167,7 +342,7 @@ func (a *Analyze[T]) buildIntrospectionInput(extracted T, originalNotes []Note)
 
 // emitFailed emits a step failed event.
 func (a *Analyze[T]) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(a.key),
 		FieldStepType.Field("analyze"),
@@ -183,7 +358,7 @@ func (a *Analyze[T]) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (a *Analyze[T]) Schema() pipz.Node {
-	return pipz.Node{Identity: a.identity, Type: "analyze"}
+	return reasoningSchema(a.identity, "analyze", nil, a.key, a.summaryKey, a.useIntrospection, a.captureRaw)
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -226,6 +401,12 @@ func (a *Analyze[T]) WithIntrospection() *Analyze[T] {
 	return a
 }
 
+// WithSessionIntrospection sends introspection only the extracted data; see DefaultSessionIntrospection.
+func (a *Analyze[T]) WithSessionIntrospection() *Analyze[T] {
+	a.sessionIntrospection = true
+	return a
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (a *Analyze[T]) WithSummaryKey(key string) *Analyze[T] {
 	a.summaryKey = key
@@ -243,3 +424,59 @@ func (a *Analyze[T]) WithIntrospectionTemperature(temp float32) *Analyze[T] {
 	a.introspectionTemperature = temp
 	return a
 }
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (a *Analyze[T]) WithIntrospectionStyle(style string) *Analyze[T] {
+	a.introspectionStyle = style
+	return a
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (a *Analyze[T]) WithRawCapture() *Analyze[T] {
+	a.captureRaw = true
+	return a
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "analyze:<tag>",
+// distinguishing notes from multiple Analyze steps in the same pipeline.
+func (a *Analyze[T]) WithSourceTag(tag string) *Analyze[T] {
+	a.sourceTag = tag
+	return a
+}
+
+// WithLocale asks for introspection summaries in the given language, such as
+// "French" or "pt-BR". Extracted values are returned as found in the input.
+func (a *Analyze[T]) WithLocale(lang string) *Analyze[T] {
+	a.locale = lang
+	return a
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (a *Analyze[T]) WithContextStrategy(strategy ContextStrategy) *Analyze[T] {
+	a.contextStrategy = strategy
+	return a
+}
+
+// WithMerge enriches an existing {key} note rather than overwriting it. See NewAnalyzeMerge.
+func (a *Analyze[T]) WithMerge() *Analyze[T] {
+	a.merge = true
+	return a
+}
+
+// WithValidationPolicy sets how extracted data that fails Validate is handled.
+// ValidationWarn keeps the partial data and flags it with a {key}_validation_error
+// note, for best-effort pipelines whose later steps can cope with incomplete data.
+func (a *Analyze[T]) WithValidationPolicy(policy ValidationPolicy) *Analyze[T] {
+	a.validationPolicy = policy
+	return a
+}
+
+// WithStructuredOutput constrains extraction to a JSON schema generated from T.
+// The schema is passed to providers implementing StructuredOutputProvider;
+// other providers fall back to prompt-based formatting. Introspection is unaffected.
+func (a *Analyze[T]) WithStructuredOutput() *Analyze[T] {
+	a.structuredOutput = true
+	return a
+}
@@ -0,0 +1,333 @@
+package cogito
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// listEnvelope wraps a list extraction so the synapse schema is a JSON object.
+// The envelope never leaves AnalyzeList; notes and Scan expose the bare []T.
+type listEnvelope[T zyn.Validator] struct {
+	Items []T `json:"items"`
+}
+
+// Validate checks every extracted element.
+func (l listEnvelope[T]) Validate() error {
+	for i, item := range l.Items {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AnalyzeList is a list extraction primitive that implements pipz.Chainable[*Thought].
+// It extracts every occurrence of a typed item from unstructured input.
+type AnalyzeList[T zyn.Validator] struct {
+	identity                 pipz.Identity
+	key                      string
+	what                     string
+	summaryKey               string
+	useIntrospection         bool
+	sessionIntrospection     bool
+	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
+	introspectionTemperature float32
+	introspectionStyle       string
+	provider                 Provider
+	temperature              float32
+	structuredOutput         bool
+}
+
+// NewAnalyzeList creates a new list extraction primitive with introspection enabled by default.
+//
+// The primitive uses two zyn synapses:
+//  1. Extract synapse: Pulls out all items of type T, each validated
+//  2. Transform synapse: Synthesizes a semantic summary for context accumulation
+//
+// Output Notes:
+//   - {key}: JSON array of T (empty when nothing was found)
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled)
+//
+// Example:
+//
+//	type ActionItem struct {
+//	    Task  string `json:"task"`
+//	    Owner string `json:"owner"`
+//	}
+//	func (a ActionItem) Validate() error { return nil }
+//
+//	step := cogito.NewAnalyzeList[ActionItem]("action_items", "action items from the meeting notes")
+//	result, _ := step.Process(ctx, thought)
+//	items, _ := step.Scan(result)
+//	for _, item := range items {
+//	    fmt.Println(item.Owner, item.Task)
+//	}
+func NewAnalyzeList[T zyn.Validator](key, what string) *AnalyzeList[T] {
+	return &AnalyzeList[T]{
+		identity:             pipz.NewIdentity(key, "List extraction primitive"),
+		key:                  key,
+		what:                 what,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (a *AnalyzeList[T]) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	// Resolve provider
+	provider, err := ResolveProviderForStep(ctx, "analyze", a.provider)
+	if err != nil {
+		return t, fmt.Errorf("analyze: %w", err)
+	}
+
+	// Constrain extraction to the schema of the list where the provider supports it
+	extractProvider := provider
+	if a.structuredOutput {
+		if sp, ok := provider.(StructuredOutputProvider); ok {
+			schema, err := generateJSONSchema[listEnvelope[T]]()
+			if err != nil {
+				return t, fmt.Errorf("analyze: %w", err)
+			}
+			extractProvider = &schemaProvider{StructuredOutputProvider: sp, schema: schema}
+		}
+	}
+
+	// Create zyn extract synapse
+	extractSynapse, err := zyn.Extract[listEnvelope[T]]("all "+a.what+" as items", extractProvider)
+	if err != nil {
+		return t, fmt.Errorf("analyze: failed to create extract synapse: %w", err)
+	}
+
+	// Get unpublished notes
+	unpublished := a.contextStrategy.apply(t.GetUnpublishedNotes())
+	noteContext := RenderNotesToContext(unpublished)
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(a.key),
+		FieldStepType.Field("analyze"),
+		FieldUnpublishedCount.Field(len(unpublished)),
+		FieldTemperature.Field(a.temperature),
+	)
+
+	// Determine reasoning temperature
+	reasoningTemp := a.temperature
+	if a.reasoningTemperature != 0 {
+		reasoningTemp = a.reasoningTemperature
+	}
+
+	emitContextRendered(ctx, t, a.key, "analyze", noteContext, len(unpublished))
+
+	// PHASE 1: REASONING - Extract the list
+	extracted, err := extractSynapse.FireWithInput(ctx, t.Session, zyn.ExtractionInput{
+		Text:        noteContext,
+		Temperature: reasoningTemp,
+	})
+	if err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("analyze: extract synapse execution failed: %w", err)
+	}
+	items := extracted.Items
+	if items == nil {
+		items = []T{}
+	}
+
+	// Store extracted items as a JSON array
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("analyze: failed to marshal extracted items: %w", err)
+	}
+	if err := t.SetNote(ctx, a.key, string(itemsJSON), noteSource("analyze", a.sourceTag), contextKeysMetadata(unpublished)); err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("analyze: failed to persist note: %w", err)
+	}
+	if a.captureRaw {
+		if err := captureRawResponse(ctx, t, "analyze", a.key, a.sourceTag); err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+
+	// PHASE 2: INTROSPECTION - Semantic summary (optional)
+	if a.useIntrospection {
+		if err := a.runIntrospection(ctx, t, itemsJSON, len(items), unpublished, provider); err != nil {
+			a.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		a.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("analyze: %w", err)
+	}
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(a.key),
+		FieldStepType.Field("analyze"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldNoteCount.Field(t.NoteCount()),
+	)
+
+	return t, nil
+}
+
+// runIntrospection executes the transform synapse for semantic summary.
+func (a *AnalyzeList[T]) runIntrospection(ctx context.Context, t *Thought, itemsJSON []byte, count int, originalNotes []Note, provider Provider) error {
+	return runIntrospection(ctx, t, provider, zyn.TransformInput{
+		Text:    fmt.Sprintf("Extracted %d items:\n%s", count, string(itemsJSON)),
+		Context: RenderNotesToContext(originalNotes),
+		Style:   "Synthesize these extracted items into rich semantic context for the next reasoning step. Focus on patterns across items, notable outliers, and actionable insights. Be concise but comprehensive.",
+	}, introspectionConfig{
+		stepType:                 "analyze",
+		key:                      a.key,
+		summaryKey:               a.summaryKey,
+		introspectionTemperature: a.introspectionTemperature,
+		synapsePrompt:            "Synthesize extracted items into context for next reasoning step",
+		style:                    a.introspectionStyle,
+		sourceTag:                a.sourceTag,
+		locale:                   a.locale,
+		sessionContext:           a.sessionIntrospection,
+	})
+}
+
+// emitFailed emits a step failed event.
+func (a *AnalyzeList[T]) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(a.key),
+		FieldStepType.Field("analyze"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (a *AnalyzeList[T]) Identity() pipz.Identity {
+	return a.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (a *AnalyzeList[T]) Schema() pipz.Node {
+	return reasoningSchema(a.identity, "analyze", nil, a.key, a.summaryKey, a.useIntrospection, a.captureRaw)
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (a *AnalyzeList[T]) Close() error {
+	return nil
+}
+
+// Scan retrieves the typed extracted items from a thought.
+func (a *AnalyzeList[T]) Scan(t *Thought) ([]T, error) {
+	content, err := t.GetContent(a.key)
+	if err != nil {
+		return nil, fmt.Errorf("analyze scan: %w", err)
+	}
+	var items []T
+	if err := json.Unmarshal([]byte(content), &items); err != nil {
+		return nil, fmt.Errorf("analyze scan: failed to unmarshal items: %w", err)
+	}
+	return items, nil
+}
+
+// Builder methods
+
+// WithProvider sets the provider for this step.
+func (a *AnalyzeList[T]) WithProvider(p Provider) *AnalyzeList[T] {
+	a.provider = p
+	return a
+}
+
+// WithTemperature sets the default temperature for this step.
+func (a *AnalyzeList[T]) WithTemperature(temp float32) *AnalyzeList[T] {
+	a.temperature = temp
+	return a
+}
+
+// WithIntrospection enables the introspection phase.
+func (a *AnalyzeList[T]) WithIntrospection() *AnalyzeList[T] {
+	a.useIntrospection = true
+	return a
+}
+
+// WithSessionIntrospection sends introspection only the extracted items; see DefaultSessionIntrospection.
+func (a *AnalyzeList[T]) WithSessionIntrospection() *AnalyzeList[T] {
+	a.sessionIntrospection = true
+	return a
+}
+
+// WithSummaryKey sets a custom key for the introspection summary note.
+func (a *AnalyzeList[T]) WithSummaryKey(key string) *AnalyzeList[T] {
+	a.summaryKey = key
+	return a
+}
+
+// WithReasoningTemperature sets the temperature for the reasoning phase.
+func (a *AnalyzeList[T]) WithReasoningTemperature(temp float32) *AnalyzeList[T] {
+	a.reasoningTemperature = temp
+	return a
+}
+
+// WithIntrospectionTemperature sets the temperature for the introspection phase.
+func (a *AnalyzeList[T]) WithIntrospectionTemperature(temp float32) *AnalyzeList[T] {
+	a.introspectionTemperature = temp
+	return a
+}
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (a *AnalyzeList[T]) WithIntrospectionStyle(style string) *AnalyzeList[T] {
+	a.introspectionStyle = style
+	return a
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note.
+func (a *AnalyzeList[T]) WithRawCapture() *AnalyzeList[T] {
+	a.captureRaw = true
+	return a
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "analyze:<tag>".
+func (a *AnalyzeList[T]) WithSourceTag(tag string) *AnalyzeList[T] {
+	a.sourceTag = tag
+	return a
+}
+
+// WithLocale asks for introspection summaries in the given language, such as
+// "French" or "pt-BR". Extracted values are returned as found in the input.
+func (a *AnalyzeList[T]) WithLocale(lang string) *AnalyzeList[T] {
+	a.locale = lang
+	return a
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (a *AnalyzeList[T]) WithContextStrategy(strategy ContextStrategy) *AnalyzeList[T] {
+	a.contextStrategy = strategy
+	return a
+}
+
+// WithStructuredOutput constrains extraction to a JSON schema generated from the list of T.
+// Providers not implementing StructuredOutputProvider fall back to prompt-based formatting.
+func (a *AnalyzeList[T]) WithStructuredOutput() *AnalyzeList[T] {
+	a.structuredOutput = true
+	return a
+}
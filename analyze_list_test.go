@@ -0,0 +1,114 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+// ActionItem is a list extraction test type.
+type ActionItem struct {
+	Task  string `json:"task"`
+	Owner string `json:"owner"`
+}
+
+func (a ActionItem) Validate() error {
+	if a.Task == "" {
+		return fmt.Errorf("task required")
+	}
+	return nil
+}
+
+// mockAnalyzeListProvider returns a fixed list extraction and records extraction prompts.
+type mockAnalyzeListProvider struct {
+	response string
+	prompts  []string
+}
+
+func (m *mockAnalyzeListProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	last := messages[len(messages)-1].Content
+	if strings.Contains(last, "Transform:") {
+		return &zyn.ProviderResponse{
+			Content: `{"output": "Two follow-ups owned by the platform team", "confidence": 0.9, "changes": [], "reasoning": []}`,
+		}, nil
+	}
+	m.prompts = append(m.prompts, last)
+	return &zyn.ProviderResponse{Content: m.response}, nil
+}
+
+func (m *mockAnalyzeListProvider) Name() string {
+	return "mock"
+}
+
+func TestAnalyzeList(t *testing.T) {
+	provider := &mockAnalyzeListProvider{
+		response: `{"items": [{"task": "Rotate credentials", "owner": "alice"}, {"task": "Update runbook", "owner": "bob"}]}`,
+	}
+
+	thought := newTestThought("test list extraction")
+	thought.SetContent(context.Background(), "meeting", "Alice rotates credentials, Bob updates the runbook.", "input")
+
+	step := NewAnalyzeList[ActionItem]("action_items", "action items").WithProvider(provider).WithIntrospection()
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(items) != 2 || items[0].Owner != "alice" || items[1].Task != "Update runbook" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+
+	content, _ := result.GetContent("action_items")
+	if !strings.HasPrefix(content, "[") {
+		t.Errorf("expected note to hold a bare JSON array, got %s", content)
+	}
+	if _, err := result.GetContent("action_items_summary"); err != nil {
+		t.Errorf("expected introspection summary: %v", err)
+	}
+	if len(provider.prompts) != 1 || !strings.Contains(provider.prompts[0], "Extract all action items") {
+		t.Errorf("expected list extraction prompt, got %v", provider.prompts)
+	}
+}
+
+func TestAnalyzeListEmpty(t *testing.T) {
+	provider := &mockAnalyzeListProvider{response: `{"items": []}`}
+
+	thought := newTestThought("test empty list")
+	thought.SetContent(context.Background(), "meeting", "Status update only, no follow-ups.", "input")
+
+	step := NewAnalyzeList[ActionItem]("action_items", "action items").WithProvider(provider)
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := result.GetContent("action_items")
+	if content != "[]" {
+		t.Errorf("expected empty JSON array, got %s", content)
+	}
+}
+
+func TestAnalyzeListValidatesEachItem(t *testing.T) {
+	provider := &mockAnalyzeListProvider{
+		response: `{"items": [{"task": "Rotate credentials", "owner": "alice"}, {"task": "", "owner": "bob"}]}`,
+	}
+
+	thought := newTestThought("test invalid item")
+	thought.SetContent(context.Background(), "meeting", "Alice rotates credentials, Bob does something.", "input")
+
+	step := NewAnalyzeList[ActionItem]("action_items", "action items").WithProvider(provider)
+	result, err := step.Process(context.Background(), thought)
+	if err == nil {
+		t.Fatal("expected validation error for item without task")
+	}
+	if _, err := result.GetContent("action_items"); err == nil {
+		t.Error("expected no note when validation fails")
+	}
+}
@@ -340,3 +340,315 @@ func TestAnalyzeBuilderComposition(t *testing.T) {
 		t.Errorf("expected 2 provider calls, got %d", provider.callCount)
 	}
 }
+
+// mockStructuredProvider records schemas passed through CallWithSchema.
+type mockStructuredProvider struct {
+	mockAnalyzeProvider
+	schemas []string
+}
+
+func (m *mockStructuredProvider) CallWithSchema(ctx context.Context, messages []zyn.Message, temperature float32, schema string) (*zyn.ProviderResponse, error) {
+	m.schemas = append(m.schemas, schema)
+	return m.Call(ctx, messages, temperature)
+}
+
+func TestAnalyzeWithStructuredOutput(t *testing.T) {
+	provider := &mockStructuredProvider{}
+
+	step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").
+		WithProvider(provider).
+		WithIntrospection().
+		WithStructuredOutput()
+
+	thought := newTestThought("test structured output")
+	thought.SetContent(context.Background(), "ticket_text", "URGENT: Login broken", "initial")
+
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the extraction call is constrained, introspection is not
+	if len(provider.schemas) != 1 {
+		t.Fatalf("expected 1 schema-constrained call, got %d", len(provider.schemas))
+	}
+	if provider.callCount != 2 {
+		t.Errorf("expected 2 provider calls, got %d", provider.callCount)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(provider.schemas[0]), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	props, ok := schema["properties"].(map[string]any)
+	if !ok {
+		t.Fatal("expected properties in schema")
+	}
+	for _, field := range []string{"severity", "component", "user_tier"} {
+		if _, ok := props[field]; !ok {
+			t.Errorf("expected %q in schema properties", field)
+		}
+	}
+
+	data, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if data.Severity != "high" {
+		t.Errorf("expected severity 'high', got %q", data.Severity)
+	}
+}
+
+func TestAnalyzeStructuredOutputFallback(t *testing.T) {
+	// Providers without structured output support use the prompt-based path
+	provider := &mockAnalyzeProvider{}
+
+	step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").
+		WithProvider(provider).
+		WithStructuredOutput()
+
+	thought := newTestThought("test fallback")
+	thought.SetContent(context.Background(), "ticket_text", "URGENT: Login broken", "initial")
+
+	if _, err := step.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("expected 1 provider call, got %d", provider.callCount)
+	}
+}
+
+// mockPartialExtractProvider returns a partial extraction and records the prompt.
+type mockPartialExtractProvider struct {
+	prompt string
+}
+
+func (m *mockPartialExtractProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.prompt = messages[len(messages)-1].Content
+	return &zyn.ProviderResponse{Content: `{"severity": "critical", "component": null, "user_tier": "enterprise"}`}, nil
+}
+
+func (m *mockPartialExtractProvider) Name() string {
+	return "mock-partial"
+}
+
+func TestAnalyzeMerge(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test merge")
+	thought.SetContent(ctx, "ticket_data", `{"severity": "high", "component": "authentication", "user_tier": ""}`, "analyze")
+	thought.SetContent(ctx, "update", "Customer confirmed they are on the enterprise plan; outage is total", "user")
+
+	provider := &mockPartialExtractProvider{}
+	step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").WithProvider(provider)
+
+	result, err := step.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(provider.prompt, `"component": "authentication"`) {
+		t.Error("expected existing value in extraction prompt")
+	}
+
+	data, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if data.Severity != "critical" {
+		t.Errorf("expected severity updated to critical, got %q", data.Severity)
+	}
+	if data.Component != "authentication" {
+		t.Errorf("expected component preserved, got %q", data.Component)
+	}
+	if data.UserTier != "enterprise" {
+		t.Errorf("expected user tier filled in, got %q", data.UserTier)
+	}
+	if merged, _ := result.GetMetadata("ticket_data", "merged"); merged != "true" {
+		t.Error("expected merged metadata on the note")
+	}
+}
+
+func TestAnalyzeMergeWithoutExistingNote(t *testing.T) {
+	thought := newTestThought("test merge first pass")
+	thought.SetContent(context.Background(), "ticket", "Login broken", "user")
+
+	step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").WithProvider(&mockPartialExtractProvider{})
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := step.Scan(result)
+	if data.Severity != "critical" || data.UserTier != "enterprise" {
+		t.Errorf("expected plain extraction on first pass, got %+v", data)
+	}
+	if _, err := result.GetMetadata("ticket_data", "merged"); err == nil {
+		t.Error("expected no merged metadata without an existing note")
+	}
+}
+
+func TestAnalyzeMergeValidation(t *testing.T) {
+	existing := `{"severity": "high", "component": "authentication", "user_tier": "free"}`
+
+	t.Run("stored value completes the extraction", func(t *testing.T) {
+		thought := newTestThought("test merge validation")
+		thought.SetContent(context.Background(), "ticket_data", existing, "analyze")
+
+		// Invalid alone: severity is missing from the response
+		step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockStaticProvider{content: `{"component": "billing"}`})
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := step.Scan(result)
+		if data.Severity != "high" || data.Component != "billing" {
+			t.Errorf("expected stored severity with updated component, got %+v", data)
+		}
+	})
+
+	t.Run("returned empty value overwrites", func(t *testing.T) {
+		thought := newTestThought("test merge validation")
+		thought.SetContent(context.Background(), "ticket_data", existing, "analyze")
+
+		step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockStaticProvider{content: `{"user_tier": ""}`})
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := step.Scan(result)
+		if data.UserTier != "" || data.Component != "authentication" {
+			t.Errorf("expected user tier cleared and component kept, got %+v", data)
+		}
+	})
+
+	t.Run("invalid merge fails by default", func(t *testing.T) {
+		thought := newTestThought("test merge validation")
+		thought.SetContent(context.Background(), "ticket_data", existing, "analyze")
+
+		step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockStaticProvider{content: `{"severity": ""}`})
+		result, err := step.Process(context.Background(), thought)
+		if err == nil {
+			t.Fatal("expected merged data failing validation to fail the step")
+		}
+		if note, _ := result.GetNote("ticket_data"); note.Content != existing {
+			t.Errorf("expected stored value untouched, got %s", note.Content)
+		}
+	})
+
+	t.Run("invalid merge kept under warn", func(t *testing.T) {
+		thought := newTestThought("test merge validation")
+		thought.SetContent(context.Background(), "ticket_data", existing, "analyze")
+
+		step := NewAnalyzeMerge[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockStaticProvider{content: `{"severity": ""}`}).
+			WithValidationPolicy(ValidationWarn)
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := result.GetContent("ticket_data_validation_error"); err != nil {
+			t.Error("expected validation error note for the merged value")
+		}
+	})
+}
+
+// mockInvalidExtractProvider returns an extraction that fails TicketData validation.
+type mockInvalidExtractProvider struct{}
+
+func (m *mockInvalidExtractProvider) Call(_ context.Context, _ []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	return &zyn.ProviderResponse{Content: `{"severity": "", "component": "billing", "user_tier": "free"}`}, nil
+}
+
+func (m *mockInvalidExtractProvider) Name() string {
+	return "mock-invalid"
+}
+
+// mockStaticProvider returns the same content for every call.
+type mockStaticProvider struct {
+	content string
+}
+
+func (m *mockStaticProvider) Call(_ context.Context, _ []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	return &zyn.ProviderResponse{Content: m.content}, nil
+}
+
+func (m *mockStaticProvider) Name() string {
+	return "mock-static"
+}
+
+func TestAnalyzeValidationPolicy(t *testing.T) {
+	t.Run("fail by default", func(t *testing.T) {
+		thought := newTestThought("test validation")
+		thought.SetContent(context.Background(), "ticket", "Invoice total is wrong", "user")
+
+		step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").WithProvider(&mockInvalidExtractProvider{})
+		if _, err := step.Process(context.Background(), thought); err == nil {
+			t.Fatal("expected validation failure to fail the step")
+		}
+		if _, ok := thought.GetNote("ticket_data"); ok {
+			t.Error("expected no note on failure")
+		}
+	})
+
+	t.Run("warn keeps partial data", func(t *testing.T) {
+		thought := newTestThought("test validation")
+		thought.SetContent(context.Background(), "ticket", "Invoice total is wrong", "user")
+
+		step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockInvalidExtractProvider{}).
+			WithValidationPolicy(ValidationWarn).
+			WithRawCapture()
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := step.Scan(result)
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		if data.Component != "billing" {
+			t.Errorf("expected partial data kept, got %+v", data)
+		}
+		validation, err := result.GetContent("ticket_data_validation_error")
+		if err != nil {
+			t.Fatalf("expected validation error note: %v", err)
+		}
+		if validation != "severity required" {
+			t.Errorf("expected validation error 'severity required', got %q", validation)
+		}
+		if _, ok := result.GetNote("ticket_data_raw"); ok {
+			t.Error("expected no raw note for a rejected response")
+		}
+	})
+
+	t.Run("warn still fails on malformed responses", func(t *testing.T) {
+		thought := newTestThought("test validation")
+		thought.SetContent(context.Background(), "ticket", "Invoice total is wrong", "user")
+
+		// The component decodes before severity's type error, leaving a partial value
+		provider := &mockStaticProvider{content: `{"component": "billing", "severity": 5}`}
+		step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(provider).
+			WithValidationPolicy(ValidationWarn)
+		if _, err := step.Process(context.Background(), thought); err == nil {
+			t.Fatal("expected a parse error to fail the step")
+		}
+		if _, ok := thought.GetNote("ticket_data"); ok {
+			t.Error("expected no note for an unparseable response")
+		}
+	})
+
+	t.Run("warn still fails on provider errors", func(t *testing.T) {
+		thought := newTestThought("test validation")
+		step := NewAnalyze[TicketData]("ticket_data", "ticket metadata").
+			WithProvider(&mockFailingProvider{}).
+			WithValidationPolicy(ValidationWarn)
+		if _, err := step.Process(context.Background(), thought); err == nil {
+			t.Fatal("expected provider error to fail the step")
+		}
+	})
+}
@@ -25,21 +25,31 @@
 // Decision & Analysis:
 //   - [NewDecide] - Binary yes/no decisions with confidence scores
 //   - [NewAnalyze] - Extract structured data into typed results
+//   - [NewAnalyzeMerge] - Enrich an existing typed note with newly found fields
+//   - [NewAnalyzeList] - Extract every occurrence of a typed item into a list
+//   - [NewMapAnalyze] - Extract typed data from each item of a list note
 //   - [NewCategorize] - Classify into one of N categories
 //   - [NewAssess] - Sentiment analysis with emotional scoring
 //   - [NewPrioritize] - Rank items by specified criteria
+//   - [NewDeduplicate] - Merge semantically equivalent list items using embeddings
 //
 // Control Flow:
 //   - [NewSift] - Semantic gate - LLM decides whether to execute wrapped processor
 //   - [NewDiscern] - Semantic router - LLM classifies and routes to different processors
+//   - [NewClassifyRoute] - Discern without routes, for routing via Scan + Switch or OnRoute
+//   - [NewMultiDiscern] - Multi-cast router - LLM selects every applicable route to run
 //
 // Memory & Reflection:
 //   - [NewRecall] - Load another Thought and summarize its context
 //   - [NewReflect] - Consolidate current Thought's Notes into a summary
+//   - [NewSummarize] - Condense unpublished Notes into one digest note
+//   - [NewExplain] - Explain the chain's reasoning to end users
 //   - [NewCheckpoint] - Create persistent snapshot for branching
 //   - [NewSeek] - Semantic search across Notes
 //   - [NewSurvey] - Search grouped by task
 //   - [NewForget] - Remove notes by key pattern
+//   - [NewRedact] - Rewrite a note with sensitive spans removed (no LLM)
+//   - [NewNormalize] - Strip quoted replies, signatures and boilerplate from a note
 //   - [NewRestore] - Restore thought state from checkpoint
 //   - [NewReset] - Clear all notes from thought
 //
@@ -18,8 +18,15 @@ type Assess struct {
 	key                      string
 	summaryKey               string
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
+	reasoningPrompt          string
 	provider                 Provider
 	temperature              float32
 }
@@ -32,6 +39,7 @@ type Assess struct {
 //
 // Output Notes:
 //   - {key}: JSON-serialized zyn.SentimentResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
 // Example:
@@ -42,31 +50,33 @@ type Assess struct {
 //	fmt.Println(resp.Overall, resp.Confidence, resp.Scores)
 func NewAssess(key string) *Assess {
 	return &Assess{
-		identity:         pipz.NewIdentity(key, "Sentiment assessment primitive"),
-		key:              key,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Sentiment assessment primitive"),
+		key:                  key,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
 // Process implements pipz.Chainable[*Thought].
 func (s *Assess) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, s.provider)
+	provider, err := ResolveProviderForStep(ctx, "assess", s.provider)
 	if err != nil {
 		return t, fmt.Errorf("assess: %w", err)
 	}
 
 	// Create zyn sentiment synapse
-	sentimentSynapse, err := zyn.NewSentiment("overall emotional tone", provider)
+	sentimentSynapse, err := zyn.NewSentiment(localize(promptOr(s.reasoningPrompt, "overall emotional tone"), s.locale), provider)
 	if err != nil {
 		return t, fmt.Errorf("assess: failed to create sentiment synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := s.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -84,6 +94,8 @@ func (s *Assess) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		reasoningTemp = s.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, s.key, "assess", noteContext, len(unpublished))
+
 	// PHASE 1: REASONING - Sentiment analysis
 	sentResponse, err := sentimentSynapse.FireWithInput(ctx, t.Session, zyn.SentimentInput{
 		Text:        noteContext,
@@ -100,10 +112,16 @@ func (s *Assess) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		s.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("assess: failed to marshal response: %w", err)
 	}
-	if err := t.SetContent(ctx, s.key, string(respJSON), "assess"); err != nil {
+	if err := t.SetNote(ctx, s.key, string(respJSON), noteSource("assess", s.sourceTag), contextKeysMetadata(unpublished)); err != nil {
 		s.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("assess: failed to persist note: %w", err)
 	}
+	if s.captureRaw {
+		if err := captureRawResponse(ctx, t, "assess", s.key, s.sourceTag); err != nil {
+			s.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
 
 	// PHASE 2: INTROSPECTION - Semantic summary (optional)
 	if s.useIntrospection {
@@ -113,8 +131,11 @@ func (s *Assess) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		s.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("assess: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -123,7 +144,7 @@ func (s *Assess) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("assess"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
@@ -137,6 +158,10 @@ func (s *Assess) runIntrospection(ctx context.Context, t *Thought, resp zyn.Sent
 		summaryKey:               s.summaryKey,
 		introspectionTemperature: s.introspectionTemperature,
 		synapsePrompt:            "Synthesize sentiment analysis into context for next reasoning step",
+		style:                    s.introspectionStyle,
+		sourceTag:                s.sourceTag,
+		locale:                   s.locale,
+		sessionContext:           s.sessionIntrospection,
 	})
 }
 
@@ -179,7 +204,7 @@ func (s *Assess) buildIntrospectionInput(resp zyn.SentimentResponse, originalNot
 
 // emitFailed emits a step failed event.
 func (s *Assess) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("assess"),
@@ -195,7 +220,7 @@ func (s *Assess) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (s *Assess) Schema() pipz.Node {
-	return pipz.Node{Identity: s.identity, Type: "assess"}
+	return reasoningSchema(s.identity, "assess", nil, s.key, s.summaryKey, s.useIntrospection, s.captureRaw)
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -216,6 +241,12 @@ func (s *Assess) Scan(t *Thought) (*zyn.SentimentResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (s *Assess) ScanIfConfident(t *Thought, min float32) (*zyn.SentimentResponse, bool, error) {
+	return scanIfConfident(s.Scan, func(r *zyn.SentimentResponse) float64 { return r.Confidence }, t, min)
+}
+
 // Builder methods
 
 // WithProvider sets the provider for this step.
@@ -236,6 +267,12 @@ func (s *Assess) WithIntrospection() *Assess {
 	return s
 }
 
+// WithSessionIntrospection sends introspection only the sentiment; see DefaultSessionIntrospection.
+func (s *Assess) WithSessionIntrospection() *Assess {
+	s.sessionIntrospection = true
+	return s
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (s *Assess) WithSummaryKey(key string) *Assess {
 	s.summaryKey = key
@@ -253,3 +290,44 @@ func (s *Assess) WithIntrospectionTemperature(temp float32) *Assess {
 	s.introspectionTemperature = temp
 	return s
 }
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (s *Assess) WithIntrospectionStyle(style string) *Assess {
+	s.introspectionStyle = style
+	return s
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (s *Assess) WithRawCapture() *Assess {
+	s.captureRaw = true
+	return s
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "assess:<tag>",
+// distinguishing notes from multiple Assess steps in the same pipeline.
+func (s *Assess) WithSourceTag(tag string) *Assess {
+	s.sourceTag = tag
+	return s
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (s *Assess) WithLocale(lang string) *Assess {
+	s.locale = lang
+	return s
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (s *Assess) WithContextStrategy(strategy ContextStrategy) *Assess {
+	s.contextStrategy = strategy
+	return s
+}
+
+// WithReasoningPrompt overrides the task prompt of the reasoning synapse.
+func (s *Assess) WithReasoningPrompt(prompt string) *Assess {
+	s.reasoningPrompt = prompt
+	return s
+}
@@ -0,0 +1,246 @@
+package cogito
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/zoobzio/capitan"
+)
+
+// DefaultAsyncEmbedderBuffer is used when NewAsyncEmbedder is given a non-positive buffer size.
+const DefaultAsyncEmbedderBuffer = 256
+
+// ErrEmbeddingQueueFull is reported via EmbeddingFailed when a note cannot be queued
+// for background embedding. The note remains persisted without an embedding and can
+// be picked up later by Memory.BackfillEmbeddings.
+var ErrEmbeddingQueueFull = errors.New("embedding queue full")
+
+// ErrAsyncEmbedderClosed is reported via EmbeddingFailed when a note is queued after Close.
+var ErrAsyncEmbedderClosed = errors.New("async embedder closed")
+
+// pendingWork counts background operations that have been queued but not finished.
+// The zero value is ready to use.
+type pendingWork struct {
+	mu   sync.Mutex
+	n    int
+	done chan struct{} // closed when n returns to zero
+}
+
+// add records a queued operation.
+func (p *pendingWork) add() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.n == 0 {
+		p.done = make(chan struct{})
+	}
+	p.n++
+}
+
+// finish records a completed operation.
+func (p *pendingWork) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.n--
+	if p.n == 0 {
+		close(p.done)
+	}
+}
+
+// drained returns a channel that is closed once no operations are pending.
+func (p *pendingWork) drained() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.n == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	return p.done
+}
+
+// pendingTracker is implemented by memories that wait for queued embeddings on Close.
+type pendingTracker interface {
+	pendingWork() *pendingWork
+}
+
+// embedJob is a persisted note awaiting its embedding.
+type embedJob struct {
+	ctx     context.Context
+	thought *Thought
+	noteID  string
+	key     string
+	content string
+	pending []*pendingWork // the thought's and, if it tracks it, its memory's
+}
+
+// AsyncEmbedder wraps an Embedder so that notes are embedded in the background
+// after they are persisted, keeping embedding latency out of AddNote.
+//
+// It satisfies Embedder, so it is installed like any other embedder via SetEmbedder,
+// WithEmbedder, or Thought.SetEmbedder. When AddNote resolves an AsyncEmbedder it
+// persists the note immediately and queues it; a background worker then embeds the
+// content, stores it with Memory.UpdateNoteEmbedding, updates the in-memory note,
+// and emits EmbeddingGenerated (or EmbeddingFailed).
+//
+// Thought.Flush waits for one thought's queued notes, and SoyMemory.Close waits for
+// every note queued against it before closing the database.
+//
+// Example:
+//
+//	async := cogito.NewAsyncEmbedder(cogito.NewOpenAIEmbedder(apiKey), 512)
+//	defer async.Close()
+//	cogito.SetEmbedder(async)
+type AsyncEmbedder struct {
+	embedder Embedder
+	jobs     chan embedJob
+	wg       sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncEmbedder starts a background worker that embeds queued notes using embedder.
+// buffer bounds the number of notes waiting to be embedded.
+func NewAsyncEmbedder(embedder Embedder, buffer int) *AsyncEmbedder {
+	if buffer <= 0 {
+		buffer = DefaultAsyncEmbedderBuffer
+	}
+
+	a := &AsyncEmbedder{
+		embedder: embedder,
+		jobs:     make(chan embedJob, buffer),
+	}
+
+	a.wg.Add(1)
+	go a.work()
+
+	return a
+}
+
+// Embed generates an embedding synchronously using the wrapped embedder.
+func (a *AsyncEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return a.embedder.Embed(ctx, text)
+}
+
+// Dimensions returns the vector dimensions of the wrapped embedder.
+func (a *AsyncEmbedder) Dimensions() int {
+	return a.embedder.Dimensions()
+}
+
+// Model returns the model name of the wrapped embedder, if it reports one.
+func (a *AsyncEmbedder) Model() string {
+	return EmbeddingModel(a.embedder)
+}
+
+// accepting reports whether the embedder is still queueing notes.
+func (a *AsyncEmbedder) accepting() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return !a.closed
+}
+
+// enqueue queues a persisted note for background embedding without blocking.
+// Notes that cannot be queued are left unembedded and reported via EmbeddingFailed.
+// Cancellation of ctx does not abort the queued work; only its values are retained.
+func (a *AsyncEmbedder) enqueue(ctx context.Context, t *Thought, note Note) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		capitan.Error(ctx, EmbeddingFailed,
+			FieldTraceID.Field(t.TraceID),
+			FieldNoteKey.Field(note.Key),
+			FieldError.Field(ErrAsyncEmbedderClosed),
+		)
+		return
+	}
+
+	job := embedJob{
+		ctx:     context.WithoutCancel(ctx),
+		thought: t,
+		noteID:  note.ID,
+		key:     note.Key,
+		content: note.Content,
+		pending: []*pendingWork{&t.pending},
+	}
+	if tracker, ok := t.memory.(pendingTracker); ok {
+		job.pending = append(job.pending, tracker.pendingWork())
+	}
+
+	job.add()
+	select {
+	case a.jobs <- job:
+	default:
+		job.finish()
+		capitan.Error(ctx, EmbeddingFailed,
+			FieldTraceID.Field(t.TraceID),
+			FieldNoteKey.Field(note.Key),
+			FieldError.Field(ErrEmbeddingQueueFull),
+		)
+	}
+}
+
+// work embeds queued notes until the queue is closed.
+func (a *AsyncEmbedder) work() {
+	defer a.wg.Done()
+	for job := range a.jobs {
+		a.process(job)
+	}
+}
+
+// add records the job as pending.
+func (j embedJob) add() {
+	for _, p := range j.pending {
+		p.add()
+	}
+}
+
+// finish records the job as done.
+func (j embedJob) finish() {
+	for _, p := range j.pending {
+		p.finish()
+	}
+}
+
+// process embeds a single note and stores the result.
+func (a *AsyncEmbedder) process(job embedJob) {
+	defer job.finish()
+
+	embedding, err := a.embedder.Embed(job.ctx, job.content)
+	if err == nil {
+		err = job.thought.memory.UpdateNoteEmbedding(job.ctx, job.noteID, embedding)
+	}
+	if err != nil {
+		capitan.Error(job.ctx, EmbeddingFailed,
+			FieldTraceID.Field(job.thought.TraceID),
+			FieldNoteKey.Field(job.key),
+			FieldError.Field(err),
+		)
+		return
+	}
+
+	job.thought.setNoteEmbedding(job.noteID, embedding)
+
+	capitan.Emit(job.ctx, EmbeddingGenerated,
+		FieldTraceID.Field(job.thought.TraceID),
+		FieldNoteKey.Field(job.key),
+		FieldContentSize.Field(len(job.content)),
+	)
+}
+
+// Close stops accepting notes and waits for queued embeddings to finish.
+// Notes added afterwards are embedded synchronously. It is safe to call more than once.
+func (a *AsyncEmbedder) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.jobs)
+	a.mu.Unlock()
+
+	a.wg.Wait()
+	return nil
+}
@@ -0,0 +1,116 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	capitantesting "github.com/zoobzio/capitan/testing"
+)
+
+// gatedEmbedder blocks each Embed call until release is closed.
+type gatedEmbedder struct {
+	release chan struct{}
+}
+
+func (g *gatedEmbedder) Embed(ctx context.Context, _ string) ([]float32, error) {
+	select {
+	case <-g.release:
+		return []float32{0.1, 0.2, 0.3}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *gatedEmbedder) Dimensions() int {
+	return 3
+}
+
+func TestAsyncEmbedder(t *testing.T) {
+	capture := capitantesting.NewEventCapture()
+	listener := capitan.Hook(EmbeddingGenerated, capture.Handler())
+	defer listener.Close()
+
+	gate := &gatedEmbedder{release: make(chan struct{})}
+	async := NewAsyncEmbedder(gate, 4)
+
+	thought := newTestThought("async embedding")
+	thought.SetEmbedder(async)
+
+	// Cancelling the caller's context must not abort the queued embedding
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := thought.SetContent(ctx, "key", "value", "test"); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+	cancel()
+
+	// AddNote returned while the embedder is still blocked
+	note, _ := thought.GetNote("key")
+	if len(note.Embedding) != 0 {
+		t.Error("expected note to be persisted before embedding")
+	}
+
+	close(gate.release)
+	if err := async.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	note, _ = thought.GetNote("key")
+	if len(note.Embedding) != 3 {
+		t.Errorf("expected in-memory note to receive embedding, got %v", note.Embedding)
+	}
+	stored, _ := thought.memory.GetNotes(context.Background(), thought.ID)
+	if len(stored) != 1 || len(stored[0].Embedding) != 3 {
+		t.Errorf("expected stored note to receive embedding, got %+v", stored)
+	}
+
+	if !capture.WaitForCount(1, time.Second) {
+		t.Fatal("timeout waiting for EmbeddingGenerated")
+	}
+	if got := getStringField(capture.Events()[0], "note_key"); got != "key" {
+		t.Errorf("expected note_key 'key', got %q", got)
+	}
+
+	// After Close, notes fall back to synchronous embedding
+	if err := thought.SetContent(context.Background(), "later", "value", "test"); err != nil {
+		t.Fatalf("SetContent after Close failed: %v", err)
+	}
+	note, _ = thought.GetNote("later")
+	if len(note.Embedding) != 3 {
+		t.Errorf("expected synchronous embedding after Close, got %v", note.Embedding)
+	}
+}
+
+func TestThoughtFlush(t *testing.T) {
+	gate := &gatedEmbedder{release: make(chan struct{})}
+	async := NewAsyncEmbedder(gate, 4)
+	defer async.Close()
+
+	thought := newTestThought("flush")
+	thought.SetEmbedder(async)
+
+	// Nothing queued yet
+	if err := thought.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush with nothing pending failed: %v", err)
+	}
+
+	if err := thought.SetContent(context.Background(), "key", "value", "test"); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+
+	// Flush gives up when its context ends before the embedding does
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := thought.Flush(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	close(gate.release)
+	if err := thought.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if note, _ := thought.GetNote("key"); len(note.Embedding) != 3 {
+		t.Errorf("expected embedding after Flush, got %v", note.Embedding)
+	}
+}
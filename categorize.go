@@ -3,6 +3,7 @@ package cogito
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,83 @@ import (
 	"github.com/zoobzio/zyn"
 )
 
+// ErrInvalidCategories is returned when a categorization step is configured
+// with no categories, an empty category, or duplicate categories.
+var ErrInvalidCategories = errors.New("invalid categories")
+
+// validateCategories checks that categories are non-empty, non-blank, and unique.
+func validateCategories(categories []string) error {
+	if len(categories) == 0 {
+		return fmt.Errorf("%w: at least one category is required", ErrInvalidCategories)
+	}
+	seen := make(map[string]bool, len(categories))
+	for i, category := range categories {
+		if category == "" {
+			return fmt.Errorf("%w: category at index %d is empty", ErrInvalidCategories, i)
+		}
+		if seen[category] {
+			return fmt.Errorf("%w: duplicate category %q", ErrInvalidCategories, category)
+		}
+		seen[category] = true
+	}
+	return nil
+}
+
+// secondaryConfidencePrompt asks the model to report its confidence in the secondary
+// category, which zyn.ClassificationResponse has no field for. It is appended to the
+// classification prompt only when an ambiguity threshold is configured.
+const secondaryConfidencePrompt = ` Also include a "secondary_confidence" field with your confidence (0.0-1.0) in the secondary category.`
+
+// withSecondaryConfidence appends secondaryConfidencePrompt to prompt when delta is set.
+func withSecondaryConfidence(prompt string, delta float32) string {
+	if delta <= 0 {
+		return prompt
+	}
+	return prompt + secondaryConfidencePrompt
+}
+
+// applyAmbiguity flags a classification as ambiguous in metadata when the primary
+// category's confidence leads the secondary's by less than delta.
+//
+// The secondary confidence is read from the "secondary_confidence" field requested by
+// withSecondaryConfidence. When the model omits it there is nothing to compare, so the
+// result is not flagged.
+func applyAmbiguity(metadata map[string]string, resp zyn.ClassificationResponse, session *zyn.Session, delta float32) map[string]string {
+	if delta <= 0 || resp.Secondary == "" || resp.Secondary == resp.Primary {
+		return metadata
+	}
+
+	raw, ok := lastAssistantContent(session)
+	if !ok {
+		return metadata
+	}
+	var extra struct {
+		SecondaryConfidence *float64 `json:"secondary_confidence"`
+	}
+	if err := json.Unmarshal([]byte(raw), &extra); err != nil || extra.SecondaryConfidence == nil {
+		return metadata
+	}
+	secondaryConfidence := *extra.SecondaryConfidence
+
+	if resp.Confidence-secondaryConfidence >= float64(delta) {
+		return metadata
+	}
+
+	metadata["ambiguous"] = "true"
+	metadata["ambiguous_categories"] = resp.Primary + "," + resp.Secondary
+	return metadata
+}
+
+// recordCategories stores the categories offered to the model as a JSON array under
+// the "categories" metadata field, so a stored decision shows what it chose from.
+// Read it back with GetMetadataValue[[]string](note, "categories").
+func recordCategories(metadata map[string]string, categories []string) map[string]string {
+	if encoded, err := json.Marshal(categories); err == nil {
+		metadata["categories"] = string(encoded)
+	}
+	return metadata
+}
+
 // Categorize is a multi-class categorization primitive that implements pipz.Chainable[*Thought].
 // It asks the LLM to place input into one of the provided categories.
 type Categorize struct {
@@ -20,8 +98,17 @@ type Categorize struct {
 	categories               []string
 	summaryKey               string
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
+	reasoningPrompt          string
+	ambiguityThreshold       float32
+	escapeCategory           string
 	provider                 Provider
 	temperature              float32
 }
@@ -34,8 +121,18 @@ type Categorize struct {
 //
 // Output Notes:
 //   - {key}: JSON-serialized zyn.ClassificationResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
+// Categories must be non-empty and unique; Process returns ErrInvalidCategories otherwise.
+//
+// When WithAmbiguityThreshold is set, the model is also asked for its confidence in the
+// secondary category; when the two are too close to call, the {key} note carries
+// ambiguous=true and ambiguous_categories metadata.
+//
+// When WithEscapeCategory is set and the model picks it, the {key} note carries
+// escaped=true metadata and Escaped reports true.
+//
 // Example:
 //
 //	step := cogito.NewCategorize("ticket_type", "What type of ticket is this?", []string{"bug", "feature", "question"})
@@ -44,33 +141,45 @@ type Categorize struct {
 //	fmt.Println(resp.Primary, resp.Confidence, resp.Reasoning)
 func NewCategorize(key, question string, categories []string) *Categorize {
 	return &Categorize{
-		identity:         pipz.NewIdentity(key, "Multi-class categorization primitive"),
-		key:              key,
-		question:         question,
-		categories:       categories,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Multi-class categorization primitive"),
+		key:                  key,
+		question:             question,
+		categories:           categories,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
 // Process implements pipz.Chainable[*Thought].
 func (c *Categorize) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
+	categories := c.categories
+	prompt := withSecondaryConfidence(promptOr(c.reasoningPrompt, c.question), c.ambiguityThreshold)
+	if c.escapeCategory != "" {
+		categories = append(categories[:len(categories):len(categories)], c.escapeCategory)
+		prompt += fmt.Sprintf(" If none of the other categories fit, choose %q.", c.escapeCategory)
+	}
+	if err := validateCategories(categories); err != nil {
+		return t, fmt.Errorf("categorize: %w", err)
+	}
+
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, c.provider)
+	provider, err := ResolveProviderForStep(ctx, "categorize", c.provider)
 	if err != nil {
 		return t, fmt.Errorf("categorize: %w", err)
 	}
 
 	// Create zyn classification synapse
-	classificationSynapse, err := zyn.Classification(c.question, c.categories, provider)
+	classificationSynapse, err := zyn.Classification(localize(prompt, c.locale), categories, provider)
 	if err != nil {
 		return t, fmt.Errorf("categorize: failed to create classification synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := c.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -88,6 +197,8 @@ func (c *Categorize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		reasoningTemp = c.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, c.key, "categorize", noteContext, len(unpublished))
+
 	// PHASE 1: REASONING - Classification
 	classResponse, err := classificationSynapse.FireWithInput(ctx, t.Session, zyn.ClassificationInput{
 		Subject:     c.question,
@@ -105,10 +216,21 @@ func (c *Categorize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		c.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("categorize: failed to marshal response: %w", err)
 	}
-	if err := t.SetContent(ctx, c.key, string(respJSON), "categorize"); err != nil {
+	metadata := applyAmbiguity(contextKeysMetadata(unpublished), classResponse, t.Session, c.ambiguityThreshold)
+	recordCategories(metadata, categories)
+	if c.escapeCategory != "" && classResponse.Primary == c.escapeCategory {
+		metadata["escaped"] = "true"
+	}
+	if err := t.SetNote(ctx, c.key, string(respJSON), noteSource("categorize", c.sourceTag), metadata); err != nil {
 		c.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("categorize: failed to persist note: %w", err)
 	}
+	if c.captureRaw {
+		if err := captureRawResponse(ctx, t, "categorize", c.key, c.sourceTag); err != nil {
+			c.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
 
 	// PHASE 2: INTROSPECTION - Semantic summary (optional)
 	if c.useIntrospection {
@@ -118,8 +240,11 @@ func (c *Categorize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		c.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("categorize: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -128,7 +253,7 @@ func (c *Categorize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("categorize"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
@@ -142,6 +267,10 @@ func (c *Categorize) runIntrospection(ctx context.Context, t *Thought, resp zyn.
 		summaryKey:               c.summaryKey,
 		introspectionTemperature: c.introspectionTemperature,
 		synapsePrompt:            "Synthesize classification into context for next reasoning step",
+		style:                    c.introspectionStyle,
+		sourceTag:                c.sourceTag,
+		locale:                   c.locale,
+		sessionContext:           c.sessionIntrospection,
 	})
 }
 
@@ -171,7 +300,7 @@ func (c *Categorize) buildIntrospectionInput(resp zyn.ClassificationResponse, or
 
 // emitFailed emits a step failed event.
 func (c *Categorize) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("categorize"),
@@ -187,7 +316,7 @@ func (c *Categorize) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (c *Categorize) Schema() pipz.Node {
-	return pipz.Node{Identity: c.identity, Type: "categorize"}
+	return reasoningSchema(c.identity, "categorize", nil, c.key, c.summaryKey, c.useIntrospection, c.captureRaw)
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -208,6 +337,22 @@ func (c *Categorize) Scan(t *Thought) (*zyn.ClassificationResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (c *Categorize) ScanIfConfident(t *Thought, min float32) (*zyn.ClassificationResponse, bool, error) {
+	return scanIfConfident(c.Scan, func(r *zyn.ClassificationResponse) float64 { return r.Confidence }, t, min)
+}
+
+// Escaped reports whether the model chose the escape category, meaning none of the
+// configured categories fit the input. It is always false without WithEscapeCategory.
+func (c *Categorize) Escaped(t *Thought) (bool, error) {
+	resp, err := c.Scan(t)
+	if err != nil {
+		return false, err
+	}
+	return c.escapeCategory != "" && resp.Primary == c.escapeCategory, nil
+}
+
 // Builder methods
 
 // WithProvider sets the provider for this step.
@@ -228,6 +373,12 @@ func (c *Categorize) WithIntrospection() *Categorize {
 	return c
 }
 
+// WithSessionIntrospection sends introspection only the classification; see DefaultSessionIntrospection.
+func (c *Categorize) WithSessionIntrospection() *Categorize {
+	c.sessionIntrospection = true
+	return c
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (c *Categorize) WithSummaryKey(key string) *Categorize {
 	c.summaryKey = key
@@ -245,3 +396,60 @@ func (c *Categorize) WithIntrospectionTemperature(temp float32) *Categorize {
 	c.introspectionTemperature = temp
 	return c
 }
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (c *Categorize) WithIntrospectionStyle(style string) *Categorize {
+	c.introspectionStyle = style
+	return c
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (c *Categorize) WithRawCapture() *Categorize {
+	c.captureRaw = true
+	return c
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "categorize:<tag>",
+// distinguishing notes from multiple Categorize steps in the same pipeline.
+func (c *Categorize) WithSourceTag(tag string) *Categorize {
+	c.sourceTag = tag
+	return c
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (c *Categorize) WithLocale(lang string) *Categorize {
+	c.locale = lang
+	return c
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (c *Categorize) WithContextStrategy(strategy ContextStrategy) *Categorize {
+	c.contextStrategy = strategy
+	return c
+}
+
+// WithReasoningPrompt overrides the task prompt of the reasoning synapse.
+func (c *Categorize) WithReasoningPrompt(prompt string) *Categorize {
+	c.reasoningPrompt = prompt
+	return c
+}
+
+// WithAmbiguityThreshold flags the result as ambiguous when the primary category's
+// confidence exceeds the secondary's by less than delta. The model is asked to report
+// a secondary confidence; a response without one is never flagged.
+func (c *Categorize) WithAmbiguityThreshold(delta float32) *Categorize {
+	c.ambiguityThreshold = delta
+	return c
+}
+
+// WithEscapeCategory offers the model an explicit out-of-set category, such as
+// "none_of_the_above", to choose when no configured category fits, instead of
+// forcing the input into the nearest one. The name must not duplicate a category.
+func (c *Categorize) WithEscapeCategory(name string) *Categorize {
+	c.escapeCategory = name
+	return c
+}
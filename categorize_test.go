@@ -2,6 +2,7 @@ package cogito
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -331,3 +332,212 @@ func TestCategorizeBuilderComposition(t *testing.T) {
 		t.Errorf("expected 2 provider calls, got %d", provider.callCount)
 	}
 }
+
+func TestCategorizeInvalidCategories(t *testing.T) {
+	provider := &mockCategorizeProvider{}
+
+	tests := []struct {
+		name       string
+		categories []string
+	}{
+		{"empty", []string{}},
+		{"nil", nil},
+		{"blank entry", []string{"bug", ""}},
+		{"duplicate", []string{"bug", "feature", "bug"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := NewCategorize("ticket_type", "What type of ticket is this?", tt.categories).WithProvider(provider)
+
+			_, err := step.Process(context.Background(), newTestThought("test"))
+			if !errors.Is(err, ErrInvalidCategories) {
+				t.Errorf("expected ErrInvalidCategories, got %v", err)
+			}
+		})
+	}
+
+	if provider.callCount != 0 {
+		t.Errorf("expected no provider calls, got %d", provider.callCount)
+	}
+}
+
+// mockCloseCallProvider records the classification prompt and returns a classification
+// with an explicit secondary confidence.
+type mockCloseCallProvider struct {
+	prompt string
+}
+
+func (m *mockCloseCallProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.prompt = messages[len(messages)-1].Content
+	return &zyn.ProviderResponse{
+		Content: `{"primary": "bug", "secondary": "feature", "confidence": 0.52, "secondary_confidence": 0.48, "reasoning": ["Could be either"]}`,
+		Usage:   zyn.TokenUsage{Prompt: 10, Completion: 20, Total: 30},
+	}, nil
+}
+
+func (m *mockCloseCallProvider) Name() string {
+	return "mock-close-call"
+}
+
+func TestCategorizeAmbiguityThreshold(t *testing.T) {
+	categories := []string{"bug", "feature", "question"}
+
+	t.Run("close call flagged", func(t *testing.T) {
+		provider := &mockCloseCallProvider{}
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(provider).
+			WithAmbiguityThreshold(0.1)
+
+		result, err := step.Process(context.Background(), newTestThought("ambiguous"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(provider.prompt, "secondary_confidence") {
+			t.Error("expected the prompt to ask for secondary_confidence")
+		}
+
+		if v, err := result.GetMetadata("ticket_type", "ambiguous"); err != nil || v != "true" {
+			t.Errorf("expected ambiguous=true, got %q (%v)", v, err)
+		}
+		if v, _ := result.GetMetadata("ticket_type", "ambiguous_categories"); v != "bug,feature" {
+			t.Errorf("expected ambiguous_categories 'bug,feature', got %q", v)
+		}
+	})
+
+	t.Run("clear winner not flagged", func(t *testing.T) {
+		// 0.52 primary vs reported 0.48 secondary
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(&mockCloseCallProvider{}).
+			WithAmbiguityThreshold(0.01)
+
+		result, err := step.Process(context.Background(), newTestThought("clear"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := result.GetMetadata("ticket_type", "ambiguous"); err == nil {
+			t.Error("expected no ambiguous flag for a clear winner")
+		}
+	})
+
+	t.Run("unreported secondary not flagged", func(t *testing.T) {
+		// No secondary_confidence in the response, so no comparison is made
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(&mockCategorizeProvider{}).
+			WithAmbiguityThreshold(0.9)
+
+		result, err := step.Process(context.Background(), newTestThought("unreported"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := result.GetMetadata("ticket_type", "ambiguous"); err == nil {
+			t.Error("expected no ambiguous flag without a reported secondary confidence")
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(&mockCloseCallProvider{})
+
+		result, err := step.Process(context.Background(), newTestThought("default"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := result.GetMetadata("ticket_type", "ambiguous"); err == nil {
+			t.Error("expected no ambiguous flag without a threshold")
+		}
+	})
+}
+
+// mockEscapeProvider records the classification prompt and picks the escape category.
+type mockEscapeProvider struct {
+	prompt string
+}
+
+func (m *mockEscapeProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.prompt = messages[len(messages)-1].Content
+	return &zyn.ProviderResponse{
+		Content: `{"primary": "none_of_the_above", "secondary": "question", "confidence": 0.8, "reasoning": ["Billing is not a listed category"]}`,
+		Usage:   zyn.TokenUsage{Prompt: 10, Completion: 20, Total: 30},
+	}, nil
+}
+
+func (m *mockEscapeProvider) Name() string {
+	return "mock-escape"
+}
+
+func TestCategorizeWithEscapeCategory(t *testing.T) {
+	categories := []string{"bug", "feature", "question"}
+
+	t.Run("escape chosen", func(t *testing.T) {
+		provider := &mockEscapeProvider{}
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(provider).
+			WithEscapeCategory("none_of_the_above")
+
+		thought := newTestThought("escape")
+		thought.SetContent(context.Background(), "ticket", "Please refund my invoice", "initial")
+
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.Contains(provider.prompt, "none_of_the_above") {
+			t.Error("expected escape category to be offered to the model")
+		}
+		if v, err := result.GetMetadata("ticket_type", "escaped"); err != nil || v != "true" {
+			t.Errorf("expected escaped=true, got %q (%v)", v, err)
+		}
+		escaped, err := step.Escaped(result)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !escaped {
+			t.Error("expected Escaped to report true")
+		}
+		if len(categories) != 3 {
+			t.Errorf("expected configured categories to be untouched, got %v", categories)
+		}
+		note, _ := result.GetNote("ticket_type")
+		if offered, _ := GetMetadataValue[[]string](note, "categories"); len(offered) != 4 || offered[3] != "none_of_the_above" {
+			t.Errorf("expected offered categories to include the escape category, got %v", offered)
+		}
+	})
+
+	t.Run("in-set category not escaped", func(t *testing.T) {
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(&mockCategorizeProvider{}).
+			WithEscapeCategory("none_of_the_above")
+
+		thought := newTestThought("in set")
+		thought.SetContent(context.Background(), "ticket", "Login button doesn't work", "initial")
+
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if escaped, _ := step.Escaped(result); escaped {
+			t.Error("expected Escaped to report false for an in-set category")
+		}
+		if _, err := result.GetMetadata("ticket_type", "escaped"); err == nil {
+			t.Error("expected no escaped metadata for an in-set category")
+		}
+	})
+
+	t.Run("duplicate escape rejected", func(t *testing.T) {
+		step := NewCategorize("ticket_type", "What type?", categories).
+			WithProvider(&mockEscapeProvider{}).
+			WithEscapeCategory("bug")
+
+		_, err := step.Process(context.Background(), newTestThought("duplicate"))
+		if !errors.Is(err, ErrInvalidCategories) {
+			t.Errorf("expected ErrInvalidCategories, got %v", err)
+		}
+	})
+}
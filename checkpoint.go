@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
 	"github.com/zoobzio/zyn"
@@ -44,6 +43,7 @@ func NewCheckpoint(key string) *Checkpoint {
 
 // Process implements pipz.Chainable[*Thought].
 func (c *Checkpoint) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Emit step started
@@ -51,14 +51,14 @@ func (c *Checkpoint) Process(ctx context.Context, t *Thought) (*Thought, error)
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("checkpoint"),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	// Create new thought with current as parent
 	parentID := t.ID
 	newThought := &Thought{
 		Intent:         t.Intent,
-		TraceID:        uuid.New().String(),
+		TraceID:        NewID(),
 		ParentID:       &parentID,
 		TaskID:         t.TaskID,
 		Session:        zyn.NewSession(),
@@ -122,7 +122,7 @@ func (c *Checkpoint) Process(ctx context.Context, t *Thought) (*Thought, error)
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("checkpoint"),
 		FieldStepDuration.Field(time.Since(start)),
-		FieldNoteCount.Field(len(newThought.AllNotes())),
+		FieldNoteCount.Field(newThought.NoteCount()),
 	)
 
 	return newThought, nil
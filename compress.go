@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
@@ -47,6 +48,7 @@ func NewCompress(key string) *Compress {
 
 // Process implements pipz.Chainable[*Thought].
 func (c *Compress) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Check threshold
@@ -62,7 +64,7 @@ func (c *Compress) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	}
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, c.provider)
+	provider, err := ResolveProviderForStep(ctx, "compress", c.provider)
 	if err != nil {
 		return t, fmt.Errorf("compress: %w", err)
 	}
@@ -79,6 +81,8 @@ func (c *Compress) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	// Build session text for summarization
 	sessionText := c.buildSessionText(t.Session.Messages())
 
+	emitContextRendered(ctx, t, c.key, "compress", sessionText, messageCount)
+
 	// Create transform synapse for summarization
 	transformSynapse, err := zyn.Transform(
 		"Summarize this conversation history into a concise context that preserves key information, decisions made, and important details for continuing the conversation",
@@ -121,7 +125,7 @@ func (c *Compress) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("compress"),
 		FieldStepDuration.Field(duration),
-		FieldContextSize.Field(len(summary)),
+		FieldContextSize.Field(utf8.RuneCountInString(summary)),
 	)
 
 	return t, nil
@@ -141,7 +145,7 @@ func (c *Compress) buildSessionText(messages []zyn.Message) string {
 
 // emitFailed emits a step failed event.
 func (c *Compress) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("compress"),
@@ -157,7 +161,11 @@ func (c *Compress) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (c *Compress) Schema() pipz.Node {
-	return pipz.Node{Identity: c.identity, Type: "compress"}
+	summaryKey := c.summaryKey
+	if summaryKey == "" {
+		summaryKey = c.key
+	}
+	return stepSchema(c.identity, "compress", false, nil, []string{summaryKey})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -0,0 +1,83 @@
+package cogito
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ConfidenceAggregation selects how ChainConfidenceWith combines step confidences.
+type ConfidenceAggregation int
+
+const (
+	// ConfidenceProduct multiplies the step confidences, so every doubtful step
+	// lowers the result.
+	ConfidenceProduct ConfidenceAggregation = iota
+	// ConfidenceMin takes the lowest step confidence: the chain is as confident
+	// as its weakest link.
+	ConfidenceMin
+)
+
+// ChainConfidence combines the confidence of the named step notes into a single
+// score by multiplying them. It is shorthand for
+// ChainConfidenceWith(t, ConfidenceProduct, keys...).
+//
+// Example:
+//
+//	if cogito.ChainConfidence(result, "intent", "eligibility", "refund") >= 0.8 {
+//	    autoApprove(result)
+//	}
+func ChainConfidence(t *Thought, keys ...string) float64 {
+	return ChainConfidenceWith(t, ConfidenceProduct, keys...)
+}
+
+// ChainConfidenceWith combines the confidence of the named step notes using agg.
+//
+// A note's confidence is read from its "confidence" metadata field when present,
+// otherwise from the "confidence" field of its JSON content, which is where
+// Decide, Assess, Categorize, Discern and Prioritize record it. A key with no note,
+// or a note with no readable confidence, counts as zero confidence so that a
+// broken chain never looks trustworthy. With no keys the result is zero.
+func ChainConfidenceWith(t *Thought, agg ConfidenceAggregation, keys ...string) float64 {
+	if len(keys) == 0 {
+		return 0
+	}
+
+	result := 1.0
+	for _, key := range keys {
+		confidence, ok := noteConfidence(t, key)
+		if !ok {
+			return 0
+		}
+		switch agg {
+		case ConfidenceMin:
+			if confidence < result {
+				result = confidence
+			}
+		default:
+			result *= confidence
+		}
+	}
+	return result
+}
+
+// noteConfidence reads the confidence recorded on the note stored under key.
+func noteConfidence(t *Thought, key string) (float64, bool) {
+	note, ok := t.GetNote(key)
+	if !ok {
+		return 0, false
+	}
+
+	if raw, ok := note.Metadata["confidence"]; ok {
+		if confidence, err := strconv.ParseFloat(raw, 64); err == nil {
+			return confidence, true
+		}
+	}
+
+	var content struct {
+		Confidence *float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(note.Content), &content); err != nil || content.Confidence == nil {
+		return 0, false
+	}
+	return *content.Confidence, true
+}
@@ -0,0 +1,32 @@
+package cogito
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestChainConfidence(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("chain")
+	thought.SetContent(ctx, "intent", `{"decision":true,"confidence":0.9,"reasoning":["clear"]}`, "decide")
+	thought.SetContent(ctx, "eligibility", `{"primary":"eligible","confidence":0.5}`, "categorize")
+	thought.SetNote(ctx, "manual", "approved by rule", "custom", map[string]string{"confidence": "0.8"})
+	thought.SetContent(ctx, "plain", "no confidence here", "custom")
+
+	if got := ChainConfidence(thought, "intent", "eligibility", "manual"); math.Abs(got-0.36) > 1e-9 {
+		t.Errorf("expected product 0.36, got %v", got)
+	}
+	if got := ChainConfidenceWith(thought, ConfidenceMin, "intent", "eligibility", "manual"); got != 0.5 {
+		t.Errorf("expected min 0.5, got %v", got)
+	}
+	if got := ChainConfidence(thought, "intent", "missing"); got != 0 {
+		t.Errorf("expected missing note to zero the chain, got %v", got)
+	}
+	if got := ChainConfidenceWith(thought, ConfidenceMin, "intent", "plain"); got != 0 {
+		t.Errorf("expected note without confidence to zero the chain, got %v", got)
+	}
+	if got := ChainConfidence(thought); got != 0 {
+		t.Errorf("expected no keys to give 0, got %v", got)
+	}
+}
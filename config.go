@@ -7,9 +7,16 @@ import "github.com/zoobzio/zyn"
 var (
 	// DefaultIntrospection controls whether primitives generate semantic summaries
 	// after reasoning. Disabled by default for cost efficiency. Enable per-step
-	// with WithIntrospection() or globally by setting this to true.
+	// with WithIntrospection() or globally with SetDefaultIntrospection(true).
 	DefaultIntrospection = false
 
+	// DefaultSessionIntrospection controls whether the introspection phase relies on
+	// the session for prior context instead of re-sending the step's notes. The
+	// reasoning call has already put those notes in the session, so this roughly
+	// halves the introspection call's input tokens. Enable per-step with
+	// WithSessionIntrospection() or globally with SetDefaultSessionIntrospection(true).
+	DefaultSessionIntrospection = false
+
 	// DefaultReasoningTemperature is used for the primary LLM call in each primitive.
 	// Defaults to deterministic (low temperature) for consistent outputs.
 	DefaultReasoningTemperature = zyn.DefaultTemperatureDeterministic
@@ -19,3 +26,18 @@ var (
 	// for richer context generation.
 	DefaultIntrospectionTemperature = zyn.DefaultTemperatureCreative
 )
+
+// SetDefaultIntrospection sets whether newly constructed primitives run the
+// introspection phase. Steps read the default when they are constructed, so call
+// it at startup before building pipelines; existing steps are unaffected.
+// Per-step WithIntrospection() still opts a step in when the default is off.
+func SetDefaultIntrospection(enabled bool) {
+	DefaultIntrospection = enabled
+}
+
+// SetDefaultSessionIntrospection sets whether newly constructed primitives send
+// introspection only the reasoning result, relying on the session for prior context.
+// Like SetDefaultIntrospection, it affects steps constructed after the call.
+func SetDefaultSessionIntrospection(enabled bool) {
+	DefaultSessionIntrospection = enabled
+}
@@ -0,0 +1,27 @@
+package cogito
+
+import "testing"
+
+func TestSetDefaultIntrospection(t *testing.T) {
+	original := DefaultIntrospection
+	defer SetDefaultIntrospection(original)
+
+	SetDefaultIntrospection(true)
+	enabled := NewDecide("urgent", "Is this urgent?")
+	if !enabled.useIntrospection {
+		t.Error("expected step constructed after enabling to use introspection")
+	}
+
+	SetDefaultIntrospection(false)
+	disabled := NewCategorize("type", "What type?", []string{"bug", "feature"})
+	if disabled.useIntrospection {
+		t.Error("expected step constructed after disabling to skip introspection")
+	}
+	if !enabled.useIntrospection {
+		t.Error("expected existing step to keep the default it was constructed with")
+	}
+
+	if !disabled.WithIntrospection().useIntrospection {
+		t.Error("expected WithIntrospection to opt in when the default is off")
+	}
+}
@@ -0,0 +1,44 @@
+package cogito
+
+import "strings"
+
+// ContextStrategy selects which of a step's unpublished notes are rendered into its
+// prompt. Every unpublished note is still marked published when the step completes.
+// A nil strategy sends all unpublished notes.
+type ContextStrategy func(notes []Note) []Note
+
+// apply runs the strategy, treating nil as the identity.
+func (s ContextStrategy) apply(notes []Note) []Note {
+	if s == nil {
+		return notes
+	}
+	return s(notes)
+}
+
+// PreferSummaries drops a note when a {key}_summary note for the same key is also
+// unpublished, so a step receives the compact introspection summary of earlier work
+// rather than the detailed result. Notes without a summary are kept, in order.
+//
+// Example:
+//
+//	decide := cogito.NewDecide("escalate", "Should this be escalated?").
+//	    WithContextStrategy(cogito.PreferSummaries)
+func PreferSummaries(notes []Note) []Note {
+	summarized := make(map[string]bool)
+	for _, note := range notes {
+		if key, ok := strings.CutSuffix(note.Key, "_summary"); ok {
+			summarized[key] = true
+		}
+	}
+	if len(summarized) == 0 {
+		return notes
+	}
+
+	selected := make([]Note, 0, len(notes))
+	for _, note := range notes {
+		if !summarized[note.Key] {
+			selected = append(selected, note)
+		}
+	}
+	return selected
+}
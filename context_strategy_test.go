@@ -0,0 +1,73 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+func TestPreferSummaries(t *testing.T) {
+	notes := []Note{
+		{Key: "ticket", Content: "Login broken"},
+		{Key: "analysis", Content: "long detailed analysis"},
+		{Key: "analysis_summary", Content: "short summary"},
+		{Key: "priority", Content: "high"},
+	}
+
+	selected := PreferSummaries(notes)
+	var keys []string
+	for _, note := range selected {
+		keys = append(keys, note.Key)
+	}
+	if got := strings.Join(keys, ","); got != "ticket,analysis_summary,priority" {
+		t.Errorf("expected detailed analysis dropped, got %s", got)
+	}
+
+	if got := PreferSummaries(notes[:2]); len(got) != 2 {
+		t.Errorf("expected notes without summaries kept, got %d", len(got))
+	}
+}
+
+// capturingDecideProvider records the prompts a Decide step sends.
+type capturingDecideProvider struct {
+	prompts []string
+}
+
+func (m *capturingDecideProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	return &zyn.ProviderResponse{Content: `{"decision": true, "confidence": 0.9, "reasoning": ["summary is enough"]}`}, nil
+}
+
+func (m *capturingDecideProvider) Name() string {
+	return "capturing-decide"
+}
+
+func TestDecideWithContextStrategy(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test context strategy")
+	thought.SetContent(ctx, "analysis", "long detailed analysis", "analyze")
+	thought.SetContent(ctx, "analysis_summary", "short summary", "analyze-introspection")
+
+	provider := &capturingDecideProvider{}
+	decide := NewDecide("escalate", "Should this be escalated?").
+		WithProvider(provider).
+		WithContextStrategy(PreferSummaries)
+
+	result, err := decide.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	prompt := provider.prompts[0]
+	if strings.Contains(prompt, "long detailed analysis") {
+		t.Error("expected detailed note omitted from prompt")
+	}
+	if !strings.Contains(prompt, "short summary") {
+		t.Error("expected summary note in prompt")
+	}
+	if len(result.GetUnpublishedNotes()) != 0 {
+		t.Error("expected all notes published after the step")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +14,22 @@ import (
 	"github.com/zoobzio/zyn"
 )
 
+// MergeErrorPolicy controls how Converge handles a branch note that fails to merge.
+type MergeErrorPolicy int
+
+const (
+	// MergeErrorFail aborts the converge when a branch note cannot be merged. This is the default.
+	MergeErrorFail MergeErrorPolicy = iota
+	// MergeErrorSkip drops the note, emits ConvergeNoteMergeSkipped at Warn, and continues to synthesis.
+	MergeErrorSkip
+)
+
+// FallbackReducer merges Converge branch results programmatically when LLM synthesis
+// fails. original holds the notes already merged from every successful branch; results
+// maps each successful branch's identity to its thought. The returned thought becomes
+// the converge result (nil means original).
+type FallbackReducer func(original *Thought, results map[pipz.Identity]*Thought) *Thought
+
 // Converge is a parallel execution primitive with LLM-powered synthesis that implements pipz.Chainable[*Thought].
 // It runs multiple processors concurrently, then uses an LLM to synthesize their outputs into a unified result.
 //
@@ -23,9 +40,16 @@ type Converge struct {
 	key             string
 	synthesisPrompt string
 	processors      []pipz.Chainable[*Thought]
+	labels          map[pipz.Identity]string // Explicit branch labels (see AddNamedProcessor)
 
 	// Configuration
 	synthesisTemperature float32
+	mergeErrorPolicy     MergeErrorPolicy
+	includeFailures      bool
+	contextStrategy      ContextStrategy
+	locale               string
+	fallbackReducer      FallbackReducer
+	progress             func(completed, total int)
 	provider             Provider
 	temperature          float32
 
@@ -59,6 +83,7 @@ func NewConverge(key, synthesisPrompt string, processors ...pipz.Chainable[*Thou
 		key:             key,
 		synthesisPrompt: synthesisPrompt,
 		processors:      processors,
+		labels:          make(map[pipz.Identity]string),
 		temperature:     DefaultReasoningTemperature,
 	}
 }
@@ -70,13 +95,29 @@ type branchResult struct {
 	err      error
 }
 
+// branchFailure records a failed branch for the synthesis context.
+type branchFailure struct {
+	label string
+	err   error
+}
+
 // Process implements pipz.Chainable[*Thought].
 func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	c.mu.RLock()
 	processors := make([]pipz.Chainable[*Thought], len(c.processors))
 	copy(processors, c.processors)
+	labels := make(map[pipz.Identity]string, len(processors))
+	for _, p := range processors {
+		labels[p.Identity()] = c.branchLabel(p)
+	}
+	mergeErrorPolicy := c.mergeErrorPolicy
+	includeFailures := c.includeFailures
+	contextStrategy := c.contextStrategy
+	fallbackReducer := c.fallbackReducer
+	progress := c.progress
 	c.mu.RUnlock()
 
 	if len(processors) == 0 {
@@ -84,14 +125,14 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	}
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, c.provider)
+	provider, err := ResolveProviderForStep(ctx, "converge", c.provider)
 	if err != nil {
 		return t, fmt.Errorf("converge: %w", err)
 	}
 
 	// Get unpublished notes and track original note count for merge filtering
-	unpublished := t.GetUnpublishedNotes()
-	originalNoteCount := len(t.AllNotes())
+	unpublished := contextStrategy.apply(t.GetUnpublishedNotes())
+	originalNoteCount := t.NoteCount()
 
 	// Emit step started
 	capitan.Emit(ctx, StepStarted,
@@ -116,7 +157,7 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 			capitan.Emit(ctx, ConvergeBranchStarted,
 				FieldTraceID.Field(t.TraceID),
 				FieldStepName.Field(c.key),
-				FieldBranchName.Field(p.Identity().Name()),
+				FieldBranchName.Field(labels[p.Identity()]),
 			)
 
 			// Clone thought for isolated processing
@@ -129,7 +170,7 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 			capitan.Emit(ctx, ConvergeBranchCompleted,
 				FieldTraceID.Field(t.TraceID),
 				FieldStepName.Field(c.key),
-				FieldBranchName.Field(p.Identity().Name()),
+				FieldBranchName.Field(labels[p.Identity()]),
 				FieldError.Field(err),
 			)
 
@@ -150,10 +191,17 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	// Collect results
 	branchResults := make(map[pipz.Identity]*Thought)
 	var branchErrors []error
+	var failures []branchFailure
 
+	completed := 0
 	for br := range results {
+		completed++
+		if progress != nil {
+			progress(completed, len(processors))
+		}
 		if br.err != nil {
-			branchErrors = append(branchErrors, fmt.Errorf("branch %q: %w", br.identity.Name(), br.err))
+			branchErrors = append(branchErrors, fmt.Errorf("branch %q: %w", labels[br.identity], br.err))
+			failures = append(failures, branchFailure{label: labels[br.identity], err: br.err})
 		} else {
 			branchResults[br.identity] = br.result
 		}
@@ -167,19 +215,33 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	}
 
 	// PHASE 2: MERGE NOTES - Collect notes from all successful branches
-	mergedContext := c.buildMergedContext(branchResults, originalNoteCount)
+	mergedContext := c.buildMergedContext(branchResults, labels, originalNoteCount)
+	if includeFailures && len(failures) > 0 {
+		mergedContext += buildFailureContext(failures)
+	}
 
 	// Copy notes from successful branches to the original thought
 	// Only copy notes added after the original note count (new notes from branch processing)
 	for identity, branchThought := range branchResults {
 		branchNotes := branchThought.AllNotes()
+		merged := make(map[string]string)
 		for i := originalNoteCount; i < len(branchNotes); i++ {
 			note := branchNotes[i]
-			// Tag the source with branch name
-			taggedSource := fmt.Sprintf("%s[%s]", note.Source, identity.Name())
-			if setErr := t.SetNote(ctx, note.Key, note.Content, taggedSource, note.Metadata); setErr != nil {
+			// Tag the source with branch label
+			taggedSource := fmt.Sprintf("%s[%s]", note.Source, labels[identity])
+			if setErr := t.mergeNote(ctx, note, taggedSource, merged); setErr != nil {
+				if mergeErrorPolicy == MergeErrorSkip {
+					capitan.Warn(ctx, ConvergeNoteMergeSkipped,
+						FieldTraceID.Field(t.TraceID),
+						FieldStepName.Field(c.key),
+						FieldBranchName.Field(labels[identity]),
+						FieldNoteKey.Field(note.Key),
+						FieldError.Field(setErr),
+					)
+					continue
+				}
 				c.emitFailed(ctx, t, start, setErr)
-				return t, fmt.Errorf("converge: failed to merge note from branch %q: %w", identity.Name(), setErr)
+				return t, fmt.Errorf("converge: failed to merge note from branch %q: %w", labels[identity], setErr)
 			}
 		}
 	}
@@ -191,10 +253,46 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldBranchCount.Field(len(branchResults)),
 	)
 
-	transformSynapse, err := zyn.Transform(c.synthesisPrompt, provider)
+	synthesis, err := c.synthesize(ctx, provider, t, mergedContext, unpublished)
 	if err != nil {
+		if fallbackReducer != nil {
+			return c.reduceFallback(ctx, t, start, fallbackReducer, branchResults, err)
+		}
+		c.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("converge: %w", err)
+	}
+
+	// Store synthesis result
+	if err := t.SetNote(ctx, c.key, synthesis, "converge", contextKeysMetadata(unpublished)); err != nil {
+		c.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("converge: failed to persist synthesis note: %w", err)
+	}
+
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
 		c.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("converge: failed to create transform synapse: %w", err)
+		return t, fmt.Errorf("converge: %w", err)
+	}
+
+	// Emit step completed
+	duration := time.Since(start)
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(c.key),
+		FieldStepType.Field("converge"),
+		FieldStepDuration.Field(duration),
+		FieldNoteCount.Field(t.NoteCount()),
+		FieldBranchCount.Field(len(branchResults)),
+	)
+
+	return t, nil
+}
+
+// synthesize fires the synthesis synapse over the merged branch context.
+func (c *Converge) synthesize(ctx context.Context, provider Provider, t *Thought, mergedContext string, unpublished []Note) (string, error) {
+	transformSynapse, err := zyn.Transform(c.synthesisPrompt, provider)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transform synapse: %w", err)
 	}
 
 	// Determine synthesis temperature
@@ -203,54 +301,74 @@ func (c *Converge) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		synthesisTemp = c.synthesisTemperature
 	}
 
+	noteContext := RenderNotesToContext(unpublished)
+	emitContextRendered(ctx, t, c.key, "converge", mergedContext+noteContext, len(unpublished))
+
+	c.mu.RLock()
+	locale := c.locale
+	c.mu.RUnlock()
+
 	synthesis, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 		Text:        mergedContext,
-		Context:     RenderNotesToContext(unpublished),
-		Style:       c.synthesisPrompt,
+		Context:     noteContext,
+		Style:       localizeOutput(c.synthesisPrompt, locale),
 		Temperature: synthesisTemp,
 	})
 	if err != nil {
-		c.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("converge: synthesis failed: %w", err)
+		return "", fmt.Errorf("synthesis failed: %w", err)
 	}
+	return synthesis, nil
+}
 
-	// Store synthesis result
-	if err := t.SetContent(ctx, c.key, synthesis, "converge"); err != nil {
-		c.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("converge: failed to persist synthesis note: %w", err)
-	}
+// reduceFallback completes the converge with the programmatic reducer after synthesis
+// failed, reporting the synthesis error at Warn.
+func (c *Converge) reduceFallback(ctx context.Context, t *Thought, start time.Time, reducer FallbackReducer, branchResults map[pipz.Identity]*Thought, synthesisErr error) (*Thought, error) {
+	capitan.Warn(ctx, ConvergeSynthesisFallback,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(c.key),
+		FieldBranchCount.Field(len(branchResults)),
+		FieldError.Field(synthesisErr),
+	)
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	result := reducer(t, branchResults)
+	if result == nil {
+		result = t
+	}
+	if err := result.publishNotes(ctx); err != nil {
+		c.emitFailed(ctx, result, start, err)
+		return result, fmt.Errorf("converge: %w", err)
+	}
 
-	// Emit step completed
-	duration := time.Since(start)
 	capitan.Emit(ctx, StepCompleted,
-		FieldTraceID.Field(t.TraceID),
+		FieldTraceID.Field(result.TraceID),
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("converge"),
-		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldNoteCount.Field(result.NoteCount()),
 		FieldBranchCount.Field(len(branchResults)),
 	)
 
-	return t, nil
+	return result, nil
 }
 
 // buildMergedContext creates a formatted context from all branch results.
 // originalNoteCount is used to filter out notes that existed before branching.
-func (c *Converge) buildMergedContext(branchResults map[pipz.Identity]*Thought, originalNoteCount int) string {
+func (c *Converge) buildMergedContext(branchResults map[pipz.Identity]*Thought, labels map[pipz.Identity]string, originalNoteCount int) string {
 	var builder strings.Builder
 
 	builder.WriteString("=== PARALLEL ANALYSIS RESULTS ===\n\n")
 
 	for identity, branchThought := range branchResults {
-		builder.WriteString(fmt.Sprintf("--- Branch: %s ---\n", identity.Name()))
+		builder.WriteString(fmt.Sprintf("--- Branch: %s ---\n", labels[identity]))
 
 		// Get notes created by this branch (after original notes)
 		branchNotes := branchThought.AllNotes()
 		for i := originalNoteCount; i < len(branchNotes); i++ {
 			note := branchNotes[i]
+			// Notes a branch replaced, such as raw text it redacted, stay out of the prompt
+			if branchThought.isSuperseded(note.ID) {
+				continue
+			}
 			builder.WriteString(fmt.Sprintf("%s: %s\n", note.Key, note.Content))
 		}
 		builder.WriteString("\n")
@@ -259,9 +377,34 @@ func (c *Converge) buildMergedContext(branchResults map[pipz.Identity]*Thought,
 	return builder.String()
 }
 
+// buildFailureContext summarizes failed branches for the synthesis prompt, sorted by
+// label so the context is stable across runs.
+func buildFailureContext(failures []branchFailure) string {
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].label < failures[j].label
+	})
+
+	var builder strings.Builder
+	builder.WriteString("=== FAILED BRANCHES ===\n")
+	builder.WriteString("The following perspectives are missing from the results above.\n\n")
+	for _, f := range failures {
+		builder.WriteString(fmt.Sprintf("--- Branch: %s ---\nerror: %s\n\n", f.label, f.err))
+	}
+	return builder.String()
+}
+
+// branchLabel returns the explicit label for a processor, or its identity name.
+// Callers must hold c.mu.
+func (c *Converge) branchLabel(p pipz.Chainable[*Thought]) string {
+	if label, ok := c.labels[p.Identity()]; ok {
+		return label
+	}
+	return p.Identity().Name()
+}
+
 // emitFailed emits a step failed event.
 func (c *Converge) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(c.key),
 		FieldStepType.Field("converge"),
@@ -277,7 +420,16 @@ func (c *Converge) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (c *Converge) Schema() pipz.Node {
-	return pipz.Node{Identity: c.identity, Type: "converge"}
+	c.mu.RLock()
+	tasks := make([]pipz.Node, len(c.processors))
+	for i, p := range c.processors {
+		tasks[i] = p.Schema()
+	}
+	c.mu.RUnlock()
+
+	node := stepSchema(c.identity, "converge", true, nil, []string{c.key})
+	node.Flow = pipz.ConcurrentFlow{Tasks: tasks}
+	return node
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -325,6 +477,79 @@ func (c *Converge) WithSynthesisTemperature(temp float32) *Converge {
 	return c
 }
 
+// WithMergeErrorPolicy sets how a branch note that fails to persist during the merge
+// is handled. MergeErrorSkip trades completeness for resilience in best-effort aggregation.
+func (c *Converge) WithMergeErrorPolicy(policy MergeErrorPolicy) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mergeErrorPolicy = policy
+	return c
+}
+
+// WithIncludeFailures adds the names and errors of failed branches to the merged
+// context passed to synthesis, so the model can caveat a synthesis that is missing
+// a perspective instead of silently omitting it.
+func (c *Converge) WithIncludeFailures() *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.includeFailures = true
+	return c
+}
+
+// WithFallbackReducer sets a programmatic reducer that completes the converge when
+// LLM synthesis fails, for example because the provider is unavailable, instead of
+// failing the whole step. The reducer is responsible for writing {key} if later steps
+// read it. Branch failures and merge errors are unaffected.
+//
+// Example:
+//
+//	converge.WithFallbackReducer(func(original *cogito.Thought, results map[pipz.Identity]*cogito.Thought) *cogito.Thought {
+//	    original.SetContent(ctx, "unified_analysis", "synthesis unavailable; see branch notes", "fallback")
+//	    return original
+//	})
+func (c *Converge) WithFallbackReducer(reducer FallbackReducer) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallbackReducer = reducer
+	return c
+}
+
+// WithProgress calls fn as each branch finishes, successfully or not, with the number
+// of branches finished so far and the total, for progress displays such as
+// "3 of 5 analyses complete". Calls are made one at a time from the goroutine running
+// Process, before merging and synthesis. Cancelling the context passed to Process
+// cancels the branches that honour it.
+//
+// Example:
+//
+//	converge.WithProgress(func(completed, total int) {
+//	    ui.SetStatus(fmt.Sprintf("%d of %d analyses complete", completed, total))
+//	})
+func (c *Converge) WithProgress(fn func(completed, total int)) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.progress = fn
+	return c
+}
+
+// WithLocale asks for the synthesis in the given language, such as "French" or
+// "pt-BR". Branch outputs are passed to the model as they are.
+func (c *Converge) WithLocale(lang string) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locale = lang
+	return c
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the synthesis prompt, for example PreferSummaries.
+func (c *Converge) WithContextStrategy(strategy ContextStrategy) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.contextStrategy = strategy
+	return c
+}
+
 // Processor management methods
 
 // AddProcessor adds a processor to the parallel execution list.
@@ -335,6 +560,17 @@ func (c *Converge) AddProcessor(processor pipz.Chainable[*Thought]) *Converge {
 	return c
 }
 
+// AddNamedProcessor adds a processor with an explicit branch label.
+// The label replaces the processor name in merged note sources, the synthesis
+// context, and branch signals, so structurally similar branches stay distinguishable.
+func (c *Converge) AddNamedProcessor(label string, processor pipz.Chainable[*Thought]) *Converge {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.processors = append(c.processors, processor)
+	c.labels[processor.Identity()] = label
+	return c
+}
+
 // RemoveProcessor removes a processor by identity.
 func (c *Converge) RemoveProcessor(identity pipz.Identity) *Converge {
 	c.mu.Lock()
@@ -343,6 +579,7 @@ func (c *Converge) RemoveProcessor(identity pipz.Identity) *Converge {
 	for i, p := range c.processors {
 		if p.Identity() == identity {
 			c.processors = append(c.processors[:i], c.processors[i+1:]...)
+			delete(c.labels, identity)
 			break
 		}
 	}
@@ -354,6 +591,7 @@ func (c *Converge) ClearProcessors() *Converge {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.processors = nil
+	c.labels = make(map[pipz.Identity]string)
 	return c
 }
 
@@ -3,6 +3,7 @@ package cogito
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -157,6 +158,154 @@ func TestConvergeBasic(t *testing.T) {
 	}
 }
 
+// mockRecordingConvergeProvider records each prompt and answers like mockConvergeProvider.
+type mockRecordingConvergeProvider struct {
+	mockConvergeProvider
+	prompts []string
+}
+
+func (m *mockRecordingConvergeProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	return m.mockConvergeProvider.Call(ctx, messages, temperature)
+}
+
+func TestConvergeRedactInBranch(t *testing.T) {
+	ctx := context.Background()
+	provider := &mockRecordingConvergeProvider{}
+
+	// The branch writes a raw note and redacts it before Converge merges it back
+	intake := Sequence(pipz.NewIdentity("intake", "Intake branch"),
+		newAnalysisProcessor("intake", "contact bob@example.com"),
+		NewRedact("intake_clean", "intake_result", []string{`[\w.+-]+@[\w-]+\.[\w.]+`}),
+	)
+	converge := NewConverge("triage", "Combine the findings", intake, newAnalysisProcessor("risk", "Downtime risk: low")).
+		WithProvider(provider)
+
+	thought := newTestThought("test converge redact")
+	thought.SetContent(ctx, "customer", "premium tier", "input")
+	thought.SetContent(ctx, "ticket", "Login broken", "input")
+
+	result, err := converge.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(provider.prompts[0], "bob@example.com") {
+		t.Errorf("expected raw branch note withheld from synthesis, got %q", provider.prompts[0])
+	}
+
+	// Reopen the whole log: only the merged raw copy is withheld
+	result.SetPublishedCount(0)
+	var keys []string
+	for _, note := range result.GetUnpublishedNotes() {
+		if strings.Contains(note.Content, "bob@example.com") {
+			t.Errorf("expected merged raw note withheld, got %s: %q", note.Key, note.Content)
+		}
+		keys = append(keys, note.Key)
+	}
+	for _, key := range []string{"customer", "ticket", "intake_clean", "risk_result", "triage"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("expected %s in context, got %v", key, keys)
+		}
+	}
+}
+
+func TestConvergeSeqUniqueAcrossBranches(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+	thought, err := New(ctx, mem, "test converge seq")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought.SetContent(ctx, "ticket", "Performance degradation reported", "initial")
+
+	converge := NewConverge("unified_analysis", "Synthesize",
+		newAnalysisProcessor("technical", "CPU usage high"),
+		newAnalysisProcessor("business", "Priority: high"),
+		newAnalysisProcessor("risk", "Downtime risk: medium"),
+	).WithProvider(&mockConvergeProvider{})
+
+	result, err := converge.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Branch notes and their merged copies share one thought ID, so their Seqs must not collide
+	stored, _ := mem.GetNotes(ctx, thought.ID)
+	seen := make(map[int64]string, len(stored))
+	for _, note := range stored {
+		if other, ok := seen[note.Seq]; ok {
+			t.Errorf("notes %s and %s share Seq %d", other, note.Key, note.Seq)
+		}
+		seen[note.Seq] = note.Key
+	}
+
+	// Reloaded in created-then-Seq order, notes written in the same instant keep the parent's order
+	same := time.Now()
+	for i := range stored {
+		stored[i].Created = same
+	}
+	slices.SortFunc(stored, func(a, b Note) int {
+		return int(a.Seq - b.Seq)
+	})
+	reloaded := &Thought{ID: thought.ID, memory: mem}
+	for _, note := range stored {
+		reloaded.AddNoteWithoutPersist(note)
+	}
+	var want, got []string
+	for _, note := range result.AllNotes() {
+		want = append(want, note.ID)
+	}
+	for _, note := range reloaded.AllNotes() {
+		if slices.Contains(want, note.ID) {
+			got = append(got, note.ID)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected reload to keep the parent's note order")
+	}
+
+	// Notes added after the reload continue past every existing Seq
+	if err := reloaded.SetContent(ctx, "followup", "Scaling up", "input"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest, _ := reloaded.GetLatestNote(); seen[latest.Seq] != "" {
+		t.Errorf("expected a fresh Seq after reload, got %d", latest.Seq)
+	}
+}
+
+func TestConvergeWithLocale(t *testing.T) {
+	provider := &mockRecordingConvergeProvider{}
+
+	converge := NewConverge(
+		"unified_analysis",
+		"Synthesize these perspectives into a unified recommendation",
+		newAnalysisProcessor("technical", "CPU usage high"),
+		newAnalysisProcessor("business", "Priority: high"),
+	).WithProvider(provider).WithLocale("pt-BR")
+
+	thought := newTestThought("test converge locale")
+	thought.SetContent(context.Background(), "ticket", "Performance degradation reported", "initial")
+
+	result, err := converge.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(provider.prompts) != 1 {
+		t.Fatalf("expected 1 provider call, got %d", len(provider.prompts))
+	}
+	if !strings.Contains(provider.prompts[0], "Write the output in pt-BR") {
+		t.Errorf("expected locale directive in synthesis prompt, got %q", provider.prompts[0])
+	}
+	if !strings.Contains(provider.prompts[0], "CPU usage high") {
+		t.Error("expected branch outputs passed through unchanged")
+	}
+	if _, err := converge.Scan(result); err != nil {
+		t.Errorf("expected synthesis to parse with a locale set: %v", err)
+	}
+}
+
 func TestConvergeParallelExecution(t *testing.T) {
 	provider := &mockConvergeProvider{}
 	SetProvider(provider)
@@ -374,6 +523,39 @@ func TestConvergeProcessorManagement(t *testing.T) {
 	}
 }
 
+func TestConvergeAddNamedProcessor(t *testing.T) {
+	provider := &mockConvergeProvider{}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	// Two structurally identical branches share a processor name
+	converge := NewConverge("unified_analysis", "Synthesize these perspectives").
+		AddNamedProcessor("shallow", newAnalysisProcessor("analysis", "quick take")).
+		AddNamedProcessor("deep", newAnalysisProcessor("analysis", "thorough take"))
+
+	thought := newTestThought("test converge named")
+	thought.SetContent(context.Background(), "ticket", "Performance degradation reported", "initial")
+
+	result, err := converge.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sources := make(map[string]string)
+	for _, note := range result.AllNotes() {
+		if note.Key == "analysis_result" {
+			sources[note.Source] = note.Content
+		}
+	}
+
+	if sources["analysis[shallow]"] != "quick take" {
+		t.Errorf("expected shallow branch note tagged by label, got %v", sources)
+	}
+	if sources["analysis[deep]"] != "thorough take" {
+		t.Errorf("expected deep branch note tagged by label, got %v", sources)
+	}
+}
+
 func TestConvergeName(t *testing.T) {
 	converge := NewConverge("my_synthesis", "Synthesize")
 
@@ -429,3 +611,192 @@ func TestConvergeThoughtIsolation(t *testing.T) {
 		t.Error("expected branch2_result")
 	}
 }
+
+// mergeFailingMemory rejects branch-tagged writes of one key, simulating a
+// persistence hiccup during the Converge merge.
+type mergeFailingMemory struct {
+	*mockMemory
+	failKey string
+}
+
+func (m *mergeFailingMemory) AddNote(ctx context.Context, note *Note) (*Note, error) {
+	if note.Key == m.failKey && strings.Contains(note.Source, "[") {
+		return nil, fmt.Errorf("connection reset")
+	}
+	return m.mockMemory.AddNote(ctx, note)
+}
+
+func TestConvergeMergeErrorPolicy(t *testing.T) {
+	SetProvider(&mockConvergeProvider{})
+	defer SetProvider(nil)
+
+	newThought := func() *Thought {
+		thought, _ := New(context.Background(), &mergeFailingMemory{mockMemory: newMockMemory(), failKey: "flaky_result"}, "test merge policy")
+		thought.SetContent(context.Background(), "input", "Test input", "initial")
+		return thought
+	}
+	newConverge := func() *Converge {
+		return NewConverge("merged", "Synthesize available results",
+			newAnalysisProcessor("stable", "Stable output"),
+			newAnalysisProcessor("flaky", "Flaky output"),
+		)
+	}
+
+	t.Run("fail by default", func(t *testing.T) {
+		_, err := newConverge().Process(context.Background(), newThought())
+		if err == nil || !strings.Contains(err.Error(), "failed to merge note") {
+			t.Fatalf("expected merge failure, got %v", err)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		converge := newConverge().WithMergeErrorPolicy(MergeErrorSkip)
+		result, err := converge.Process(context.Background(), newThought())
+		if err != nil {
+			t.Fatalf("expected skip policy to continue, got %v", err)
+		}
+		if _, err := result.GetContent("stable_result"); err != nil {
+			t.Error("expected stable_result to be merged")
+		}
+		if _, err := result.GetContent("flaky_result"); err == nil {
+			t.Error("expected flaky_result to be skipped")
+		}
+		if synthesis, err := converge.Scan(result); err != nil || synthesis == "" {
+			t.Errorf("expected synthesis despite skipped note, got %q (err: %v)", synthesis, err)
+		}
+	})
+}
+
+// capturingConvergeProvider records the prompts sent for synthesis.
+type capturingConvergeProvider struct {
+	mockConvergeProvider
+	prompts []string
+}
+
+func (m *capturingConvergeProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	return m.mockConvergeProvider.Call(ctx, messages, temperature)
+}
+
+func TestConvergeIncludeFailures(t *testing.T) {
+	run := func(includeFailures bool) string {
+		provider := &capturingConvergeProvider{}
+		converge := NewConverge(
+			"include_failures_test",
+			"Synthesize available results",
+			newAnalysisProcessor("technical", "Technical view"),
+			newAnalysisProcessor("risk", "").withFail(),
+		).WithProvider(provider)
+		if includeFailures {
+			converge.WithIncludeFailures()
+		}
+
+		if _, err := converge.Process(context.Background(), newTestThought("test include failures")); err != nil {
+			t.Fatalf("expected partial success, got error: %v", err)
+		}
+		if len(provider.prompts) != 1 {
+			t.Fatalf("expected 1 synthesis call, got %d", len(provider.prompts))
+		}
+		return provider.prompts[0]
+	}
+
+	t.Run("excluded by default", func(t *testing.T) {
+		prompt := run(false)
+		if strings.Contains(prompt, "FAILED BRANCHES") {
+			t.Error("expected no failure summary without WithIncludeFailures")
+		}
+	})
+
+	t.Run("included", func(t *testing.T) {
+		prompt := run(true)
+		if !strings.Contains(prompt, "FAILED BRANCHES") {
+			t.Fatal("expected failure summary in synthesis prompt")
+		}
+		if !strings.Contains(prompt, "--- Branch: risk ---") || !strings.Contains(prompt, "risk failed") {
+			t.Errorf("expected failed branch name and error in prompt, got: %s", prompt)
+		}
+		if !strings.Contains(prompt, "Technical view") {
+			t.Error("expected successful branch output in prompt")
+		}
+	})
+}
+
+// downConvergeProvider fails every call, simulating an unavailable LLM.
+type downConvergeProvider struct{}
+
+func (downConvergeProvider) Call(_ context.Context, _ []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	return nil, fmt.Errorf("provider unavailable")
+}
+
+func (downConvergeProvider) Name() string {
+	return "down-converge"
+}
+
+func TestConvergeFallbackReducer(t *testing.T) {
+	newConverge := func() *Converge {
+		return NewConverge(
+			"fallback_test",
+			"Synthesize",
+			newAnalysisProcessor("technical", "Technical view"),
+			newAnalysisProcessor("business", "Business view"),
+		).WithProvider(downConvergeProvider{})
+	}
+
+	t.Run("fails without reducer", func(t *testing.T) {
+		if _, err := newConverge().Process(context.Background(), newTestThought("fallback")); err == nil {
+			t.Fatal("expected synthesis failure")
+		}
+	})
+
+	t.Run("reducer produces result", func(t *testing.T) {
+		var branches int
+		converge := newConverge().WithFallbackReducer(func(original *Thought, results map[pipz.Identity]*Thought) *Thought {
+			branches = len(results)
+			original.SetContent(context.Background(), "fallback_test", "merged without synthesis", "fallback")
+			return original
+		})
+
+		result, err := converge.Process(context.Background(), newTestThought("fallback"))
+		if err != nil {
+			t.Fatalf("expected fallback to succeed, got %v", err)
+		}
+		if branches != 2 {
+			t.Errorf("expected 2 branch results, got %d", branches)
+		}
+		if synthesis, _ := converge.Scan(result); synthesis != "merged without synthesis" {
+			t.Errorf("expected reducer output, got %q", synthesis)
+		}
+		if _, err := result.GetContent("technical_result"); err != nil {
+			t.Error("expected branch notes merged before fallback")
+		}
+	})
+}
+
+func TestConvergeProgress(t *testing.T) {
+	var calls [][2]int
+	converge := NewConverge(
+		"progress_test",
+		"Synthesize",
+		newAnalysisProcessor("first", "First view"),
+		newAnalysisProcessor("failing", "").withFail(),
+		newAnalysisProcessor("third", "Third view"),
+	).WithProvider(&mockConvergeProvider{}).
+		WithProgress(func(completed, total int) {
+			calls = append(calls, [2]int{completed, total})
+		})
+
+	if _, err := converge.Process(context.Background(), newTestThought("progress")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Failed branches count as finished
+	want := [][2]int{{1, 3}, {2, 3}, {3, 3}}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %d progress calls, got %v", len(want), calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d: expected %v, got %v", i, want[i], calls[i])
+		}
+	}
+}
@@ -19,8 +19,15 @@ type Decide struct {
 	question                 string
 	summaryKey               string
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
+	reasoningPrompt          string
 	provider                 Provider
 	temperature              float32
 }
@@ -33,6 +40,7 @@ type Decide struct {
 //
 // Output Notes:
 //   - {key}: JSON-serialized zyn.BinaryResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
 // Example:
@@ -43,32 +51,34 @@ type Decide struct {
 //	fmt.Println(resp.Decision, resp.Confidence, resp.Reasoning)
 func NewDecide(key, question string) *Decide {
 	return &Decide{
-		identity:         pipz.NewIdentity(key, "Binary decision primitive"),
-		key:              key,
-		question:         question,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Binary decision primitive"),
+		key:                  key,
+		question:             question,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
 // Process implements pipz.Chainable[*Thought].
 func (d *Decide) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, d.provider)
+	provider, err := ResolveProviderForStep(ctx, "decide", d.provider)
 	if err != nil {
 		return t, fmt.Errorf("decide: %w", err)
 	}
 
 	// Create zyn binary synapse
-	binarySynapse, err := zyn.Binary(d.question, provider)
+	binarySynapse, err := zyn.Binary(localize(promptOr(d.reasoningPrompt, d.question), d.locale), provider)
 	if err != nil {
 		return t, fmt.Errorf("decide: failed to create binary synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := d.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -86,6 +96,8 @@ func (d *Decide) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		reasoningTemp = d.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, d.key, "decide", noteContext, len(unpublished))
+
 	// PHASE 1: REASONING - Binary decision
 	binaryResponse, err := binarySynapse.FireWithInput(ctx, t.Session, zyn.BinaryInput{
 		Subject:     d.question,
@@ -97,16 +109,22 @@ func (d *Decide) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		return t, fmt.Errorf("decide: binary synapse execution failed: %w", err)
 	}
 
-	// Store full response as JSON
-	respJSON, err := json.Marshal(binaryResponse)
+	// Store full response as JSON, keeping any fields the model returned beyond zyn.BinaryResponse
+	respJSON, err := mergeRawResponse(t.Session, binaryResponse)
 	if err != nil {
 		d.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("decide: failed to marshal response: %w", err)
 	}
-	if err := t.SetContent(ctx, d.key, string(respJSON), "decide"); err != nil {
+	if err := t.SetNote(ctx, d.key, string(respJSON), noteSource("decide", d.sourceTag), contextKeysMetadata(unpublished)); err != nil {
 		d.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("decide: failed to persist note: %w", err)
 	}
+	if d.captureRaw {
+		if err := captureRawResponse(ctx, t, "decide", d.key, d.sourceTag); err != nil {
+			d.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
 
 	// PHASE 2: INTROSPECTION - Semantic summary (optional)
 	if d.useIntrospection {
@@ -116,8 +134,11 @@ func (d *Decide) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("decide: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -126,7 +147,7 @@ func (d *Decide) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(d.key),
 		FieldStepType.Field("decide"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
@@ -140,6 +161,10 @@ func (d *Decide) runIntrospection(ctx context.Context, t *Thought, resp zyn.Bina
 		summaryKey:               d.summaryKey,
 		introspectionTemperature: d.introspectionTemperature,
 		synapsePrompt:            "Synthesize decision into context for next reasoning step",
+		style:                    d.introspectionStyle,
+		sourceTag:                d.sourceTag,
+		locale:                   d.locale,
+		sessionContext:           d.sessionIntrospection,
 	})
 }
 
@@ -163,7 +188,7 @@ func (d *Decide) buildIntrospectionInput(resp zyn.BinaryResponse, originalNotes
 
 // emitFailed emits a step failed event.
 func (d *Decide) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(d.key),
 		FieldStepType.Field("decide"),
@@ -179,7 +204,7 @@ func (d *Decide) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (d *Decide) Schema() pipz.Node {
-	return pipz.Node{Identity: d.identity, Type: "decide"}
+	return reasoningSchema(d.identity, "decide", nil, d.key, d.summaryKey, d.useIntrospection, d.captureRaw)
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -200,6 +225,54 @@ func (d *Decide) Scan(t *Thought) (*zyn.BinaryResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (d *Decide) ScanIfConfident(t *Thought, min float32) (*zyn.BinaryResponse, bool, error) {
+	return scanIfConfident(d.Scan, func(r *zyn.BinaryResponse) float64 { return r.Confidence }, t, min)
+}
+
+// ScanDetails retrieves the full stored response from a thought, including any
+// fields the model returned that zyn.BinaryResponse does not map.
+func (d *Decide) ScanDetails(t *Thought) (map[string]any, error) {
+	content, err := t.GetContent(d.key)
+	if err != nil {
+		return nil, fmt.Errorf("decide scan: %w", err)
+	}
+	var details map[string]any
+	if err := json.Unmarshal([]byte(content), &details); err != nil {
+		return nil, fmt.Errorf("decide scan: failed to unmarshal response: %w", err)
+	}
+	return details, nil
+}
+
+// mergeRawResponse marshals a typed synapse response, preserving extra fields from the
+// raw model output recorded as the session's latest assistant message.
+// Typed fields take precedence; unparseable raw output is ignored.
+func mergeRawResponse(session *zyn.Session, typed any) ([]byte, error) {
+	typedJSON, err := json.Marshal(typed)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := lastAssistantContent(session)
+	if !ok {
+		return typedJSON, nil
+	}
+
+	var merged map[string]any
+	if err := json.Unmarshal([]byte(raw), &merged); err != nil || merged == nil {
+		return typedJSON, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(typedJSON, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return json.Marshal(merged)
+}
+
 // Builder methods
 
 // WithProvider sets the provider for this step.
@@ -220,6 +293,12 @@ func (d *Decide) WithIntrospection() *Decide {
 	return d
 }
 
+// WithSessionIntrospection sends introspection only the decision; see DefaultSessionIntrospection.
+func (d *Decide) WithSessionIntrospection() *Decide {
+	d.sessionIntrospection = true
+	return d
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (d *Decide) WithSummaryKey(key string) *Decide {
 	d.summaryKey = key
@@ -237,3 +316,44 @@ func (d *Decide) WithIntrospectionTemperature(temp float32) *Decide {
 	d.introspectionTemperature = temp
 	return d
 }
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (d *Decide) WithIntrospectionStyle(style string) *Decide {
+	d.introspectionStyle = style
+	return d
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (d *Decide) WithRawCapture() *Decide {
+	d.captureRaw = true
+	return d
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "decide:<tag>",
+// distinguishing notes from multiple Decide steps in the same pipeline.
+func (d *Decide) WithSourceTag(tag string) *Decide {
+	d.sourceTag = tag
+	return d
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (d *Decide) WithLocale(lang string) *Decide {
+	d.locale = lang
+	return d
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (d *Decide) WithContextStrategy(strategy ContextStrategy) *Decide {
+	d.contextStrategy = strategy
+	return d
+}
+
+// WithReasoningPrompt overrides the task prompt of the reasoning synapse.
+func (d *Decide) WithReasoningPrompt(prompt string) *Decide {
+	d.reasoningPrompt = prompt
+	return d
+}
@@ -109,6 +109,29 @@ func TestDecideBasic(t *testing.T) {
 	}
 }
 
+func TestDecideRecordsContextKeys(t *testing.T) {
+	provider := &mockDecideProvider{}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	thought := newTestThought("test context keys")
+	thought.SetContent(context.Background(), "ticket_text", "URGENT: System is down!", "initial")
+	thought.SetContent(context.Background(), "user_tier", "premium", "initial")
+
+	result, err := NewDecide("is_urgent", "Is this urgent?").Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, err := result.GetMetadata("is_urgent", "context_keys")
+	if err != nil {
+		t.Fatalf("context_keys metadata not found: %v", err)
+	}
+	if keys != "ticket_text,user_tier" {
+		t.Errorf("expected context_keys 'ticket_text,user_tier', got %q", keys)
+	}
+}
+
 func TestDecideScan(t *testing.T) {
 	provider := &mockDecideProvider{}
 	SetProvider(provider)
@@ -493,3 +516,309 @@ func TestDecideBuilderComposition(t *testing.T) {
 		t.Errorf("expected 2 provider calls, got %d", provider.callCount)
 	}
 }
+
+// mockVerboseDecideProvider returns a binary response with fields beyond zyn.BinaryResponse.
+type mockVerboseDecideProvider struct{}
+
+func (m *mockVerboseDecideProvider) Call(_ context.Context, _ []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	return &zyn.ProviderResponse{
+		Content: `{"decision": true, "confidence": 0.8, "reasoning": ["Mentions outage"], "explanation": "Production outages are always urgent"}`,
+		Usage:   zyn.TokenUsage{Prompt: 10, Completion: 20, Total: 30},
+	}, nil
+}
+
+func (m *mockVerboseDecideProvider) Name() string {
+	return "mock-verbose"
+}
+
+func TestDecideScanDetails(t *testing.T) {
+	SetProvider(&mockVerboseDecideProvider{})
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?")
+
+	thought := newTestThought("test scan details")
+	thought.SetContent(context.Background(), "input_text", "Production is down", "initial")
+
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if !resp.Decision || resp.Confidence != 0.8 {
+		t.Errorf("unexpected typed response: %+v", resp)
+	}
+
+	details, err := step.ScanDetails(result)
+	if err != nil {
+		t.Fatalf("scan details failed: %v", err)
+	}
+	if details["explanation"] != "Production outages are always urgent" {
+		t.Errorf("expected explanation to be preserved, got %v", details["explanation"])
+	}
+	if details["decision"] != true {
+		t.Errorf("expected decision true in details, got %v", details["decision"])
+	}
+}
+
+// mockRecordingDecideProvider delegates to mockDecideProvider and records prompts.
+type mockRecordingDecideProvider struct {
+	mockDecideProvider
+	prompts []string
+}
+
+func (m *mockRecordingDecideProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	if len(messages) > 0 {
+		m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	}
+	return m.mockDecideProvider.Call(ctx, messages, temperature)
+}
+
+func TestDecideCustomPrompts(t *testing.T) {
+	provider := &mockRecordingDecideProvider{}
+
+	step := NewDecide("is_urgent", "Is this urgent?").
+		WithProvider(provider).
+		WithIntrospection().
+		WithReasoningPrompt("Is urgent per on-call escalation policy").
+		WithIntrospectionStyle("Write a one-line escalation note for the on-call engineer.")
+
+	thought := newTestThought("test custom prompts")
+	thought.SetContent(context.Background(), "input_text", "Production is down", "initial")
+
+	if _, err := step.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(provider.prompts) != 2 {
+		t.Fatalf("expected 2 provider calls, got %d", len(provider.prompts))
+	}
+	if !strings.Contains(provider.prompts[0], "on-call escalation policy") {
+		t.Errorf("expected custom reasoning prompt, got %q", provider.prompts[0])
+	}
+	if !strings.Contains(provider.prompts[1], "one-line escalation note") {
+		t.Errorf("expected custom introspection style, got %q", provider.prompts[1])
+	}
+	if strings.Contains(provider.prompts[1], "rich semantic context") {
+		t.Error("expected default introspection style to be replaced")
+	}
+}
+
+func TestDecideSessionIntrospection(t *testing.T) {
+	run := func(t *testing.T, step *Decide, provider *mockRecordingDecideProvider) *Thought {
+		t.Helper()
+		thought := newTestThought("test session introspection")
+		thought.SetContent(context.Background(), "input_text", "Production is down", "initial")
+
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(provider.prompts) != 2 {
+			t.Fatalf("expected 2 provider calls, got %d", len(provider.prompts))
+		}
+		if _, err := result.GetContent("is_urgent_summary"); err != nil {
+			t.Errorf("expected summary note: %v", err)
+		}
+		if !strings.Contains(provider.prompts[1], "Input indicates urgency") {
+			t.Errorf("expected introspection to receive the reasoning result, got %q", provider.prompts[1])
+		}
+		return result
+	}
+
+	t.Run("re-sends notes by default", func(t *testing.T) {
+		provider := &mockRecordingDecideProvider{}
+		run(t, NewDecide("is_urgent", "Is this urgent?").WithProvider(provider).WithIntrospection(), provider)
+		if !strings.Contains(provider.prompts[1], "Production is down") {
+			t.Error("expected introspection prompt to include the notes")
+		}
+	})
+
+	t.Run("relies on the session when enabled", func(t *testing.T) {
+		provider := &mockRecordingDecideProvider{}
+		result := run(t, NewDecide("is_urgent", "Is this urgent?").WithProvider(provider).WithIntrospection().WithSessionIntrospection(), provider)
+		if strings.Contains(provider.prompts[1], "Production is down") {
+			t.Error("expected introspection prompt to omit the notes")
+		}
+
+		// The notes still reach the model through the session's reasoning turn
+		found := false
+		for _, msg := range result.Session.Messages() {
+			if strings.Contains(msg.Content, "Production is down") {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected the session to carry the notes from the reasoning call")
+		}
+	})
+
+	t.Run("global default", func(t *testing.T) {
+		SetDefaultSessionIntrospection(true)
+		defer SetDefaultSessionIntrospection(false)
+
+		provider := &mockRecordingDecideProvider{}
+		run(t, NewDecide("is_urgent", "Is this urgent?").WithProvider(provider).WithIntrospection(), provider)
+		if strings.Contains(provider.prompts[1], "Production is down") {
+			t.Error("expected the global default to omit the notes")
+		}
+	})
+}
+
+func TestDecideWithRawCapture(t *testing.T) {
+	SetProvider(&mockVerboseDecideProvider{})
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?").WithRawCapture()
+
+	result, err := step.Process(context.Background(), newTestThought("test raw capture"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := result.GetContent("is_urgent_raw")
+	if err != nil {
+		t.Fatalf("expected raw note: %v", err)
+	}
+	if !strings.Contains(raw, `"explanation": "Production outages are always urgent"`) {
+		t.Errorf("expected verbatim provider response, got %q", raw)
+	}
+	note, _ := result.GetNote("is_urgent_raw")
+	if note.Source != "decide-raw" {
+		t.Errorf("expected source 'decide-raw', got %q", note.Source)
+	}
+
+	// Disabled by default
+	plain, err := NewDecide("is_urgent", "Is this urgent?").Process(context.Background(), newTestThought("no raw"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := plain.GetContent("is_urgent_raw"); err == nil {
+		t.Error("expected no raw note without WithRawCapture")
+	}
+}
+
+func TestDecideRawCaptureWithIntrospection(t *testing.T) {
+	SetProvider(&mockDecideProvider{})
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?").WithRawCapture().WithIntrospection()
+
+	result, err := step.Process(context.Background(), newTestThought("test raw with introspection"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Raw note holds the decision response, not the later introspection response
+	raw, _ := result.GetContent("is_urgent_raw")
+	if !strings.Contains(raw, `"decision": true`) || strings.Contains(raw, `"output"`) {
+		t.Errorf("expected raw decision response, got %q", raw)
+	}
+}
+
+func TestDecideWithSourceTag(t *testing.T) {
+	SetProvider(&mockDecideProvider{})
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?").
+		WithSourceTag("triage").
+		WithRawCapture().
+		WithIntrospection()
+
+	result, err := step.Process(context.Background(), newTestThought("test source tag"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"is_urgent":         "decide:triage",
+		"is_urgent_raw":     "decide-raw:triage",
+		"is_urgent_summary": "decide-introspection:triage",
+	}
+	for key, source := range expected {
+		note, ok := result.GetNote(key)
+		if !ok {
+			t.Fatalf("expected note %q", key)
+		}
+		if note.Source != source {
+			t.Errorf("expected %q source %q, got %q", key, source, note.Source)
+		}
+	}
+
+	// Untagged steps keep the bare step type
+	plain, err := NewDecide("is_urgent", "Is this urgent?").Process(context.Background(), newTestThought("no tag"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if note, _ := plain.GetNote("is_urgent"); note.Source != "decide" {
+		t.Errorf("expected source 'decide', got %q", note.Source)
+	}
+}
+
+func TestDecideScanIfConfident(t *testing.T) {
+	SetProvider(&mockDecideProvider{})
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?")
+	result, err := step.Process(context.Background(), newTestThought("test confidence filter"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, ok, err := step.ScanIfConfident(result, 0.95)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || resp == nil {
+		t.Error("expected confidence 0.95 to meet threshold 0.95")
+	}
+
+	resp, ok, err = step.ScanIfConfident(result, 0.99)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected confidence 0.95 to miss threshold 0.99")
+	}
+	if resp == nil || !resp.Decision {
+		t.Error("expected response to be returned below threshold")
+	}
+
+	if _, ok, err := NewDecide("missing", "?").ScanIfConfident(result, 0.5); err == nil || ok {
+		t.Error("expected error for missing note")
+	}
+}
+
+func TestDecideWithLocale(t *testing.T) {
+	provider := &mockRecordingDecideProvider{}
+
+	step := NewDecide("is_urgent", "Is this urgent?").
+		WithProvider(provider).
+		WithIntrospection().
+		WithLocale("French")
+
+	thought := newTestThought("test locale")
+	thought.SetContent(context.Background(), "input_text", "La production est en panne", "initial")
+
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(provider.prompts) != 2 {
+		t.Fatalf("expected 2 provider calls, got %d", len(provider.prompts))
+	}
+	if !strings.Contains(provider.prompts[0], "write reasoning in French") {
+		t.Errorf("expected locale directive in reasoning prompt, got %q", provider.prompts[0])
+	}
+	if !strings.Contains(provider.prompts[1], "Write the summary in French") {
+		t.Errorf("expected locale directive in introspection prompt, got %q", provider.prompts[1])
+	}
+	if _, err := step.Scan(result); err != nil {
+		t.Errorf("expected response to parse with a locale set: %v", err)
+	}
+}
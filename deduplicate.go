@@ -0,0 +1,225 @@
+package cogito
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+)
+
+// DefaultDeduplicateThreshold is the cosine similarity at or above which two items
+// are considered duplicates.
+const DefaultDeduplicateThreshold = 0.9
+
+// Deduplicate collapses semantically equivalent entries in a JSON array of strings.
+// It implements pipz.Chainable[*Thought].
+//
+// Each item is embedded and compared against the items kept so far; an item whose
+// similarity to a kept item meets the threshold is merged into it. The first
+// occurrence wins, so the output preserves the input order. Items that are equal
+// after trimming and case folding are merged without being embedded.
+//
+// Output Notes:
+//   - {key}: JSON array of the deduplicated items
+//
+// The note carries "original_count" and "merged_count" metadata.
+//
+// Example:
+//
+//	dedupe := cogito.NewDeduplicate("issues", "raw_issues")
+//	rank := cogito.NewPrioritizeFrom("ranked", "by customer impact", "issues")
+type Deduplicate struct {
+	identity  pipz.Identity
+	key       string
+	itemsKey  string
+	threshold float64
+	embedder  Embedder
+}
+
+// NewDeduplicate creates a primitive that reads a JSON array of strings from itemsKey
+// and writes the deduplicated array to key.
+func NewDeduplicate(key, itemsKey string) *Deduplicate {
+	return &Deduplicate{
+		identity:  pipz.NewIdentity(key, "Semantic deduplication primitive"),
+		key:       key,
+		itemsKey:  itemsKey,
+		threshold: DefaultDeduplicateThreshold,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (d *Deduplicate) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	itemsJSON, err := t.GetContent(d.itemsKey)
+	if err != nil {
+		return t, fmt.Errorf("deduplicate: items note %q not found: %w", d.itemsKey, err)
+	}
+	var items []string
+	if err := json.Unmarshal([]byte(itemsJSON), &items); err != nil {
+		return t, fmt.Errorf("deduplicate: failed to parse items from %q: %w", d.itemsKey, err)
+	}
+
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("deduplicate"),
+	)
+
+	kept, err := d.deduplicate(ctx, items)
+	if err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, err
+	}
+
+	keptJSON, err := json.Marshal(kept)
+	if err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("deduplicate: failed to marshal items: %w", err)
+	}
+	if err := t.SetNote(ctx, d.key, string(keptJSON), "deduplicate", map[string]string{
+		"original_count": strconv.Itoa(len(items)),
+		"merged_count":   strconv.Itoa(len(items) - len(kept)),
+	}); err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("deduplicate: failed to persist note: %w", err)
+	}
+
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("deduplicate"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldResultCount.Field(len(kept)),
+	)
+
+	return t, nil
+}
+
+// deduplicate returns items with duplicates removed, keeping first occurrences.
+func (d *Deduplicate) deduplicate(ctx context.Context, items []string) ([]string, error) {
+	kept := make([]string, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+	var unique []string
+	for _, item := range items {
+		norm := strings.ToLower(strings.TrimSpace(item))
+		if _, ok := seen[norm]; ok {
+			continue
+		}
+		seen[norm] = struct{}{}
+		unique = append(unique, item)
+	}
+
+	if len(unique) < 2 {
+		return append(kept, unique...), nil
+	}
+
+	embedder, err := ResolveEmbedder(ctx, d.embedder)
+	if err != nil {
+		return nil, fmt.Errorf("deduplicate: %w", err)
+	}
+
+	keptEmbeddings := make([][]float32, 0, len(unique))
+	for _, item := range unique {
+		embedding, err := embedder.Embed(ctx, item)
+		if err != nil {
+			return nil, fmt.Errorf("deduplicate: failed to embed item %q: %w", item, err)
+		}
+
+		duplicate := false
+		for _, other := range keptEmbeddings {
+			if cosineSimilarity(embedding, other) >= d.threshold {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+
+		kept = append(kept, item)
+		keptEmbeddings = append(keptEmbeddings, embedding)
+	}
+
+	return kept, nil
+}
+
+// cosineSimilarity returns the cosine similarity of a and b, or 0 if either is empty,
+// zero-length, or their dimensions differ.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// emitFailed emits a step failed event.
+func (d *Deduplicate) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("deduplicate"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Scan returns the deduplicated items from a thought.
+func (d *Deduplicate) Scan(t *Thought) ([]string, error) {
+	content, err := t.GetContent(d.key)
+	if err != nil {
+		return nil, fmt.Errorf("deduplicate scan: %w", err)
+	}
+	var items []string
+	if err := json.Unmarshal([]byte(content), &items); err != nil {
+		return nil, fmt.Errorf("deduplicate scan: failed to unmarshal items: %w", err)
+	}
+	return items, nil
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (d *Deduplicate) Identity() pipz.Identity {
+	return d.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (d *Deduplicate) Schema() pipz.Node {
+	return stepSchema(d.identity, "deduplicate", false, []string{d.itemsKey}, []string{d.key})
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (d *Deduplicate) Close() error {
+	return nil
+}
+
+// Builder methods
+
+// WithThreshold sets the cosine similarity at or above which items are merged.
+func (d *Deduplicate) WithThreshold(threshold float64) *Deduplicate {
+	d.threshold = threshold
+	return d
+}
+
+// WithEmbedder sets a specific embedder for this step.
+func (d *Deduplicate) WithEmbedder(e Embedder) *Deduplicate {
+	d.embedder = e
+	return d
+}
+
+var _ pipz.Chainable[*Thought] = (*Deduplicate)(nil)
@@ -0,0 +1,83 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+)
+
+// vocabularyEmbedder embeds known texts to fixed vectors for deduplication tests.
+type vocabularyEmbedder struct {
+	vectors map[string][]float32
+	calls   int
+}
+
+func (v *vocabularyEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	v.calls++
+	return v.vectors[text], nil
+}
+
+func (v *vocabularyEmbedder) Dimensions() int {
+	return 2
+}
+
+func TestDeduplicate(t *testing.T) {
+	embedder := &vocabularyEmbedder{vectors: map[string][]float32{
+		"login broken":       {1, 0},
+		"can't log in":       {0.98, 0.05},
+		"slow dashboard":     {0, 1},
+		"dashboard is laggy": {0.1, 0.95},
+	}}
+
+	thought := newTestThought("test deduplicate")
+	thought.SetContent(context.Background(), "issues", `["login broken", "slow dashboard", "can't log in", "Login Broken ", "dashboard is laggy"]`, "input")
+
+	step := NewDeduplicate("unique_issues", "issues").WithEmbedder(embedder)
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(items) != 2 || items[0] != "login broken" || items[1] != "slow dashboard" {
+		t.Errorf("expected [login broken, slow dashboard], got %v", items)
+	}
+
+	merged, _ := result.GetMetadata("unique_issues", "merged_count")
+	if merged != "3" {
+		t.Errorf("expected merged_count 3, got %q", merged)
+	}
+	original, _ := result.GetMetadata("unique_issues", "original_count")
+	if original != "5" {
+		t.Errorf("expected original_count 5, got %q", original)
+	}
+
+	// Case-insensitive exact duplicates are merged without embedding
+	if embedder.calls != 4 {
+		t.Errorf("expected 4 embed calls, got %d", embedder.calls)
+	}
+
+	t.Run("threshold", func(t *testing.T) {
+		thought := newTestThought("test deduplicate threshold")
+		thought.SetContent(context.Background(), "issues", `["slow dashboard", "dashboard is laggy"]`, "input")
+
+		step := NewDeduplicate("unique_issues", "issues").WithEmbedder(embedder).WithThreshold(0.999)
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		items, _ := step.Scan(result)
+		if len(items) != 2 {
+			t.Errorf("expected strict threshold to keep both items, got %v", items)
+		}
+	})
+
+	t.Run("missing items", func(t *testing.T) {
+		_, err := NewDeduplicate("unique_issues", "missing").WithEmbedder(embedder).Process(context.Background(), newTestThought("no items"))
+		if err == nil {
+			t.Error("expected error for missing items note")
+		}
+	})
+}
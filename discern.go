@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,17 +17,28 @@ import (
 // Discern is an LLM-powered semantic routing connector that implements pipz.Chainable[*Thought].
 // It uses zyn.Classification directly to determine which route to take based on semantic analysis.
 type Discern struct {
-	identity   pipz.Identity
-	key        string
-	question   string
-	categories []string
-	routes     map[string]pipz.Chainable[*Thought]
-	fallback   pipz.Chainable[*Thought]
+	identity     pipz.Identity
+	key          string
+	question     string
+	categories   []string
+	categoriesFn func(context.Context, *Thought) []string // computes categories per invocation (overrides categories)
+	routes       map[string]pipz.Chainable[*Thought]
+	fallback     pipz.Chainable[*Thought]
+	onRoute      func(context.Context, string, *Thought) error // external routing hook, runs before any route
 
 	// Configuration
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
+	reasoningPrompt          string
+	ambiguityThreshold       float32
+	overrideKey              string
 	summaryKey               string
 	provider                 Provider
 	temperature              float32
@@ -38,10 +50,13 @@ type Discern struct {
 //
 // The connector uses zyn.Classification to determine which route to take.
 // Routes are matched against the Primary category from the classification response.
+// Categories must be non-empty and unique; Process returns ErrInvalidCategories otherwise.
 //
 // Output Notes:
-//   - {key}: JSON-serialized zyn.ClassificationResponse
-//   - {key}_summary: Semantic summary for next steps (if introspection enabled)
+//   - {key}: JSON-serialized zyn.ClassificationResponse (with ambiguous metadata if WithAmbiguityThreshold flags it,
+//     or override metadata if WithOverrideKey routed it)
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled and a route or fallback runs)
 //
 // Example:
 //
@@ -55,34 +70,108 @@ type Discern struct {
 //	router.SetFallback(generalPipeline)
 func NewDiscern(key, question string, categories []string) *Discern {
 	return &Discern{
-		identity:         pipz.NewIdentity(key, "Semantic routing connector"),
-		key:              key,
-		question:         question,
-		categories:       categories,
-		routes:           make(map[string]pipz.Chainable[*Thought]),
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Semantic routing connector"),
+		key:                  key,
+		question:             question,
+		categories:           categories,
+		routes:               make(map[string]pipz.Chainable[*Thought]),
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
+// NewDiscernFunc creates a semantic routing connector whose categories are computed
+// from the thought on every invocation, for destinations that are only known at runtime
+// (e.g. per-tenant queues). Routes are matched against whichever category is returned;
+// categories without a route fall through to the fallback.
+// The computed categories must be non-empty and unique; Process returns ErrInvalidCategories otherwise.
+//
+// Example:
+//
+//	router := cogito.NewDiscernFunc(
+//	    "queue_route",
+//	    "Which queue should handle this ticket?",
+//	    func(ctx context.Context, t *cogito.Thought) []string {
+//	        tenant, _ := t.GetAttr("tenant")
+//	        return queuesFor(tenant)
+//	    },
+//	)
+//	router.AddRoute("billing", billingPipeline)
+//	router.SetFallback(genericQueuePipeline)
+func NewDiscernFunc(key, question string, categoriesFn func(context.Context, *Thought) []string) *Discern {
+	d := NewDiscern(key, question, nil)
+	d.identity = pipz.NewIdentity(key, "Semantic routing connector (dynamic categories)")
+	d.categoriesFn = categoriesFn
+	return d
+}
+
+// NewClassifyRoute creates a classification-only connector: Discern without any
+// registered routes, for callers that route with their own logic (e.g. dispatching
+// to a work queue by category). Read the decision afterwards with Scan and branch on
+// it with Switch, or hand it to an OnRoute callback.
+// Categories must be non-empty and unique; Process returns ErrInvalidCategories otherwise.
+//
+// Output Notes:
+//   - {key}: JSON-serialized zyn.ClassificationResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled and OnRoute set)
+//
+// Example:
+//
+//	classify := cogito.NewClassifyRoute(
+//	    "ticket_class",
+//	    "What type of support ticket is this?",
+//	    []string{"billing", "technical", "general"},
+//	)
+//	chain := cogito.Sequence(pipz.NewIdentity("triage", "Ticket triage"),
+//	    classify,
+//	    cogito.Switch(pipz.NewIdentity("dispatch", "Dispatch by category"), func(ctx context.Context, t *cogito.Thought) string {
+//	        resp, err := classify.Scan(t)
+//	        if err != nil {
+//	            return "general"
+//	        }
+//	        return resp.Primary
+//	    }).AddRoute("billing", billingPipeline).AddRoute("technical", technicalPipeline),
+//	)
+func NewClassifyRoute(key, question string, categories []string) *Discern {
+	d := NewDiscern(key, question, categories)
+	d.identity = pipz.NewIdentity(key, "Semantic classification connector")
+	return d
+}
+
 // Process implements pipz.Chainable[*Thought].
 func (d *Discern) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
+	categories := d.categories
+	if d.categoriesFn != nil {
+		categories = d.categoriesFn(ctx, t)
+	}
+	if err := validateCategories(categories); err != nil {
+		return t, fmt.Errorf("discern: %w", err)
+	}
+
+	// A known category in the override note routes without classification
+	if category, ok := d.overrideCategory(t, categories); ok {
+		return d.processOverride(ctx, t, start, categories, category)
+	}
+
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, d.provider)
+	provider, err := ResolveProviderForStep(ctx, "discern", d.provider)
 	if err != nil {
 		return t, fmt.Errorf("discern: %w", err)
 	}
 
 	// Create zyn classification synapse
-	classificationSynapse, err := zyn.Classification(d.question, d.categories, provider)
+	classificationSynapse, err := zyn.Classification(localize(withSecondaryConfidence(promptOr(d.reasoningPrompt, d.question), d.ambiguityThreshold), d.locale), categories, provider)
 	if err != nil {
 		return t, fmt.Errorf("discern: failed to create classification synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := d.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -100,6 +189,8 @@ func (d *Discern) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		reasoningTemp = d.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, d.key, "discern", noteContext, len(unpublished))
+
 	// PHASE 1: CLASSIFICATION - Determine route
 	classResponse, err := classificationSynapse.FireWithInput(ctx, t.Session, zyn.ClassificationInput{
 		Subject:     d.question,
@@ -117,33 +208,116 @@ func (d *Discern) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		d.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("discern: failed to marshal response: %w", err)
 	}
-	if setErr := t.SetContent(ctx, d.key, string(respJSON), "discern"); setErr != nil {
+	metadata := applyAmbiguity(contextKeysMetadata(unpublished), classResponse, t.Session, d.ambiguityThreshold)
+	recordCategories(metadata, categories)
+	if setErr := t.SetNote(ctx, d.key, string(respJSON), noteSource("discern", d.sourceTag), metadata); setErr != nil {
 		d.emitFailed(ctx, t, start, setErr)
 		return t, fmt.Errorf("discern: failed to persist note: %w", setErr)
 	}
+	if d.captureRaw {
+		if setErr := captureRawResponse(ctx, t, "discern", d.key, d.sourceTag); setErr != nil {
+			d.emitFailed(ctx, t, start, setErr)
+			return t, setErr
+		}
+	}
 
-	// PHASE 2: INTROSPECTION - Semantic summary (optional)
-	if d.useIntrospection {
+	// Resolve route before introspection so pass-through skips the extra call
+	d.mu.RLock()
+	processor, exists := d.routes[classResponse.Primary]
+	fallback := d.fallback
+	d.mu.RUnlock()
+
+	// PHASE 2: INTROSPECTION - Semantic summary (optional, only if a route or OnRoute will run)
+	if d.useIntrospection && (exists || fallback != nil || d.onRoute != nil) {
 		if introErr := d.runIntrospection(ctx, t, classResponse, unpublished, provider); introErr != nil {
 			d.emitFailed(ctx, t, start, introErr)
 			return t, introErr
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("discern: %w", err)
+	}
 
 	// PHASE 3: ROUTING - Execute appropriate processor
+	return d.route(ctx, t, start, classResponse.Primary, processor, exists, fallback)
+}
+
+// overrideCategory returns the override note's content when it names one of categories.
+func (d *Discern) overrideCategory(t *Thought, categories []string) (string, bool) {
+	if d.overrideKey == "" {
+		return "", false
+	}
+	content, err := t.GetContent(d.overrideKey)
+	if err != nil {
+		return "", false
+	}
+	category := strings.TrimSpace(content)
+	for _, c := range categories {
+		if c == category {
+			return category, true
+		}
+	}
+	return "", false
+}
+
+// processOverride records category as the routing decision and runs its route,
+// without calling the provider. Unpublished notes stay unpublished, since no
+// prompt consumed them.
+func (d *Discern) processOverride(ctx context.Context, t *Thought, start time.Time, categories []string, category string) (*Thought, error) {
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("discern"),
+		FieldUnpublishedCount.Field(len(t.GetUnpublishedNotes())),
+	)
+
+	resp := zyn.ClassificationResponse{
+		Primary:    category,
+		Confidence: 1.0,
+		Reasoning:  []string{fmt.Sprintf("Routed by override note %q", d.overrideKey)},
+	}
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("discern: failed to marshal response: %w", err)
+	}
+	metadata := recordCategories(map[string]string{
+		"override":     "true",
+		"override_key": d.overrideKey,
+	}, categories)
+	if err := t.SetNote(ctx, d.key, string(respJSON), noteSource("discern", d.sourceTag), metadata); err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("discern: failed to persist note: %w", err)
+	}
+
 	d.mu.RLock()
-	processor, exists := d.routes[classResponse.Primary]
+	processor, exists := d.routes[category]
 	fallback := d.fallback
 	d.mu.RUnlock()
 
+	return d.route(ctx, t, start, category, processor, exists, fallback)
+}
+
+// route runs the OnRoute callback, then the processor chosen for category, or the
+// fallback, and emits step completion.
+func (d *Discern) route(ctx context.Context, t *Thought, start time.Time, category string, processor pipz.Chainable[*Thought], exists bool, fallback pipz.Chainable[*Thought]) (*Thought, error) {
+	if d.onRoute != nil {
+		if err := d.onRoute(ctx, category, t); err != nil {
+			d.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("discern: on-route callback for %q failed: %w", category, err)
+		}
+	}
+
+	var err error
 	if exists {
 		t, err = processor.Process(ctx, t)
 		if err != nil {
 			d.emitFailed(ctx, t, start, err)
-			return t, fmt.Errorf("discern: route %q failed: %w", classResponse.Primary, err)
+			return t, fmt.Errorf("discern: route %q failed: %w", category, err)
 		}
 	} else if fallback != nil {
 		t, err = fallback.Process(ctx, t)
@@ -161,7 +335,7 @@ func (d *Discern) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(d.key),
 		FieldStepType.Field("discern"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
@@ -175,6 +349,10 @@ func (d *Discern) runIntrospection(ctx context.Context, t *Thought, resp zyn.Cla
 		summaryKey:               d.summaryKey,
 		introspectionTemperature: d.introspectionTemperature,
 		synapsePrompt:            "Synthesize routing decision into context for next reasoning step",
+		style:                    d.introspectionStyle,
+		sourceTag:                d.sourceTag,
+		locale:                   d.locale,
+		sessionContext:           d.sessionIntrospection,
 	})
 }
 
@@ -204,7 +382,7 @@ func (d *Discern) buildIntrospectionInput(resp zyn.ClassificationResponse, origi
 
 // emitFailed emits a step failed event.
 func (d *Discern) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(d.key),
 		FieldStepType.Field("discern"),
@@ -220,7 +398,9 @@ func (d *Discern) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (d *Discern) Schema() pipz.Node {
-	return pipz.Node{Identity: d.identity, Type: "discern"}
+	node := reasoningSchema(d.identity, "discern", nil, d.key, d.summaryKey, d.useIntrospection, d.captureRaw)
+	node.Flow = routeFlow(&d.mu, d.routes, d.fallback)
+	return node
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -259,6 +439,12 @@ func (d *Discern) Scan(t *Thought) (*zyn.ClassificationResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (d *Discern) ScanIfConfident(t *Thought, min float32) (*zyn.ClassificationResponse, bool, error) {
+	return scanIfConfident(d.Scan, func(r *zyn.ClassificationResponse) float64 { return r.Confidence }, t, min)
+}
+
 // Builder methods
 
 // WithProvider sets the provider for classification.
@@ -279,6 +465,12 @@ func (d *Discern) WithIntrospection() *Discern {
 	return d
 }
 
+// WithSessionIntrospection sends introspection only the classification; see DefaultSessionIntrospection.
+func (d *Discern) WithSessionIntrospection() *Discern {
+	d.sessionIntrospection = true
+	return d
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (d *Discern) WithSummaryKey(key string) *Discern {
 	d.summaryKey = key
@@ -297,6 +489,75 @@ func (d *Discern) WithIntrospectionTemperature(temp float32) *Discern {
 	return d
 }
 
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (d *Discern) WithIntrospectionStyle(style string) *Discern {
+	d.introspectionStyle = style
+	return d
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (d *Discern) WithRawCapture() *Discern {
+	d.captureRaw = true
+	return d
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "discern:<tag>",
+// distinguishing notes from multiple Discern steps in the same pipeline.
+func (d *Discern) WithSourceTag(tag string) *Discern {
+	d.sourceTag = tag
+	return d
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (d *Discern) WithLocale(lang string) *Discern {
+	d.locale = lang
+	return d
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (d *Discern) WithContextStrategy(strategy ContextStrategy) *Discern {
+	d.contextStrategy = strategy
+	return d
+}
+
+// WithReasoningPrompt overrides the task prompt of the reasoning synapse.
+func (d *Discern) WithReasoningPrompt(prompt string) *Discern {
+	d.reasoningPrompt = prompt
+	return d
+}
+
+// WithAmbiguityThreshold flags the classification as ambiguous when the primary
+// category's confidence exceeds the secondary's by less than delta, as reported by
+// the model's secondary_confidence field. Routing is unchanged; downstream steps can inspect the note's ambiguous metadata.
+func (d *Discern) WithAmbiguityThreshold(delta float32) *Discern {
+	d.ambiguityThreshold = delta
+	return d
+}
+
+// WithOverrideKey routes straight to the category named by the note at key,
+// skipping classification, when that note exists and its content is one of the
+// categories. Rule-based upstream steps can then force the obvious cases without
+// an LLM call. The {key} note records the override with "override" and
+// "override_key" metadata and a confidence of 1.0. Any other value is ignored
+// and classification runs as usual.
+func (d *Discern) WithOverrideKey(key string) *Discern {
+	d.overrideKey = key
+	return d
+}
+
+// OnRoute sets a callback that receives the chosen category after classification
+// (or override), so routing can live entirely outside the connector, e.g. enqueueing
+// the thought on a per-category work queue. It runs before any registered route or
+// fallback; an error fails the step and skips them.
+func (d *Discern) OnRoute(fn func(ctx context.Context, category string, t *Thought) error) *Discern {
+	d.onRoute = fn
+	return d
+}
+
 // Route management methods
 
 // AddRoute adds or updates a route for a category.
@@ -2,6 +2,7 @@ package cogito
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -211,6 +212,52 @@ func TestDiscernPassThrough(t *testing.T) {
 	}
 }
 
+func TestDiscernPassThroughSkipsIntrospection(t *testing.T) {
+	provider := &mockDiscernProvider{primaryResult: "unknown"}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	// No routes, no fallback - introspection has no downstream consumer
+	router := NewDiscern(
+		"ticket_route",
+		"What type of support ticket is this?",
+		[]string{"billing", "technical"},
+	).WithIntrospection()
+
+	thought := newTestThought("test pass through introspection")
+	thought.SetContent(context.Background(), "ticket_text", "Something unusual", "initial")
+
+	result, err := router.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := result.GetContent("ticket_route_summary"); err == nil {
+		t.Error("expected no summary note when passing through")
+	}
+
+	// Verify only 1 provider call (Classification only)
+	if provider.callCount != 1 {
+		t.Errorf("expected 1 provider call, got %d", provider.callCount)
+	}
+}
+
+func TestDiscernInvalidCategories(t *testing.T) {
+	provider := &mockDiscernProvider{primaryResult: "billing"}
+
+	router := NewDiscern(
+		"ticket_route",
+		"What type of support ticket is this?",
+		[]string{"billing", "billing"},
+	).WithProvider(provider)
+
+	thought := newTestThought("test invalid categories")
+	_, err := router.Process(context.Background(), thought)
+	if !errors.Is(err, ErrInvalidCategories) {
+		t.Errorf("expected ErrInvalidCategories, got %v", err)
+	}
+}
+
 func TestDiscernScan(t *testing.T) {
 	provider := &mockDiscernProvider{primaryResult: "technical", secondaryResult: "billing"}
 	SetProvider(provider)
@@ -290,6 +337,7 @@ func TestDiscernWithIntrospection(t *testing.T) {
 		"What type of support ticket is this?",
 		[]string{"billing", "technical"},
 	).WithIntrospection()
+	router.AddRoute("billing", newMockRouteProcessor("billing", "billing_handled"))
 
 	thought := newTestThought("test with introspection")
 	thought.SetContent(context.Background(), "ticket_text", "Billing question", "initial")
@@ -321,6 +369,7 @@ func TestDiscernWithSummaryKey(t *testing.T) {
 		"What type of support ticket is this?",
 		[]string{"billing", "technical"},
 	).WithIntrospection().WithSummaryKey("custom_routing_summary")
+	router.AddRoute("billing", newMockRouteProcessor("billing", "billing_handled"))
 
 	thought := newTestThought("test custom summary key")
 	thought.SetContent(context.Background(), "ticket_text", "Billing question", "initial")
@@ -600,3 +649,231 @@ func TestDiscernCloseCollectsErrors(t *testing.T) {
 		t.Error("expected fallback to be closed")
 	}
 }
+
+func TestDiscernAmbiguityThreshold(t *testing.T) {
+	bugRoute := newMockRouteProcessor("bug-handler", "bug_processed")
+
+	router := NewDiscern("ticket_route", "What type?", []string{"bug", "feature"}).
+		WithProvider(&mockCloseCallProvider{}).
+		WithAmbiguityThreshold(0.1)
+	router.AddRoute("bug", bugRoute)
+
+	result, err := router.Process(context.Background(), newTestThought("ambiguous routing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bugRoute.called {
+		t.Error("expected primary route to still run for ambiguous classification")
+	}
+	if v, err := result.GetMetadata("ticket_route", "ambiguous"); err != nil || v != "true" {
+		t.Errorf("expected ambiguous=true, got %q (%v)", v, err)
+	}
+}
+
+func TestNewDiscernFunc(t *testing.T) {
+	queues := map[string][]string{
+		"acme":   {"billing", "acme_vip"},
+		"globex": {"billing", "technical"},
+	}
+	var computed []string
+	categoriesFn := func(_ context.Context, th *Thought) []string {
+		tenant, _ := th.GetAttr("tenant")
+		computed = queues[tenant.(string)]
+		return computed
+	}
+
+	t.Run("routes on runtime category", func(t *testing.T) {
+		vipRoute := newMockRouteProcessor("vip-handler", "vip_processed")
+		router := NewDiscernFunc("queue_route", "Which queue?", categoriesFn).
+			WithProvider(&mockDiscernProvider{primaryResult: "acme_vip"})
+		router.AddRoute("acme_vip", vipRoute)
+
+		thought := newTestThought("dynamic routing")
+		thought.SetAttr("tenant", "acme")
+
+		if _, err := router.Process(context.Background(), thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !vipRoute.called {
+			t.Error("expected runtime category route to run")
+		}
+		if strings.Join(computed, ",") != "billing,acme_vip" {
+			t.Errorf("expected categories computed for tenant, got %v", computed)
+		}
+	})
+
+	t.Run("invalid runtime categories", func(t *testing.T) {
+		provider := &mockDiscernProvider{}
+		router := NewDiscernFunc("queue_route", "Which queue?", func(context.Context, *Thought) []string {
+			return nil
+		}).WithProvider(provider)
+
+		_, err := router.Process(context.Background(), newTestThought("no queues"))
+		if !errors.Is(err, ErrInvalidCategories) {
+			t.Errorf("expected ErrInvalidCategories, got %v", err)
+		}
+		if provider.callCount != 0 {
+			t.Errorf("expected no provider calls, got %d", provider.callCount)
+		}
+	})
+}
+
+func TestDiscernRecordsOfferedCategories(t *testing.T) {
+	router := NewDiscern("ticket_route", "What type of support ticket is this?", []string{"billing", "technical"}).
+		WithProvider(&mockDiscernProvider{primaryResult: "billing"})
+
+	thought := newTestThought("test categories metadata")
+	thought.SetContent(context.Background(), "ticket_text", "I have a billing question", "initial")
+
+	result, err := router.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	note, _ := result.GetNote("ticket_route")
+	offered, err := GetMetadataValue[[]string](note, "categories")
+	if err != nil {
+		t.Fatalf("expected categories metadata: %v", err)
+	}
+	if len(offered) != 2 || offered[0] != "billing" || offered[1] != "technical" {
+		t.Errorf("expected [billing technical], got %v", offered)
+	}
+}
+
+func TestDiscernOverrideKey(t *testing.T) {
+	t.Run("known category bypasses classification", func(t *testing.T) {
+		provider := &mockDiscernProvider{primaryResult: "technical"}
+		billingRoute := newMockRouteProcessor("billing-handler", "billing_processed")
+		router := NewDiscern("ticket_route", "What type of support ticket is this?", []string{"billing", "technical"}).
+			WithProvider(provider).
+			WithOverrideKey("forced_route")
+		router.AddRoute("billing", billingRoute)
+
+		thought := newTestThought("test override")
+		thought.SetContent(context.Background(), "forced_route", "billing", "rule")
+
+		result, err := router.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if provider.callCount != 0 {
+			t.Errorf("expected no provider calls, got %d", provider.callCount)
+		}
+		if !billingRoute.called {
+			t.Error("expected billing route to be called")
+		}
+
+		resp, err := router.Scan(result)
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		if resp.Primary != "billing" || resp.Confidence != 1.0 {
+			t.Errorf("expected billing with confidence 1.0, got %q %v", resp.Primary, resp.Confidence)
+		}
+		note, _ := result.GetNote("ticket_route")
+		if note.Metadata["override"] != "true" || note.Metadata["override_key"] != "forced_route" {
+			t.Errorf("expected override metadata, got %v", note.Metadata)
+		}
+	})
+
+	t.Run("unknown value falls back to classification", func(t *testing.T) {
+		provider := &mockDiscernProvider{primaryResult: "technical"}
+		router := NewDiscern("ticket_route", "What type of support ticket is this?", []string{"billing", "technical"}).
+			WithProvider(provider).
+			WithOverrideKey("forced_route")
+
+		thought := newTestThought("test unknown override")
+		thought.SetContent(context.Background(), "forced_route", "shipping", "rule")
+
+		result, err := router.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if provider.callCount == 0 {
+			t.Error("expected classification to call the provider")
+		}
+		note, _ := result.GetNote("ticket_route")
+		if _, ok := note.Metadata["override"]; ok {
+			t.Error("expected no override metadata")
+		}
+	})
+}
+
+func TestNewClassifyRouteWithSwitch(t *testing.T) {
+	provider := &mockDiscernProvider{primaryResult: "technical"}
+
+	classify := NewClassifyRoute(
+		"ticket_class",
+		"What type of support ticket is this?",
+		[]string{"billing", "technical", "general"},
+	).WithProvider(provider)
+
+	technicalRoute := newMockRouteProcessor("technical-handler", "technical_processed")
+	dispatch := Switch(pipz.NewIdentity("dispatch", "Dispatch by category"), func(_ context.Context, th *Thought) string {
+		resp, err := classify.Scan(th)
+		if err != nil {
+			return ""
+		}
+		return resp.Primary
+	}).AddRoute("technical", technicalRoute)
+
+	thought := newTestThought("test classify route")
+	thought.SetContent(context.Background(), "ticket_text", "The app crashes on login", "initial")
+
+	result, err := Sequence(pipz.NewIdentity("triage", "Ticket triage"), classify, dispatch).Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !technicalRoute.called {
+		t.Error("expected technical route to be called via Switch")
+	}
+	if len(classify.Routes()) != 0 {
+		t.Error("expected no routes registered on the classifier")
+	}
+	if _, err := result.GetContent("technical_processed"); err != nil {
+		t.Error("expected technical_processed note to exist")
+	}
+}
+
+func TestDiscernOnRoute(t *testing.T) {
+	provider := &mockDiscernProvider{primaryResult: "billing"}
+
+	var queued []string
+	billingRoute := newMockRouteProcessor("billing-handler", "billing_processed")
+	router := NewClassifyRoute(
+		"ticket_class",
+		"What type of support ticket is this?",
+		[]string{"billing", "technical"},
+	).WithProvider(provider).OnRoute(func(_ context.Context, category string, th *Thought) error {
+		queued = append(queued, category+":"+th.ID)
+		return nil
+	})
+	router.AddRoute("billing", billingRoute)
+
+	thought := newTestThought("test on route")
+	thought.SetContent(context.Background(), "ticket_text", "I was charged twice", "initial")
+
+	if _, err := router.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queued) != 1 || queued[0] != "billing:"+thought.ID {
+		t.Errorf("expected one billing dispatch, got %v", queued)
+	}
+	if !billingRoute.called {
+		t.Error("expected registered route to run after the callback")
+	}
+
+	// A failing callback fails the step and skips the registered route
+	billingRoute.called = false
+	router.OnRoute(func(context.Context, string, *Thought) error {
+		return errors.New("queue unavailable")
+	})
+	if _, err := router.Process(context.Background(), newTestThought("test on route error")); err == nil {
+		t.Fatal("expected error from failing callback")
+	}
+	if billingRoute.called {
+		t.Error("expected registered route to be skipped after callback failure")
+	}
+}
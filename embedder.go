@@ -19,9 +19,51 @@ type Embedder interface {
 	Dimensions() int
 }
 
+// ModelEmbedder is an Embedder that names the model behind its vectors.
+// Notes embedded by one record the name in EmbeddingModel and "embedding_model"
+// metadata, so vectors from different models are not compared against each other.
+type ModelEmbedder interface {
+	Embedder
+
+	// Model returns the embedding model name, such as "text-embedding-3-small".
+	Model() string
+}
+
+// EmbeddingModel returns the model name reported by e, or "" when e is not a
+// ModelEmbedder.
+func EmbeddingModel(e Embedder) string {
+	if m, ok := e.(ModelEmbedder); ok {
+		return m.Model()
+	}
+	return ""
+}
+
+// embeddingSpaceKey is the context key set by WithEmbeddingSpace.
+type embeddingSpaceKey struct{}
+
+// WithEmbeddingSpace returns a context under which Memory searches only compare
+// the query against notes embedded by model, or with no recorded model. Seek and
+// Survey set it from their embedder, so results from a different model's vectors
+// never take the place of relevant ones.
+func WithEmbeddingSpace(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, embeddingSpaceKey{}, model)
+}
+
+// EmbeddingSpaceFrom returns the model set by WithEmbeddingSpace, or "" for no
+// restriction. Memory implementations apply it in SearchNotes and SearchNotesByTask
+// before limiting results, so a search still returns up to limit matches.
+func EmbeddingSpaceFrom(ctx context.Context) string {
+	model, _ := ctx.Value(embeddingSpaceKey{}).(string)
+	return model
+}
+
 // ErrNoEmbedder is returned when no embedder is configured.
 var ErrNoEmbedder = fmt.Errorf("no embedder configured")
 
+// ErrEmptyEmbedding is returned by BackfillEmbeddings when the embedder returns no vector,
+// which would leave the note unembedded and selected again on the next pass.
+var ErrEmptyEmbedding = fmt.Errorf("embedder returned an empty embedding")
+
 // Global embedder state.
 var (
 	globalEmbedder   Embedder
@@ -204,4 +246,9 @@ func (e *OpenAIEmbedder) Dimensions() int {
 	return e.dimensions
 }
 
-var _ Embedder = (*OpenAIEmbedder)(nil)
+// Model returns the embedding model name.
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
+
+var _ ModelEmbedder = (*OpenAIEmbedder)(nil)
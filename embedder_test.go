@@ -138,3 +138,73 @@ func TestOpenAIEmbedderConfiguration(t *testing.T) {
 		}
 	})
 }
+
+// mockModelEmbedder implements ModelEmbedder for testing.
+type mockModelEmbedder struct {
+	mockEmbedder
+	model string
+}
+
+func (m *mockModelEmbedder) Model() string {
+	return m.model
+}
+
+func TestSetEmbedderFor(t *testing.T) {
+	SetEmbedder(nil)
+	ctx := context.Background()
+
+	prose := &mockModelEmbedder{mockEmbedder{embedding: []float32{0.1}, dimensions: 1}, "prose-model"}
+	code := &mockModelEmbedder{mockEmbedder{embedding: []float32{0.9}, dimensions: 1}, "code-model"}
+
+	thought := newTestThought("test embedder routing")
+	thought.SetEmbedder(prose)
+	thought.SetEmbedderFor("code", code)
+
+	thought.SetContent(ctx, "summary", "plain text", "test")
+	thought.SetNote(ctx, "patch", "func main() {}", "test", map[string]string{"content_type": "code"})
+	thought.SetNote(ctx, "table", "a,b", "test", map[string]string{"content_type": "csv"})
+
+	tests := []struct {
+		key       string
+		embedding float32
+		model     string
+	}{
+		{"summary", 0.1, "prose-model"},
+		{"patch", 0.9, "code-model"},
+		{"table", 0.1, "prose-model"}, // no route for csv, default embedder
+	}
+	for _, tt := range tests {
+		note, _ := thought.GetNote(tt.key)
+		if len(note.Embedding) != 1 || note.Embedding[0] != tt.embedding {
+			t.Errorf("%s: expected embedding [%v], got %v", tt.key, tt.embedding, note.Embedding)
+		}
+		if note.Metadata["embedding_model"] != tt.model || note.EmbeddingModel != tt.model {
+			t.Errorf("%s: expected embedding_model %q, got %q / %q", tt.key, tt.model, note.Metadata["embedding_model"], note.EmbeddingModel)
+		}
+	}
+
+	// Removing the route falls back to the default embedder
+	thought.SetEmbedderFor("code", nil)
+	thought.SetNote(ctx, "patch", "func main() {}", "test", map[string]string{"content_type": "code"})
+	if note, _ := thought.GetNote("patch"); note.Metadata["embedding_model"] != "prose-model" {
+		t.Errorf("expected prose-model after removing route, got %q", note.Metadata["embedding_model"])
+	}
+
+	// Clones keep their routes
+	thought.SetEmbedderFor("code", code)
+	clone := thought.Clone()
+	clone.SetNote(ctx, "patch", "func main() {}", "test", map[string]string{"content_type": "code"})
+	if note, _ := clone.GetNote("patch"); note.Metadata["embedding_model"] != "code-model" {
+		t.Errorf("expected clone to route code notes, got %q", note.Metadata["embedding_model"])
+	}
+}
+
+func TestEmbeddingModel(t *testing.T) {
+	if model := EmbeddingModel(&mockEmbedder{}); model != "" {
+		t.Errorf("expected no model for plain embedder, got %q", model)
+	}
+	e := NewOpenAIEmbedder("test-key", WithEmbeddingModel(ModelTextEmbedding3Small, DimensionsTextEmbedding3S))
+	if model := EmbeddingModel(e); model != ModelTextEmbedding3Small {
+		t.Errorf("expected %s, got %q", ModelTextEmbedding3Small, model)
+	}
+}
@@ -0,0 +1,209 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// explainResultLimit caps how much of each step's result is quoted in the trail.
+const explainResultLimit = 500
+
+// Explain is a terminal primitive that turns the reasoning recorded across a chain
+// into one explanation for end users. Where introspection summaries are forward-looking
+// context for the next step, Explain looks back over the whole path and says why the
+// system reached its conclusion.
+type Explain struct {
+	identity    pipz.Identity
+	key         string
+	prompt      string
+	temperature float32
+	provider    Provider
+}
+
+// NewExplain creates a new explanation primitive.
+//
+// The primitive:
+//  1. Collects every step's reasoning (reasoning_N metadata or the JSON "reasoning"
+//     array) and every introspection summary, in chain order
+//  2. Writes them into a reasoning trail, quoting each step's result
+//  3. Rewrites the trail as a plain-language explanation via LLM transform synapse
+//
+// Only the most recent note for each key is used. Notes without reasoning, other than
+// introspection summaries, are left out. Explain fails if no step recorded any.
+//
+// Output Notes:
+//   - {key}: Human-facing explanation of the reasoning path, with "explained_steps" metadata
+//
+// Example:
+//
+//	chain := cogito.Sequence(pipz.NewIdentity("refund", "Refund decision"),
+//	    cogito.NewCategorize("intent", "What is the customer asking for?", intents),
+//	    cogito.NewDecide("eligible", "Is the order eligible for a refund?").WithIntrospection(),
+//	    cogito.NewExplain("explanation"),
+//	)
+func NewExplain(key string) *Explain {
+	return &Explain{
+		identity:    pipz.NewIdentity(key, "Explanation primitive"),
+		key:         key,
+		prompt:      "Explain to the end user, in plain language, how and why this conclusion was reached",
+		temperature: DefaultIntrospectionTemperature,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (e *Explain) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	// Resolve provider
+	provider, err := ResolveProviderForStep(ctx, "explain", e.provider)
+	if err != nil {
+		return t, fmt.Errorf("explain: %w", err)
+	}
+
+	trail, steps := e.buildTrail(t.AllNotes())
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(e.key),
+		FieldStepType.Field("explain"),
+		FieldNoteCount.Field(len(steps)),
+		FieldTemperature.Field(e.temperature),
+	)
+
+	if len(steps) == 0 {
+		err := fmt.Errorf("no reasoning to explain")
+		e.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("explain: %w", err)
+	}
+
+	// Create transform synapse for the explanation
+	transformSynapse, err := zyn.Transform(e.prompt, provider)
+	if err != nil {
+		e.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("explain: failed to create transform synapse: %w", err)
+	}
+
+	emitContextRendered(ctx, t, e.key, "explain", trail, len(steps))
+
+	explanation, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
+		Text:        trail,
+		Context:     "Original request: " + t.Intent,
+		Style:       "Write a short, coherent narrative for a non-technical reader. Follow the order of the steps, state the conclusion plainly, and avoid internal step names and jargon.",
+		Temperature: e.temperature,
+	})
+	if err != nil {
+		e.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("explain: transform synapse execution failed: %w", err)
+	}
+
+	if err := t.SetNote(ctx, e.key, explanation, "explain", map[string]string{
+		"explained_steps": strings.Join(steps, ","),
+	}); err != nil {
+		e.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("explain: failed to persist explanation: %w", err)
+	}
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(e.key),
+		FieldStepType.Field("explain"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldContentSize.Field(len(explanation)),
+	)
+
+	return t, nil
+}
+
+// buildTrail renders the latest reasoning and summary notes in chain order and
+// returns the keys it included.
+func (e *Explain) buildTrail(notes []Note) (string, []string) {
+	latest := make(map[string]int, len(notes))
+	for i, note := range notes {
+		latest[note.Key] = i
+	}
+
+	var trail strings.Builder
+	var steps []string
+	for i, note := range notes {
+		if latest[note.Key] != i || note.Key == e.key {
+			continue
+		}
+
+		if strings.Contains(note.Source, "-introspection") {
+			fmt.Fprintf(&trail, "Step summary (%s):\n%s\n\n", note.Key, note.Content)
+			steps = append(steps, note.Key)
+			continue
+		}
+
+		reasoning := noteReasoning(note)
+		if len(reasoning) == 0 {
+			continue
+		}
+		fmt.Fprintf(&trail, "Step %s (%s):\nResult: %s\nReasoning:\n", note.Key, note.Source, truncateContent(note.Content, explainResultLimit))
+		for j, reason := range reasoning {
+			fmt.Fprintf(&trail, "  %d. %s\n", j+1, reason)
+		}
+		trail.WriteString("\n")
+		steps = append(steps, note.Key)
+	}
+
+	return trail.String(), steps
+}
+
+// emitFailed emits a step failed event.
+func (e *Explain) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(e.key),
+		FieldStepType.Field("explain"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (e *Explain) Identity() pipz.Identity {
+	return e.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (e *Explain) Schema() pipz.Node {
+	return stepSchema(e.identity, "explain", true, nil, []string{e.key})
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (e *Explain) Close() error {
+	return nil
+}
+
+// Builder methods
+
+// WithPrompt sets a custom explanation prompt, for example to address a
+// particular audience or to match a product's tone.
+func (e *Explain) WithPrompt(prompt string) *Explain {
+	e.prompt = prompt
+	return e
+}
+
+// WithTemperature sets the temperature for the explanation.
+func (e *Explain) WithTemperature(temp float32) *Explain {
+	e.temperature = temp
+	return e
+}
+
+// WithProvider sets the provider for the LLM call.
+func (e *Explain) WithProvider(p Provider) *Explain {
+	e.provider = p
+	return e
+}
+
+var _ pipz.Chainable[*Thought] = (*Explain)(nil)
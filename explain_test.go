@@ -0,0 +1,74 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+// explainProvider records the last prompt and answers with a fixed transform response.
+type explainProvider struct {
+	prompt string
+}
+
+func (p *explainProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	p.prompt = messages[len(messages)-1].Content
+	return &zyn.ProviderResponse{
+		Content: `{"output": "We approved your refund because the order arrived damaged.", "confidence": 0.9, "changes": [], "reasoning": []}`,
+	}, nil
+}
+
+func (p *explainProvider) Name() string {
+	return "explain-provider"
+}
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("should we refund order 42")
+	thought.SetContent(ctx, "ticket", "The parcel arrived crushed.", "input")
+	thought.SetContent(ctx, "eligible", `{"decision": false, "confidence": 0.4, "reasoning": ["stale"]}`, "decide")
+	thought.SetContent(ctx, "eligible", `{"decision": true, "confidence": 0.9, "reasoning": ["item damaged in transit"]}`, "decide")
+	thought.SetContent(ctx, "eligible_summary", "Damage qualifies the order for a refund.", "decide-introspection")
+
+	provider := &explainProvider{}
+	result, err := NewExplain("explanation").WithProvider(provider).Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	explanation, _ := result.GetContent("explanation")
+	if explanation != "We approved your refund because the order arrived damaged." {
+		t.Errorf("unexpected explanation %q", explanation)
+	}
+	if steps, _ := result.GetMetadata("explanation", "explained_steps"); steps != "eligible,eligible_summary" {
+		t.Errorf("expected explained_steps 'eligible,eligible_summary', got %q", steps)
+	}
+
+	for _, want := range []string{"item damaged in transit", "Damage qualifies the order", "should we refund order 42"} {
+		if !strings.Contains(provider.prompt, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+	if strings.Contains(provider.prompt, "stale") {
+		t.Error("expected superseded reasoning to be left out")
+	}
+	if strings.Contains(provider.prompt, "parcel arrived crushed") {
+		t.Error("expected notes without reasoning to be left out")
+	}
+}
+
+func TestExplainFailsWithoutReasoning(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("nothing to explain")
+	thought.SetContent(ctx, "ticket", "plain input", "input")
+
+	provider := &explainProvider{}
+	if _, err := NewExplain("explanation").WithProvider(provider).Process(ctx, thought); err == nil {
+		t.Fatal("expected error when no step recorded reasoning")
+	}
+	if provider.prompt != "" {
+		t.Error("expected no provider call")
+	}
+}
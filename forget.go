@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
 	"github.com/zoobzio/zyn"
@@ -55,6 +54,7 @@ func NewForget(key string) *Forget {
 
 // Process implements pipz.Chainable[*Thought].
 func (f *Forget) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Emit step started
@@ -62,14 +62,14 @@ func (f *Forget) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(f.key),
 		FieldStepType.Field("forget"),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	// Create new thought with current as parent
 	parentID := t.ID
 	newThought := &Thought{
 		Intent:         t.Intent,
-		TraceID:        uuid.New().String(),
+		TraceID:        NewID(),
 		ParentID:       &parentID,
 		TaskID:         t.TaskID,
 		Session:        zyn.NewSession(), // Fresh session - no LLM state carried over
@@ -143,7 +143,7 @@ func (f *Forget) shouldKeep(key string) bool {
 
 // emitFailed emits a step failed event.
 func (f *Forget) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(f.key),
 		FieldStepType.Field("forget"),
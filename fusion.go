@@ -0,0 +1,38 @@
+package cogito
+
+import "sort"
+
+// RRFConstant is the rank offset k used by ReciprocalRankFusion. The conventional
+// value of 60 damps the influence of top ranks so no single list dominates.
+const RRFConstant = 60
+
+// ReciprocalRankFusion merges several ranked search results into one list using
+// reciprocal rank fusion. Each note scores the sum of 1/(RRFConstant+rank) over the
+// lists it appears in, with rank starting at 1, and the result is ordered by
+// descending score. Notes are deduplicated by ID; the first occurrence is kept.
+// Ties keep the order in which notes were first seen.
+//
+// Use it to blend results from different embedding models without changing the
+// storage layer:
+//
+//	general, _ := memory.SearchNotes(ctx, generalVec, 20)
+//	domain, _ := memory.SearchNotes(ctx, domainVec, 20)
+//	fused := cogito.ReciprocalRankFusion(general, domain)
+func ReciprocalRankFusion(results ...[]NoteWithThought) []NoteWithThought {
+	scores := make(map[string]float64)
+	var fused []NoteWithThought
+	for _, list := range results {
+		for rank, result := range list {
+			id := result.Note.ID
+			if _, ok := scores[id]; !ok {
+				fused = append(fused, result)
+			}
+			scores[id] += 1.0 / float64(RRFConstant+rank+1)
+		}
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool {
+		return scores[fused[i].Note.ID] > scores[fused[j].Note.ID]
+	})
+	return fused
+}
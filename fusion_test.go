@@ -0,0 +1,36 @@
+package cogito
+
+import "testing"
+
+func TestReciprocalRankFusion(t *testing.T) {
+	result := func(id string) NoteWithThought {
+		return NoteWithThought{Note: Note{ID: id, Key: id}}
+	}
+
+	general := []NoteWithThought{result("a"), result("b"), result("c")}
+	domain := []NoteWithThought{result("c"), result("d"), result("b")}
+
+	fused := ReciprocalRankFusion(general, domain)
+
+	var ids []string
+	for _, r := range fused {
+		ids = append(ids, r.Note.ID)
+	}
+	// b: 1/62+1/63, c: 1/63+1/61, a: 1/61, d: 1/62
+	want := []string{"c", "b", "a", "d"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+
+	if got := ReciprocalRankFusion(); len(got) != 0 {
+		t.Errorf("expected no results for no input, got %d", len(got))
+	}
+	if got := ReciprocalRankFusion(general); len(got) != 3 || got[0].Note.ID != "a" {
+		t.Errorf("expected a single list to keep its order, got %v", got)
+	}
+}
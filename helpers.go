@@ -2,8 +2,12 @@ package cogito
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
+	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
 )
 
@@ -26,7 +30,9 @@ import (
 //	    return t, nil
 //	})
 func Do(identity pipz.Identity, fn func(context.Context, *Thought) (*Thought, error)) pipz.Processor[*Thought] {
-	return pipz.Apply(identity, fn)
+	return pipz.Apply(identity, func(ctx context.Context, t *Thought) (*Thought, error) {
+		return fn(withThoughtTrace(ctx, t), t)
+	})
 }
 
 // Transform creates a processor from a pure transformation function.
@@ -39,7 +45,9 @@ func Do(identity pipz.Identity, fn func(context.Context, *Thought) (*Thought, er
 //	    return t
 //	})
 func Transform(identity pipz.Identity, fn func(context.Context, *Thought) *Thought) pipz.Processor[*Thought] {
-	return pipz.Transform(identity, fn)
+	return pipz.Transform(identity, func(ctx context.Context, t *Thought) *Thought {
+		return fn(withThoughtTrace(ctx, t), t)
+	})
 }
 
 // Effect creates a processor that performs a side effect without modifying the thought.
@@ -52,7 +60,34 @@ func Transform(identity pipz.Identity, fn func(context.Context, *Thought) *Thoug
 //	    return nil
 //	})
 func Effect(identity pipz.Identity, fn func(context.Context, *Thought) error) pipz.Processor[*Thought] {
-	return pipz.Effect(identity, fn)
+	return pipz.Effect(identity, func(ctx context.Context, t *Thought) error {
+		return fn(withThoughtTrace(ctx, t), t)
+	})
+}
+
+// EffectWhen creates a processor that performs a side effect only when the predicate returns true.
+// The thought is always passed through unchanged.
+//
+// Example:
+//
+//	urgentMetric := cogito.EffectWhen(pipz.NewIdentity("urgent-metric", "Count urgent thoughts"),
+//	    func(ctx context.Context, t *cogito.Thought) bool {
+//	        urgency, _ := t.GetContent("urgency")
+//	        return urgency == "critical"
+//	    },
+//	    func(ctx context.Context, t *cogito.Thought) error {
+//	        metrics.Increment("urgent_thoughts")
+//	        return nil
+//	    },
+//	)
+func EffectWhen(identity pipz.Identity, predicate func(context.Context, *Thought) bool, fn func(context.Context, *Thought) error) pipz.Processor[*Thought] {
+	predicate = skipWhenFalse(identity, "effect_when", predicate)
+	return pipz.Effect(identity, func(ctx context.Context, t *Thought) error {
+		if !predicate(ctx, t) {
+			return nil
+		}
+		return fn(withThoughtTrace(ctx, t), t)
+	})
 }
 
 // Mutate creates a processor that conditionally modifies a thought.
@@ -71,7 +106,39 @@ func Effect(identity pipz.Identity, fn func(context.Context, *Thought) error) pi
 //	    },
 //	)
 func Mutate(identity pipz.Identity, fn func(context.Context, *Thought) *Thought, predicate func(context.Context, *Thought) bool) pipz.Processor[*Thought] {
-	return pipz.Mutate(identity, fn, predicate)
+	return pipz.Mutate(identity, func(ctx context.Context, t *Thought) *Thought {
+		return fn(withThoughtTrace(ctx, t), t)
+	}, skipWhenFalse(identity, "mutate", predicate))
+}
+
+// MutateE is Mutate with a predicate that can fail. A predicate error aborts the
+// step with that error instead of skipping the modification, for predicates that
+// do real work such as parsing a note.
+//
+// Example:
+//
+//	escalate := cogito.MutateE(pipz.NewIdentity("escalate", "Escalate high-value orders"),
+//	    func(ctx context.Context, t *cogito.Thought) *cogito.Thought {
+//	        t.SetContent(ctx, "priority", "high", "escalate")
+//	        return t
+//	    },
+//	    func(ctx context.Context, t *cogito.Thought) (bool, error) {
+//	        total, err := t.GetFloat("order_total")
+//	        return total > 1000, err
+//	    },
+//	)
+func MutateE(identity pipz.Identity, fn func(context.Context, *Thought) *Thought, predicate func(context.Context, *Thought) (bool, error)) pipz.Processor[*Thought] {
+	return pipz.Apply(identity, func(ctx context.Context, t *Thought) (*Thought, error) {
+		ok, err := predicate(ctx, t)
+		if err != nil {
+			return t, fmt.Errorf("predicate failed: %w", err)
+		}
+		if !ok {
+			emitPredicateSkipped(ctx, identity, "mutate", t)
+			return t, nil
+		}
+		return fn(withThoughtTrace(ctx, t), t), nil
+	})
 }
 
 // Enrich creates a processor that optionally enhances a thought.
@@ -88,16 +155,29 @@ func Mutate(identity pipz.Identity, fn func(context.Context, *Thought) *Thought,
 //	    return t, nil
 //	})
 func Enrich(identity pipz.Identity, fn func(context.Context, *Thought) (*Thought, error)) pipz.Processor[*Thought] {
-	return pipz.Enrich(identity, fn)
+	return pipz.Enrich(identity, func(ctx context.Context, t *Thought) (*Thought, error) {
+		return fn(withThoughtTrace(ctx, t), t)
+	})
 }
 
 // -----------------------------------------------------------------------------
 // Sequential Connectors - process thoughts in order
 // -----------------------------------------------------------------------------
 
+// ErrStopPipeline is returned by a processor to end the enclosing Sequence early as a
+// success. The remaining steps are skipped, emitting StepSkipped, and the sequence
+// returns the thought without an error. Wrap it to record why the pipeline stopped:
+//
+//	return t, fmt.Errorf("not our problem: %w", cogito.ErrStopPipeline)
+var ErrStopPipeline = errors.New("pipeline stopped")
+
 // Sequence creates a sequential pipeline of thought processors.
 // Each processor receives the output of the previous one.
 //
+// A processor that returns ErrStopPipeline ends the sequence early without an error.
+// This applies to the processors passed here, not to those added later with Register,
+// Push or similar methods.
+//
 // Example:
 //
 //	pipeline := cogito.Sequence(pipz.NewIdentity("reasoning-chain", "Main reasoning pipeline"),
@@ -105,7 +185,83 @@ func Enrich(identity pipz.Identity, fn func(context.Context, *Thought) (*Thought
 //	    cogito.NewDecide("decide", "What action to take?"),
 //	)
 func Sequence(identity pipz.Identity, processors ...pipz.Chainable[*Thought]) *pipz.Sequence[*Thought] {
-	return pipz.NewSequence(identity, processors...)
+	steps := make([]pipz.Chainable[*Thought], len(processors))
+	for i, p := range processors {
+		steps[i] = &stoppable{sequence: identity, first: i == 0, processor: p}
+	}
+	return pipz.NewSequence(identity, steps...)
+}
+
+// sequenceStopKey is the thought attribute recording that a sequence was stopped.
+type sequenceStopKey struct {
+	sequence pipz.Identity
+}
+
+// stoppable runs a sequence step, turning ErrStopPipeline into a clean early exit.
+// The first step clears any stop left by a previous run of the same sequence.
+type stoppable struct {
+	sequence  pipz.Identity
+	first     bool
+	processor pipz.Chainable[*Thought]
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (s *stoppable) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	key := sequenceStopKey{sequence: s.sequence}
+	if s.first {
+		t.attrs.Delete(key)
+	} else if reason, stopped := t.attrs.Load(key); stopped {
+		capitan.Emit(ctx, StepSkipped,
+			FieldTraceID.Field(t.TraceID),
+			FieldStepName.Field(s.processor.Identity().Name()),
+			FieldStepType.Field(s.processor.Schema().Type),
+			FieldReason.Field(reason.(string)),
+		)
+		return t, nil
+	}
+
+	result, err := s.processor.Process(ctx, t)
+	if errors.Is(err, ErrStopPipeline) {
+		if result == nil {
+			result = t
+		}
+		result.attrs.Store(key, err.Error())
+		return result, nil
+	}
+	return result, err
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (s *stoppable) Identity() pipz.Identity {
+	return s.processor.Identity()
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (s *stoppable) Schema() pipz.Node {
+	return s.processor.Schema()
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (s *stoppable) Close() error {
+	return s.processor.Close()
+}
+
+// SessionLimit wraps a processor so the session is trimmed to maxMessages with
+// TrimSession before the processor runs. Wrap steps deep in a long chain to keep the
+// accumulated session within the model's context window.
+//
+// Example:
+//
+//	decide := cogito.SessionLimit(pipz.NewIdentity("bounded-decide", "Decide with a bounded session"), 20,
+//	    cogito.NewDecide("escalate", "Should this be escalated?"),
+//	)
+func SessionLimit(identity pipz.Identity, maxMessages int, processor pipz.Chainable[*Thought]) *pipz.Sequence[*Thought] {
+	trim := Transform(pipz.NewIdentity(identity.Name()+"-trim", "Trims the session before the step"), func(_ context.Context, t *Thought) *Thought {
+		t.TrimSession(maxMessages)
+		return t
+	})
+	return pipz.NewSequence(identity, trim, processor)
 }
 
 // -----------------------------------------------------------------------------
@@ -126,7 +282,60 @@ func Sequence(identity pipz.Identity, processors ...pipz.Chainable[*Thought]) *p
 //	    urgentProcessor,
 //	)
 func Filter(identity pipz.Identity, predicate func(context.Context, *Thought) bool, processor pipz.Chainable[*Thought]) *pipz.Filter[*Thought] {
-	return pipz.NewFilter(identity, predicate, processor)
+	return pipz.NewFilter(identity, skipWhenFalse(identity, "filter", predicate), processor)
+}
+
+// FilterE is Filter with a predicate that can fail. A predicate error aborts the
+// step with that error instead of passing the thought through, for predicates
+// that do real work such as parsing a note.
+//
+// Example:
+//
+//	refundable := cogito.FilterE(pipz.NewIdentity("refundable", "Refund recent orders"),
+//	    func(ctx context.Context, t *cogito.Thought) (bool, error) {
+//	        days, err := t.GetInt("order_age_days")
+//	        return days <= 30, err
+//	    },
+//	    refundProcessor,
+//	)
+func FilterE(identity pipz.Identity, predicate func(context.Context, *Thought) (bool, error), processor pipz.Chainable[*Thought]) pipz.Chainable[*Thought] {
+	return &filterE{identity: identity, predicate: predicate, processor: processor}
+}
+
+// filterE is the connector behind FilterE. pipz.Filter conditions cannot fail,
+// so it evaluates the predicate itself and reports the same schema as Filter.
+type filterE struct {
+	identity  pipz.Identity
+	predicate func(context.Context, *Thought) (bool, error)
+	processor pipz.Chainable[*Thought]
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (f *filterE) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ok, err := f.predicate(ctx, t)
+	if err != nil {
+		return t, fmt.Errorf("%s: predicate failed: %w", f.identity.Name(), err)
+	}
+	if !ok {
+		emitPredicateSkipped(ctx, f.identity, "filter", t)
+		return t, nil
+	}
+	return f.processor.Process(ctx, t)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (f *filterE) Identity() pipz.Identity {
+	return f.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (f *filterE) Schema() pipz.Node {
+	return pipz.Node{Identity: f.identity, Type: "filter", Flow: pipz.FilterFlow{Processor: f.processor.Schema()}}
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (f *filterE) Close() error {
+	return f.processor.Close()
 }
 
 // Switch creates a router that directs thoughts to different processors.
@@ -154,6 +363,7 @@ func Switch(identity pipz.Identity, condition func(context.Context, *Thought) st
 //	    return err == nil
 //	})
 func Gate(identity pipz.Identity, predicate func(context.Context, *Thought) bool) pipz.Processor[*Thought] {
+	predicate = skipWhenFalse(identity, "gate", predicate)
 	return pipz.Apply(identity, func(ctx context.Context, t *Thought) (*Thought, error) {
 		if predicate(ctx, t) {
 			return t, nil
@@ -162,6 +372,37 @@ func Gate(identity pipz.Identity, predicate func(context.Context, *Thought) bool
 	})
 }
 
+// BlockedError is returned by Block when a thought fails its predicate.
+// Use errors.As to recover the reason, for example in a Handle or Fallback that
+// routes blocked thoughts to a rejection queue.
+type BlockedError struct {
+	Step   string // Name of the Block that rejected the thought
+	Reason string
+}
+
+// Error implements error.
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("blocked by %s: %s", e.Step, e.Reason)
+}
+
+// Block creates a gatekeeper that halts processing with a *BlockedError carrying
+// reason when the predicate returns false. Thoughts that pass continue unchanged.
+//
+// Example:
+//
+//	inScope := cogito.Block(pipz.NewIdentity("in-scope", "Reject out-of-scope tickets"), func(ctx context.Context, t *cogito.Thought) bool {
+//	    owner, _ := t.GetContent("owner")
+//	    return owner == "support"
+//	}, "ticket is not owned by support")
+func Block(identity pipz.Identity, predicate func(context.Context, *Thought) bool, reason string) pipz.Processor[*Thought] {
+	return pipz.Apply(identity, func(ctx context.Context, t *Thought) (*Thought, error) {
+		if predicate(ctx, t) {
+			return t, nil
+		}
+		return t, &BlockedError{Step: identity.Name(), Reason: reason}
+	})
+}
+
 // -----------------------------------------------------------------------------
 // Error Handling Connectors - handle failures gracefully
 // -----------------------------------------------------------------------------
@@ -239,6 +480,34 @@ func RateLimiter(identity pipz.Identity, requestsPerSecond float64, burst int, p
 	return pipz.NewRateLimiter(identity, requestsPerSecond, burst, processor)
 }
 
+// RateLimiterTokens reports how many tokens a rate limiter currently has available.
+// A value of 1 or more means the next request will pass without waiting.
+func RateLimiterTokens(rl *pipz.RateLimiter[*Thought]) float64 {
+	return rl.GetAvailableTokens()
+}
+
+// RateLimiterReserve reports how long the next request would wait for a token,
+// or 0 if one is available now. It does not consume a token, so callers can use
+// it to decide whether to queue, reject, or tell the user when to try again.
+// A limiter with a zero rate never refills and reports the maximum duration.
+//
+// Example:
+//
+//	if wait := cogito.RateLimiterReserve(limiter); wait > 0 {
+//	    return fmt.Errorf("busy, try again in %s", wait.Round(time.Millisecond))
+//	}
+func RateLimiterReserve(rl *pipz.RateLimiter[*Thought]) time.Duration {
+	needed := 1 - rl.GetAvailableTokens()
+	if needed <= 0 {
+		return 0
+	}
+	rate := rl.GetRate()
+	if rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(needed / rate * float64(time.Second))
+}
+
 // CircuitBreaker creates a processor that prevents cascade failures.
 // Opens the circuit after failureThreshold consecutive failures.
 //
@@ -249,6 +518,96 @@ func CircuitBreaker(identity pipz.Identity, processor pipz.Chainable[*Thought],
 	return pipz.NewCircuitBreaker(identity, processor, failureThreshold, resetTimeout)
 }
 
+// Circuit states reported by CircuitState.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitState reports whether a circuit breaker is closed, open, or half-open.
+// An open circuit whose reset timeout has elapsed reports half-open.
+func CircuitState(cb *pipz.CircuitBreaker[*Thought]) string {
+	return cb.GetState()
+}
+
+// ResetCircuit force-closes a circuit breaker and clears its failure count,
+// for operators who have confirmed the downstream provider recovered and do not
+// want to wait out the reset timeout.
+//
+// Example:
+//
+//	if cogito.CircuitState(breaker) == cogito.CircuitOpen && providerHealthy() {
+//	    cogito.ResetCircuit(breaker)
+//	}
+func ResetCircuit(cb *pipz.CircuitBreaker[*Thought]) {
+	cb.Reset()
+}
+
+// ResilienceConfig configures the wrappers applied by Resilient. A zero field
+// leaves its wrapper out.
+type ResilienceConfig struct {
+	Timeout         time.Duration // Deadline for each attempt
+	Attempts        int           // Total attempts; 1 or less disables retry
+	BaseDelay       time.Duration // Exponential backoff base delay; zero retries immediately
+	BreakerFailures int           // Consecutive failures that open the circuit
+	BreakerRecovery time.Duration // How long the circuit stays open before a trial call
+}
+
+// Resilient wraps processor in timeout, retry and circuit breaker in a fixed order:
+// the timeout is innermost, so it bounds each attempt rather than all attempts
+// together; retry sits around it; the breaker is outermost, so a call that fails
+// after every retry counts as a single failure and an open circuit skips retries.
+//
+// The outermost wrapper takes identity. Inner wrappers are named after it with
+// "-timeout" and "-retry" suffixes.
+//
+// Example:
+//
+//	reliable := cogito.Resilient(pipz.NewIdentity("classify", "Resilient classification"), classify, cogito.ResilienceConfig{
+//	    Timeout:         10 * time.Second,
+//	    Attempts:        3,
+//	    BaseDelay:       time.Second,
+//	    BreakerFailures: 5,
+//	    BreakerRecovery: 30 * time.Second,
+//	})
+func Resilient(identity pipz.Identity, processor pipz.Chainable[*Thought], cfg ResilienceConfig) pipz.Chainable[*Thought] {
+	type layer struct {
+		suffix string
+		wrap   func(pipz.Identity, pipz.Chainable[*Thought]) pipz.Chainable[*Thought]
+	}
+
+	var layers []layer
+	if cfg.Timeout > 0 {
+		layers = append(layers, layer{"timeout", func(id pipz.Identity, p pipz.Chainable[*Thought]) pipz.Chainable[*Thought] {
+			return Timeout(id, p, cfg.Timeout)
+		}})
+	}
+	if cfg.Attempts > 1 {
+		layers = append(layers, layer{"retry", func(id pipz.Identity, p pipz.Chainable[*Thought]) pipz.Chainable[*Thought] {
+			if cfg.BaseDelay > 0 {
+				return Backoff(id, p, cfg.Attempts, cfg.BaseDelay)
+			}
+			return Retry(id, p, cfg.Attempts)
+		}})
+	}
+	if cfg.BreakerFailures > 0 {
+		layers = append(layers, layer{"breaker", func(id pipz.Identity, p pipz.Chainable[*Thought]) pipz.Chainable[*Thought] {
+			return CircuitBreaker(id, p, cfg.BreakerFailures, cfg.BreakerRecovery)
+		}})
+	}
+
+	wrapped := processor
+	for i, l := range layers {
+		id := identity
+		if i < len(layers)-1 {
+			id = pipz.NewIdentity(identity.Name()+"-"+l.suffix, identity.Description())
+		}
+		wrapped = l.wrap(id, wrapped)
+	}
+	return wrapped
+}
+
 // -----------------------------------------------------------------------------
 // Parallel Connectors - process thoughts concurrently
 // These require *Thought to implement pipz.Cloner[*Thought] (see thought.go Clone())
@@ -268,6 +627,31 @@ func Concurrent(identity pipz.Identity, reducer func(original *Thought, results
 	return pipz.NewConcurrent(identity, reducer, processors...)
 }
 
+// ConcurrentWithTimeout runs all processors in parallel with a deadline on each branch.
+// Branches that exceed perBranchTimeout are canceled and reported in the reducer's
+// error map under their own identity, so one hung branch cannot stall the fan-out.
+//
+// Example:
+//
+//	parallel := cogito.ConcurrentWithTimeout(pipz.NewIdentity("enrich-all", "Bounded parallel enrichment"), 5*time.Second,
+//	    func(original *cogito.Thought, results map[pipz.Identity]*cogito.Thought, errs map[pipz.Identity]error) *cogito.Thought {
+//	        for id, err := range errs {
+//	            log.Printf("branch %s failed: %v", id.Name(), err)
+//	        }
+//	        return original
+//	    },
+//	    crmLookup,
+//	    billingLookup,
+//	)
+func ConcurrentWithTimeout(identity pipz.Identity, perBranchTimeout time.Duration, reducer func(original *Thought, results map[pipz.Identity]*Thought, errors map[pipz.Identity]error) *Thought, processors ...pipz.Chainable[*Thought]) *pipz.Concurrent[*Thought] {
+	bounded := make([]pipz.Chainable[*Thought], len(processors))
+	for i, p := range processors {
+		// Reuse the branch identity so reducer map keys match the original processors
+		bounded[i] = pipz.NewTimeout(p.Identity(), p, perBranchTimeout)
+	}
+	return pipz.NewConcurrent(identity, reducer, bounded...)
+}
+
 // Race runs all processors in parallel and returns the first successful result.
 // Useful for reducing latency when multiple paths can produce the same result.
 //
@@ -295,3 +679,24 @@ func Race(identity pipz.Identity, processors ...pipz.Chainable[*Thought]) *pipz.
 func WorkerPool(identity pipz.Identity, workers int, processors ...pipz.Chainable[*Thought]) *pipz.WorkerPool[*Thought] {
 	return pipz.NewWorkerPool(identity, workers, processors...)
 }
+
+// skipWhenFalse wraps a predicate so that a false result emits StepSkipped.
+func skipWhenFalse(identity pipz.Identity, stepType string, predicate func(context.Context, *Thought) bool) func(context.Context, *Thought) bool {
+	return func(ctx context.Context, t *Thought) bool {
+		if predicate(ctx, t) {
+			return true
+		}
+		emitPredicateSkipped(ctx, identity, stepType, t)
+		return false
+	}
+}
+
+// emitPredicateSkipped emits StepSkipped for a step whose predicate returned false.
+func emitPredicateSkipped(ctx context.Context, identity pipz.Identity, stepType string, t *Thought) {
+	capitan.Emit(ctx, StepSkipped,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(identity.Name()),
+		FieldStepType.Field(stepType),
+		FieldReason.Field("predicate returned false"),
+	)
+}
@@ -3,9 +3,14 @@ package cogito
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/zoobzio/capitan"
+	capitantesting "github.com/zoobzio/capitan/testing"
 	"github.com/zoobzio/pipz"
 )
 
@@ -201,6 +206,56 @@ func TestEffect(t *testing.T) {
 	}
 }
 
+func TestEffectWhen(t *testing.T) {
+	t.Run("runs when predicate true", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "urgency", "critical", "test")
+
+		called := false
+		processor := EffectWhen(pipz.NewIdentity("urgent-metric", "Test processor"),
+			func(ctx context.Context, th *Thought) bool {
+				urgency, _ := th.GetContent("urgency")
+				return urgency == "critical"
+			},
+			func(ctx context.Context, th *Thought) error {
+				called = true
+				return nil
+			},
+		)
+
+		if _, err := processor.Process(context.Background(), thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !called {
+			t.Error("expected effect to run")
+		}
+	})
+
+	t.Run("skips when predicate false", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "urgency", "low", "test")
+
+		called := false
+		processor := EffectWhen(pipz.NewIdentity("urgent-metric", "Test processor"),
+			func(ctx context.Context, th *Thought) bool {
+				urgency, _ := th.GetContent("urgency")
+				return urgency == "critical"
+			},
+			func(ctx context.Context, th *Thought) error {
+				called = true
+				return errors.New("should not run")
+			},
+		)
+
+		if _, err := processor.Process(context.Background(), thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if called {
+			t.Error("expected effect to be skipped")
+		}
+	})
+}
+
 func TestMutate(t *testing.T) {
 	t.Run("applies when predicate true", func(t *testing.T) {
 		thought := newTestThought("test")
@@ -255,6 +310,55 @@ func TestMutate(t *testing.T) {
 	})
 }
 
+func TestMutateE(t *testing.T) {
+	upgrade := func(ctx context.Context, th *Thought) *Thought {
+		th.SetContent(ctx, "priority", "urgent", "upgrade")
+		return th
+	}
+	predicate := func(_ context.Context, th *Thought) (bool, error) {
+		return th.GetBool("escalate")
+	}
+
+	t.Run("applies when predicate true", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "escalate", "yes", "test")
+
+		result, err := MutateE(pipz.NewIdentity("upgrade", "Test processor"), upgrade, predicate).Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if priority, _ := result.GetContent("priority"); priority != "urgent" {
+			t.Errorf("expected priority 'urgent', got %q", priority)
+		}
+	})
+
+	t.Run("skips when predicate false", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "escalate", "no", "test")
+
+		result, err := MutateE(pipz.NewIdentity("upgrade", "Test processor"), upgrade, predicate).Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := result.GetContent("priority"); err == nil {
+			t.Error("expected mutation to be skipped")
+		}
+	})
+
+	t.Run("predicate error aborts", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "escalate", "maybe", "test")
+
+		_, err := MutateE(pipz.NewIdentity("upgrade", "Test processor"), upgrade, predicate).Process(context.Background(), thought)
+		if err == nil || !strings.Contains(err.Error(), "predicate failed") {
+			t.Fatalf("expected predicate error, got %v", err)
+		}
+		if _, err := thought.GetContent("priority"); err == nil {
+			t.Error("expected mutation not to run")
+		}
+	})
+}
+
 func TestEnrich(t *testing.T) {
 	t.Run("applies enrichment on success", func(t *testing.T) {
 		thought := newTestThought("test")
@@ -324,6 +428,57 @@ func TestSequence(t *testing.T) {
 	}
 }
 
+func TestSequenceStopPipeline(t *testing.T) {
+	capture := capitantesting.NewEventCapture()
+	listener := capitan.Hook(StepSkipped, capture.Handler())
+	defer listener.Close()
+
+	seq := Sequence(pipz.NewIdentity("stoppable", "Test pipeline"),
+		Do(pipz.NewIdentity("triage", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			if content, _ := th.GetContent("owner"); content != "us" {
+				return th, fmt.Errorf("not our problem: %w", ErrStopPipeline)
+			}
+			return th, nil
+		}),
+		Do(pipz.NewIdentity("handle", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			th.SetContent(ctx, "handled", "yes", "handle")
+			return th, nil
+		}),
+	)
+
+	thought := newTestThought("test")
+	thought.SetContent(context.Background(), "owner", "them", "test")
+
+	result, err := seq.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("expected clean early exit, got %v", err)
+	}
+	if _, err := result.GetContent("handled"); err == nil {
+		t.Error("expected remaining step to be skipped")
+	}
+
+	if !capture.WaitForCount(1, time.Second) {
+		t.Fatalf("expected StepSkipped event, got %d", len(capture.Events()))
+	}
+	event := capture.Events()[0]
+	if got := getStringField(event, FieldStepName.Name()); got != "handle" {
+		t.Errorf("expected skipped step %q, got %q", "handle", got)
+	}
+	if got := getStringField(event, FieldReason.Name()); !strings.Contains(got, "not our problem") {
+		t.Errorf("expected stop reason, got %q", got)
+	}
+
+	// A later run of the same sequence is not affected by the earlier stop.
+	result.SetContent(context.Background(), "owner", "us", "test")
+	result, err = seq.Process(context.Background(), result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handled, _ := result.GetContent("handled"); handled != "yes" {
+		t.Error("expected remaining step to run when not stopped")
+	}
+}
+
 func TestFilter(t *testing.T) {
 	t.Run("executes processor when predicate true", func(t *testing.T) {
 		thought := newTestThought("test")
@@ -378,6 +533,68 @@ func TestFilter(t *testing.T) {
 	})
 }
 
+func TestFilterE(t *testing.T) {
+	handler := func() pipz.Chainable[*Thought] {
+		return Do(pipz.NewIdentity("handle-refund", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			th.SetContent(ctx, "handled", "yes", "handle-refund")
+			return th, nil
+		})
+	}
+	predicate := func(_ context.Context, th *Thought) (bool, error) {
+		days, err := th.GetInt("age")
+		return days <= 30, err
+	}
+
+	t.Run("executes processor when predicate true", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "age", "10", "test")
+
+		result, err := FilterE(pipz.NewIdentity("refundable", "Test filter"), predicate, handler()).Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if handled, _ := result.GetContent("handled"); handled != "yes" {
+			t.Errorf("expected handled 'yes', got %q", handled)
+		}
+	})
+
+	t.Run("passes through when predicate false", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "age", "90", "test")
+
+		result, err := FilterE(pipz.NewIdentity("refundable", "Test filter"), predicate, handler()).Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := result.GetContent("handled"); err == nil {
+			t.Error("expected processor to be skipped")
+		}
+	})
+
+	t.Run("predicate error aborts", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "age", "corrupt", "test")
+
+		result, err := FilterE(pipz.NewIdentity("refundable", "Test filter"), predicate, handler()).Process(context.Background(), thought)
+		if err == nil || !strings.Contains(err.Error(), "predicate failed") {
+			t.Fatalf("expected predicate error, got %v", err)
+		}
+		if _, err := result.GetContent("handled"); err == nil {
+			t.Error("expected processor not to run")
+		}
+	})
+
+	t.Run("schema matches Filter", func(t *testing.T) {
+		node := FilterE(pipz.NewIdentity("refundable", "Test filter"), predicate, handler()).Schema()
+		if node.Type != "filter" {
+			t.Errorf("expected type 'filter', got %q", node.Type)
+		}
+		if _, ok := node.Flow.(pipz.FilterFlow); !ok {
+			t.Errorf("expected FilterFlow, got %T", node.Flow)
+		}
+	})
+}
+
 func TestSwitch(t *testing.T) {
 	thought := newTestThought("test")
 	thought.SetContent(context.Background(), "category", "question", "test")
@@ -447,6 +664,52 @@ func TestGate(t *testing.T) {
 	})
 }
 
+func TestBlock(t *testing.T) {
+	inScope := func(_ context.Context, th *Thought) bool {
+		owner, _ := th.GetContent("owner")
+		return owner == "support"
+	}
+
+	t.Run("passes through when predicate true", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "owner", "support", "test")
+
+		block := Block(pipz.NewIdentity("in-scope", "Test block"), inScope, "not ours")
+		result, err := block.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != thought {
+			t.Error("expected same thought returned")
+		}
+	})
+
+	t.Run("halts sequence with reason when predicate false", func(t *testing.T) {
+		thought := newTestThought("test")
+		thought.SetContent(context.Background(), "owner", "billing", "test")
+
+		seq := Sequence(pipz.NewIdentity("pipeline", "Test pipeline"),
+			Block(pipz.NewIdentity("in-scope", "Test block"), inScope, "not ours"),
+			Do(pipz.NewIdentity("handle", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+				th.SetContent(ctx, "handled", "yes", "handle")
+				return th, nil
+			}),
+		)
+
+		_, err := seq.Process(context.Background(), thought)
+		var blocked *BlockedError
+		if !errors.As(err, &blocked) {
+			t.Fatalf("expected BlockedError, got %v", err)
+		}
+		if blocked.Reason != "not ours" || blocked.Step != "in-scope" {
+			t.Errorf("unexpected blocked error: %+v", blocked)
+		}
+		if _, err := thought.GetContent("handled"); err == nil {
+			t.Error("expected later steps not to run")
+		}
+	})
+}
+
 func TestFallback(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -558,6 +821,95 @@ func TestCircuitBreaker(t *testing.T) {
 	}
 }
 
+func TestCircuitStateAndReset(t *testing.T) {
+	thought := newTestThought("test")
+
+	healthy := false
+	cb := CircuitBreaker(pipz.NewIdentity("breaker", "Test circuit breaker"), Do(pipz.NewIdentity("flaky-service", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+		if !healthy {
+			return th, errors.New("service down")
+		}
+		return th, nil
+	}), 2, time.Hour)
+
+	if state := CircuitState(cb); state != CircuitClosed {
+		t.Fatalf("expected new circuit to be closed, got %q", state)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Process(context.Background(), thought)
+	}
+	if state := CircuitState(cb); state != CircuitOpen {
+		t.Fatalf("expected circuit to be open, got %q", state)
+	}
+
+	// Recovered downstream is still rejected until the circuit is reset
+	healthy = true
+	if _, err := cb.Process(context.Background(), thought); err == nil {
+		t.Fatal("expected open circuit to reject the call")
+	}
+
+	ResetCircuit(cb)
+	if state := CircuitState(cb); state != CircuitClosed {
+		t.Fatalf("expected reset circuit to be closed, got %q", state)
+	}
+	if _, err := cb.Process(context.Background(), thought); err != nil {
+		t.Errorf("expected call to succeed after reset, got %v", err)
+	}
+}
+
+func TestResilient(t *testing.T) {
+	t.Run("timeout bounds each attempt", func(t *testing.T) {
+		// Timeout runs each attempt in its own goroutine
+		var attempts atomic.Int32
+		resilient := Resilient(pipz.NewIdentity("resilient", "Test resilience"), Do(pipz.NewIdentity("slow-then-fast", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			if attempts.Add(1) == 1 {
+				<-ctx.Done()
+				return th, ctx.Err()
+			}
+			return th, nil
+		}), ResilienceConfig{Timeout: 20 * time.Millisecond, Attempts: 2})
+
+		if _, err := resilient.Process(context.Background(), newTestThought("test")); err != nil {
+			t.Fatalf("expected second attempt to succeed, got %v", err)
+		}
+		if n := attempts.Load(); n != 2 {
+			t.Errorf("expected 2 attempts, got %d", n)
+		}
+	})
+
+	t.Run("breaker counts a retried call once", func(t *testing.T) {
+		calls := 0
+		resilient := Resilient(pipz.NewIdentity("resilient", "Test resilience"), Do(pipz.NewIdentity("down", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			calls++
+			return th, errors.New("service down")
+		}), ResilienceConfig{Attempts: 3, BreakerFailures: 2, BreakerRecovery: time.Hour})
+
+		for i := 0; i < 4; i++ {
+			_, _ = resilient.Process(context.Background(), newTestThought("test"))
+		}
+		if calls != 6 {
+			t.Errorf("expected 2 calls of 3 attempts before the circuit opened, got %d calls", calls)
+		}
+		cb, ok := resilient.(*pipz.CircuitBreaker[*Thought])
+		if !ok {
+			t.Fatalf("expected circuit breaker outermost, got %T", resilient)
+		}
+		if cb.Identity().Name() != "resilient" {
+			t.Errorf("expected outermost wrapper to take the identity, got %q", cb.Identity().Name())
+		}
+	})
+
+	t.Run("empty config returns processor", func(t *testing.T) {
+		processor := Do(pipz.NewIdentity("plain", "Test processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+			return th, nil
+		})
+		if got := Resilient(pipz.NewIdentity("resilient", "Test resilience"), processor, ResilienceConfig{}); got.Identity() != processor.Identity() {
+			t.Error("expected processor returned unwrapped")
+		}
+	})
+}
+
 func TestRateLimiter(t *testing.T) {
 	// RateLimiter now requires a processor parameter
 	processor := Do(pipz.NewIdentity("inner", "Inner processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
@@ -580,6 +932,38 @@ func TestRateLimiter(t *testing.T) {
 	}
 }
 
+func TestRateLimiterTokensAndReserve(t *testing.T) {
+	processor := Transform(pipz.NewIdentity("inner", "Inner processor"), func(_ context.Context, th *Thought) *Thought {
+		return th
+	})
+	rl := RateLimiter(pipz.NewIdentity("limiter", "Test rate limiter"), 1, 2, processor)
+
+	if tokens := RateLimiterTokens(rl); tokens < 2 {
+		t.Errorf("expected full bucket of 2 tokens, got %v", tokens)
+	}
+	if wait := RateLimiterReserve(rl); wait != 0 {
+		t.Errorf("expected no wait with tokens available, got %v", wait)
+	}
+
+	thought := newTestThought("test")
+	for i := 0; i < 2; i++ {
+		if _, err := rl.Process(context.Background(), thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if tokens := RateLimiterTokens(rl); tokens >= 1 {
+		t.Errorf("expected drained bucket, got %v tokens", tokens)
+	}
+	wait := RateLimiterReserve(rl)
+	if wait <= 0 || wait > time.Second {
+		t.Errorf("expected wait in (0, 1s], got %v", wait)
+	}
+	if tokens := RateLimiterTokens(rl); tokens >= 1 {
+		t.Errorf("expected reserve not to add tokens, got %v", tokens)
+	}
+}
+
 func TestConcurrent(t *testing.T) {
 	thought := newTestThought("test")
 	thought.SetContent(context.Background(), "input", "value", "test")
@@ -620,6 +1004,49 @@ func TestConcurrent(t *testing.T) {
 	}
 }
 
+func TestConcurrentWithTimeout(t *testing.T) {
+	thought := newTestThought("test")
+
+	fast := pipz.NewIdentity("fast", "Test processor")
+	hung := pipz.NewIdentity("hung", "Test processor")
+
+	var succeeded, failed []string
+	concurrent := ConcurrentWithTimeout(pipz.NewIdentity("parallel", "Test concurrent"), 50*time.Millisecond,
+		func(original *Thought, results map[pipz.Identity]*Thought, errs map[pipz.Identity]error) *Thought {
+			for identity := range results {
+				succeeded = append(succeeded, identity.Name())
+			}
+			for identity := range errs {
+				failed = append(failed, identity.Name())
+			}
+			return original
+		},
+		Do(fast, func(ctx context.Context, th *Thought) (*Thought, error) {
+			return th, nil
+		}),
+		Do(hung, func(ctx context.Context, th *Thought) (*Thought, error) {
+			<-ctx.Done()
+			time.Sleep(time.Second) // ignores cancellation
+			return th, nil
+		}),
+	)
+
+	start := time.Now()
+	if _, err := concurrent.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected hung branch to be abandoned, took %v", elapsed)
+	}
+
+	if len(succeeded) != 1 || succeeded[0] != "fast" {
+		t.Errorf("expected only fast branch to succeed, got %v", succeeded)
+	}
+	if len(failed) != 1 || failed[0] != "hung" {
+		t.Errorf("expected hung branch in error map, got %v", failed)
+	}
+}
+
 func TestRace(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -700,3 +1127,25 @@ func TestThoughtClone(t *testing.T) {
 		t.Errorf("expected clone key1 'modified', got %q", cloneVal)
 	}
 }
+
+func TestSessionLimit(t *testing.T) {
+	thought := newTestThought("test")
+	for i := 0; i < 5; i++ {
+		thought.Session.Append("user", fmt.Sprintf("message %d", i))
+	}
+
+	var seen int
+	step := SessionLimit(pipz.NewIdentity("bounded", "Test bounded step"), 2,
+		Do(pipz.NewIdentity("count", "Test processor"), func(_ context.Context, th *Thought) (*Thought, error) {
+			seen = th.Session.Len()
+			return th, nil
+		}),
+	)
+
+	if _, err := step.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("expected the step to see 2 messages, got %d", seen)
+	}
+}
@@ -0,0 +1,47 @@
+package cogito
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Global ID generator state.
+var (
+	idGenerator   func() string
+	idGeneratorMu sync.RWMutex
+)
+
+// SetIDGenerator sets the function used to generate trace IDs for new thoughts,
+// and IDs for thoughts and notes held by NopMemory. Use it to make thought creation
+// deterministic in tests and replays, or to derive trace IDs from upstream request
+// IDs. Passing nil restores the default of random UUIDs.
+//
+// Generated IDs must be unique: SoyMemory enforces unique trace IDs. To give a
+// single thought a known trace ID, use NewWithTrace instead.
+//
+// Example:
+//
+//	var n int
+//	cogito.SetIDGenerator(func() string {
+//	    n++
+//	    return fmt.Sprintf("trace-%d", n)
+//	})
+//	defer cogito.SetIDGenerator(nil)
+func SetIDGenerator(fn func() string) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	idGenerator = fn
+}
+
+// NewID returns an ID from the configured generator, or a random UUID by default.
+// Memory implementations that assign IDs themselves can use it to honor SetIDGenerator.
+func NewID() string {
+	idGeneratorMu.RLock()
+	fn := idGenerator
+	idGeneratorMu.RUnlock()
+	if fn == nil {
+		return uuid.New().String()
+	}
+	return fn()
+}
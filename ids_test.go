@@ -0,0 +1,35 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestSetIDGenerator(t *testing.T) {
+	n := 0
+	SetIDGenerator(func() string {
+		n++
+		return fmt.Sprintf("id-%d", n)
+	})
+	defer SetIDGenerator(nil)
+
+	ctx := context.Background()
+	first, err := New(ctx, NopMemory{}, "deterministic")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.TraceID != "id-1" || first.ID != "id-2" {
+		t.Errorf("expected trace id-1 and id id-2, got %q and %q", first.TraceID, first.ID)
+	}
+
+	second, _ := New(ctx, NopMemory{}, "deterministic")
+	if second.TraceID != "id-3" {
+		t.Errorf("expected trace id-3, got %q", second.TraceID)
+	}
+
+	SetIDGenerator(nil)
+	if id := NewID(); len(id) != 36 {
+		t.Errorf("expected UUID after reset, got %q", id)
+	}
+}
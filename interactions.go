@@ -0,0 +1,106 @@
+package cogito
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zoobzio/zyn"
+)
+
+// Interaction is one provider call made by a step, as recorded by raw capture.
+type Interaction struct {
+	Key        string // The step's note key
+	StepType   string // decide, analyze, categorize, ...
+	Dimension  string // The criterion ranked, for multi-criteria Prioritize calls
+	Prompt     string
+	Completion string
+	Tokens     zyn.TokenUsage
+	Created    time.Time
+}
+
+// Interactions returns the prompt and completion of every provider call made
+// against this thought by a step with raw capture enabled (WithRawCapture),
+// oldest first. A multi-criteria Prioritize step contributes one interaction per
+// dimension. Steps without raw capture, and introspection calls, are not
+// recorded and so do not appear.
+//
+// Example:
+//
+//	for _, call := range thought.Interactions() {
+//	    audit.Record(call.StepType, call.Prompt, call.Completion, call.Tokens.Total)
+//	}
+func (t *Thought) Interactions() []Interaction {
+	var interactions []Interaction
+	t.Walk(func(note Note) bool {
+		stepType, ok := rawCaptureStepType(note)
+		if !ok {
+			return true
+		}
+		key := strings.TrimSuffix(note.Key, "_raw")
+		if dimensions, err := GetMetadataValue[[]string](note, "dimensions"); err == nil {
+			interactions = append(interactions, dimensionInteractions(note, key, stepType, dimensions)...)
+			return true
+		}
+		interactions = append(interactions, rawInteraction(note, key, stepType, "", note.Content))
+		return true
+	})
+	return interactions
+}
+
+// dimensionInteractions splits a multi-criteria raw note, a JSON object of raw
+// responses keyed by dimension, into one interaction per dimension.
+func dimensionInteractions(note Note, key, stepType string, dimensions []string) []Interaction {
+	var responses map[string]string
+	if err := json.Unmarshal([]byte(note.Content), &responses); err != nil {
+		return []Interaction{rawInteraction(note, key, stepType, "", note.Content)}
+	}
+	interactions := make([]Interaction, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		interactions = append(interactions, rawInteraction(note, key, stepType, dimension, responses[dimension]))
+	}
+	return interactions
+}
+
+// rawInteraction builds an interaction from a raw note's metadata. For a dimension,
+// the fields are read with the "{dimension}." prefix.
+func rawInteraction(note Note, key, stepType, dimension, completion string) Interaction {
+	prefix := ""
+	if dimension != "" {
+		prefix = dimension + "."
+	}
+	return Interaction{
+		Key:        key,
+		StepType:   stepType,
+		Dimension:  dimension,
+		Prompt:     note.Metadata[prefix+"prompt"],
+		Completion: completion,
+		Tokens: zyn.TokenUsage{
+			Prompt:     metadataInt(note, prefix+"prompt_tokens"),
+			Completion: metadataInt(note, prefix+"completion_tokens"),
+			Total:      metadataInt(note, prefix+"total_tokens"),
+		},
+		Created: note.Created,
+	}
+}
+
+// rawCaptureStepType reports whether note was written by captureRawResponse and,
+// if so, the step type recorded in its source ("decide-raw" or "decide-raw:tag").
+func rawCaptureStepType(note Note) (string, bool) {
+	if !strings.HasSuffix(note.Key, "_raw") {
+		return "", false
+	}
+	source, _, _ := strings.Cut(note.Source, ":")
+	stepType, ok := strings.CutSuffix(source, "-raw")
+	return stepType, ok && stepType != ""
+}
+
+// metadataInt reads an integer metadata field, returning zero when absent or malformed.
+func metadataInt(note Note, field string) int {
+	n, err := strconv.Atoi(note.Metadata[field])
+	if err != nil {
+		return 0
+	}
+	return n
+}
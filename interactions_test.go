@@ -0,0 +1,75 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestThoughtInteractions(t *testing.T) {
+	SetProvider(&mockVerboseDecideProvider{})
+	defer SetProvider(nil)
+
+	ctx := context.Background()
+	thought := newTestThought("test interactions")
+	thought.SetContent(ctx, "ticket", "Production database is down", "input")
+
+	result, err := NewDecide("is_urgent", "Is this urgent?").WithRawCapture().Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err = NewDecide("needs_oncall", "Should on-call be paged?").Process(ctx, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	interactions := result.Interactions()
+	if len(interactions) != 1 {
+		t.Fatalf("expected 1 captured interaction, got %d", len(interactions))
+	}
+
+	call := interactions[0]
+	if call.Key != "is_urgent" || call.StepType != "decide" {
+		t.Errorf("expected decide call for is_urgent, got %s/%s", call.StepType, call.Key)
+	}
+	if !strings.Contains(call.Prompt, "Is this urgent?") {
+		t.Errorf("expected prompt to contain the question, got %q", call.Prompt)
+	}
+	if !strings.Contains(call.Completion, "Production outages are always urgent") {
+		t.Errorf("expected verbatim completion, got %q", call.Completion)
+	}
+	if call.Tokens.Total != 30 || call.Tokens.Prompt != 10 {
+		t.Errorf("expected token usage 10/20/30, got %+v", call.Tokens)
+	}
+}
+
+func TestThoughtInteractionsPrioritizeMulti(t *testing.T) {
+	step := NewPrioritizeMulti("priority", map[string]float64{"urgency": 1, "effort": 1}, []string{"login", "typo", "outage"}).
+		WithProvider(&mockDimensionProvider{}).
+		WithRawCapture()
+
+	result, err := step.Process(context.Background(), newTestThought("multi interactions"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	interactions := result.Interactions()
+	if len(interactions) != 2 {
+		t.Fatalf("expected one interaction per dimension, got %d", len(interactions))
+	}
+	for i, dimension := range []string{"effort", "urgency"} {
+		call := interactions[i]
+		if call.Key != "priority" || call.StepType != "prioritize" || call.Dimension != dimension {
+			t.Errorf("expected prioritize call for %s, got %s/%s/%s", dimension, call.StepType, call.Key, call.Dimension)
+		}
+		if !strings.Contains(call.Prompt, dimension) {
+			t.Errorf("%s: expected prompt to name the dimension, got %q", dimension, call.Prompt)
+		}
+		if call.Tokens.Total != 30 {
+			t.Errorf("%s: expected 30 total tokens, got %+v", dimension, call.Tokens)
+		}
+	}
+	if !strings.Contains(interactions[0].Completion, "one-line fix") || !strings.Contains(interactions[1].Completion, "blocks everyone") {
+		t.Errorf("expected each dimension's own completion, got %q and %q", interactions[0].Completion, interactions[1].Completion)
+	}
+}
@@ -2,12 +2,41 @@ package cogito
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/zyn"
 )
 
+// ErrIntrospectionFailed matches, via errors.Is, step errors raised during the
+// introspection phase. The reasoning note has already been stored when it occurs,
+// so only the summary is lost.
+var ErrIntrospectionFailed = errors.New("introspection failed")
+
+// introspectionError marks an error as an introspection failure without changing its message.
+type introspectionError struct {
+	err error
+}
+
+func (e *introspectionError) Error() string { return e.err.Error() }
+
+func (e *introspectionError) Unwrap() error { return e.err }
+
+func (e *introspectionError) Is(target error) bool { return target == ErrIntrospectionFailed }
+
+// emitStepFailed emits StepFailed with a severity derived from the failing phase:
+// introspection failures are reported at Warn, all other failures at Error.
+func emitStepFailed(ctx context.Context, err error, fields ...capitan.Field) {
+	if errors.Is(err, ErrIntrospectionFailed) {
+		capitan.Warn(ctx, StepFailed, fields...)
+		return
+	}
+	capitan.Error(ctx, StepFailed, fields...)
+}
+
 // introspectionConfig holds parameters for running introspection.
 type introspectionConfig struct {
 	stepType                 string
@@ -15,16 +44,74 @@ type introspectionConfig struct {
 	summaryKey               string
 	introspectionTemperature float32
 	synapsePrompt            string
+	style                    string // overrides input.Style when set
+	sourceTag                string // qualifies the note source when set
+	locale                   string // language for the summary when set
+	sessionContext           bool   // drops input.Context; the session already holds the notes
+}
+
+// noteSource returns the note source for a step, qualified as "source:tag" when tagged.
+func noteSource(source, tag string) string {
+	if tag == "" {
+		return source
+	}
+	return source + ":" + tag
+}
+
+// promptOr returns override if set, otherwise the step's default prompt.
+func promptOr(override, fallback string) string {
+	if override != "" {
+		return override
+	}
+	return fallback
+}
+
+// localize appends a locale directive to a reasoning synapse prompt. Free-text
+// reasoning is requested in locale while categories, items and field names stay
+// as given, so parsing and routing are unaffected.
+func localize(prompt, locale string) string {
+	if locale == "" {
+		return prompt
+	}
+	return prompt + " (write reasoning in " + locale + "; keep categories, items and JSON field names exactly as given)"
+}
+
+// localizeOutput appends a locale directive to a transform synapse style, for steps
+// whose main output is free text rather than a structured response.
+func localizeOutput(style, locale string) string {
+	if locale == "" {
+		return style
+	}
+	return style + " Write the output in " + locale + "."
 }
 
 // runIntrospection executes the transform synapse for semantic summary.
 // This is shared logic used by all primitives that support introspection.
+// It does nothing for steps IntrospectIfConsumed has marked as unread.
+// Returned errors match ErrIntrospectionFailed.
 func runIntrospection(
 	ctx context.Context,
 	t *Thought,
 	provider Provider,
 	input zyn.TransformInput,
 	cfg introspectionConfig,
+) error {
+	if introspectionSkipped(ctx, cfg.key) {
+		return nil
+	}
+	if err := introspect(ctx, t, provider, input, cfg); err != nil {
+		return &introspectionError{err: err}
+	}
+	return nil
+}
+
+// introspect runs the introspection phase and stores the summary note.
+func introspect(
+	ctx context.Context,
+	t *Thought,
+	provider Provider,
+	input zyn.TransformInput,
+	cfg introspectionConfig,
 ) error {
 	transformSynapse, err := zyn.Transform(cfg.synapsePrompt, provider)
 	if err != nil {
@@ -37,6 +124,15 @@ func runIntrospection(
 		introspectionTemp = cfg.introspectionTemperature
 	}
 	input.Temperature = introspectionTemp
+	if cfg.sessionContext {
+		input.Context = ""
+	}
+	if cfg.style != "" {
+		input.Style = cfg.style
+	}
+	if cfg.locale != "" {
+		input.Style += " Write the summary in " + cfg.locale + "."
+	}
 
 	summary, err := transformSynapse.FireWithInput(ctx, t.Session, input)
 	if err != nil {
@@ -49,7 +145,7 @@ func runIntrospection(
 		summaryKey = cfg.key + "_summary"
 	}
 
-	source := cfg.stepType + "-introspection"
+	source := noteSource(cfg.stepType+"-introspection", cfg.sourceTag)
 	if err := t.SetContent(ctx, summaryKey, summary, source); err != nil {
 		return fmt.Errorf("%s: failed to persist introspection note: %w", cfg.stepType, err)
 	}
@@ -57,8 +153,59 @@ func runIntrospection(
 	capitan.Emit(ctx, IntrospectionCompleted,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepType.Field(cfg.stepType),
-		FieldContextSize.Field(len(summary)),
+		FieldContextSize.Field(utf8.RuneCountInString(summary)),
 	)
 
 	return nil
 }
+
+// lastUserContent returns the prompt of the most recent synapse call, which zyn
+// records as the user message just before the latest assistant message.
+func lastUserContent(session *zyn.Session) (string, bool) {
+	messages := session.Messages()
+	if len(messages) < 2 || messages[len(messages)-2].Role != zyn.RoleUser {
+		return "", false
+	}
+	return messages[len(messages)-2].Content, true
+}
+
+// lastAssistantContent returns the raw model output of the most recent synapse call,
+// which zyn records as the session's latest assistant message.
+func lastAssistantContent(session *zyn.Session) (string, bool) {
+	messages := session.Messages()
+	if len(messages) == 0 || messages[len(messages)-1].Role != zyn.RoleAssistant {
+		return "", false
+	}
+	return messages[len(messages)-1].Content, true
+}
+
+// captureRawResponse stores the raw model output of the step's reasoning synapse
+// as a {key}_raw note, with the prompt and token usage of the call as metadata.
+// It must run before introspection replaces the latest response.
+func captureRawResponse(ctx context.Context, t *Thought, stepType, key, sourceTag string) error {
+	raw, ok := lastAssistantContent(t.Session)
+	if !ok {
+		return fmt.Errorf("%s: no raw response recorded in session", stepType)
+	}
+
+	metadata := rawCallMetadata(t.Session, "")
+	if err := t.SetNote(ctx, key+"_raw", raw, noteSource(stepType+"-raw", sourceTag), metadata); err != nil {
+		return fmt.Errorf("%s: failed to persist raw response: %w", stepType, err)
+	}
+	return nil
+}
+
+// rawCallMetadata returns the prompt and token usage of the session's latest call
+// as raw-capture metadata, with every field name prefixed by prefix.
+func rawCallMetadata(session *zyn.Session, prefix string) map[string]string {
+	metadata := make(map[string]string)
+	if prompt, ok := lastUserContent(session); ok {
+		metadata[prefix+"prompt"] = prompt
+	}
+	if usage := session.LastUsage(); usage != nil {
+		metadata[prefix+"prompt_tokens"] = strconv.Itoa(usage.Prompt)
+		metadata[prefix+"completion_tokens"] = strconv.Itoa(usage.Completion)
+		metadata[prefix+"total_tokens"] = strconv.Itoa(usage.Total)
+	}
+	return metadata
+}
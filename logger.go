@@ -0,0 +1,75 @@
+package cogito
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Global logger state.
+var (
+	globalLogger   *slog.Logger
+	globalLoggerMu sync.RWMutex
+)
+
+// SetLogger sets the base logger used by Thought.Logger.
+// Passing nil restores the default of slog.Default().
+func SetLogger(l *slog.Logger) {
+	globalLoggerMu.Lock()
+	defer globalLoggerMu.Unlock()
+	globalLogger = l
+}
+
+// GetLogger returns the base logger, falling back to slog.Default().
+func GetLogger() *slog.Logger {
+	globalLoggerMu.RLock()
+	defer globalLoggerMu.RUnlock()
+	if globalLogger == nil {
+		return slog.Default()
+	}
+	return globalLogger
+}
+
+// Logger returns the base logger pre-tagged with the thought's trace_id and intent,
+// so custom processors log with correlation without threading the trace ID by hand.
+//
+// Example:
+//
+//	step := cogito.Do(pipz.NewIdentity("lookup", "Look up account"), func(ctx context.Context, t *cogito.Thought) (*cogito.Thought, error) {
+//	    t.Logger().InfoContext(ctx, "looking up account")
+//	    return t, nil
+//	})
+func (t *Thought) Logger() *slog.Logger {
+	return GetLogger().With("trace_id", t.TraceID, "intent", t.Intent)
+}
+
+// traceIDKey is the context key set by WithTraceID.
+type traceIDKey struct{}
+
+// WithTraceID returns a copy of ctx carrying traceID. Primitives and the function
+// adapters (Do, Transform, Effect and similar) set the thought's trace ID before
+// running, so context-aware logging and metrics code can correlate work without
+// being handed the thought.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFrom returns the trace ID set by WithTraceID, if any.
+func TraceIDFrom(ctx context.Context) (string, bool) {
+	traceID, ok := ctx.Value(traceIDKey{}).(string)
+	return traceID, ok && traceID != ""
+}
+
+// withThoughtTrace tags ctx with the thought's trace ID unless it already carries it.
+func withThoughtTrace(ctx context.Context, t *Thought) context.Context {
+	if t == nil {
+		return ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if current, ok := TraceIDFrom(ctx); ok && current == t.TraceID {
+		return ctx
+	}
+	return WithTraceID(ctx, t.TraceID)
+}
@@ -0,0 +1,85 @@
+package cogito
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+func TestThoughtLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	thought := newTestThought("triage ticket")
+	thought.Logger().Info("looking up account", "account", "acme")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry %q: %v", buf.String(), err)
+	}
+	if entry["trace_id"] != thought.TraceID {
+		t.Errorf("expected trace_id %q, got %v", thought.TraceID, entry["trace_id"])
+	}
+	if entry["intent"] != "triage ticket" {
+		t.Errorf("expected intent 'triage ticket', got %v", entry["intent"])
+	}
+	if entry["account"] != "acme" {
+		t.Errorf("expected caller attributes to be kept, got %v", entry["account"])
+	}
+}
+
+func TestGetLoggerDefault(t *testing.T) {
+	SetLogger(nil)
+	if GetLogger() != slog.Default() {
+		t.Error("expected slog.Default() when no logger is set")
+	}
+}
+
+// traceCapturingProvider records the trace ID found in the provider call context.
+type traceCapturingProvider struct {
+	traceID string
+}
+
+func (p *traceCapturingProvider) Call(ctx context.Context, _ []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	p.traceID, _ = TraceIDFrom(ctx)
+	return &zyn.ProviderResponse{Content: `{"decision": true, "confidence": 0.9, "reasoning": ["ok"]}`}, nil
+}
+
+func (p *traceCapturingProvider) Name() string {
+	return "trace-capturing"
+}
+
+func TestTraceIDInContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := TraceIDFrom(ctx); ok {
+		t.Error("expected no trace ID in a bare context")
+	}
+
+	thought := newTestThoughtWithTrace("trace context", "trace-ctx-1")
+
+	var seen string
+	step := Do(pipz.NewIdentity("custom", "Custom processor"), func(ctx context.Context, th *Thought) (*Thought, error) {
+		seen, _ = TraceIDFrom(ctx)
+		return th, nil
+	})
+	if _, err := step.Process(ctx, thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen != "trace-ctx-1" {
+		t.Errorf("expected custom processor to see trace ID, got %q", seen)
+	}
+
+	provider := &traceCapturingProvider{}
+	if _, err := NewDecide("approve", "Approve?").WithProvider(provider).Process(ctx, thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.traceID != "trace-ctx-1" {
+		t.Errorf("expected primitive to pass trace ID to provider, got %q", provider.traceID)
+	}
+}
@@ -0,0 +1,241 @@
+package cogito
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// mapItemKey is the note key an item is seeded under on its isolated clone.
+const mapItemKey = "item"
+
+// MapAnalyze is a batch extraction primitive that implements pipz.Chainable[*Thought].
+// It runs an Analyze[T] over every item of a JSON-array note and collects the typed results.
+type MapAnalyze[T zyn.Validator] struct {
+	identity    pipz.Identity
+	key         string
+	listKey     string
+	analyze     *Analyze[T]
+	concurrency int
+	sourceTag   string
+}
+
+// NewMapAnalyze creates a per-item structured extraction primitive.
+//
+// The note at listKey must hold a JSON array. For each element, an Analyze[T] runs on
+// an isolated clone of the thought whose only note is the element, so items neither
+// see each other nor write to memory. String elements are given to the model as-is;
+// other elements as JSON. Items run one at a time unless WithConcurrency is set.
+//
+// Output Notes:
+//   - {key}: JSON array of T, in the order of the input items
+//
+// Example:
+//
+//	step := cogito.NewMapAnalyze[TicketData]("ticket_data", "tickets", "ticket metadata").
+//	    WithConcurrency(4)
+//	result, _ := step.Process(ctx, thought)
+//	tickets, _ := step.Scan(result)
+func NewMapAnalyze[T zyn.Validator](key, listKey, what string) *MapAnalyze[T] {
+	return &MapAnalyze[T]{
+		identity:    pipz.NewIdentity(key, "Per-item structured extraction primitive"),
+		key:         key,
+		listKey:     listKey,
+		analyze:     NewAnalyze[T](key, what),
+		concurrency: 1,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (m *MapAnalyze[T]) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	items, err := m.resolveItems(t)
+	if err != nil {
+		return t, err
+	}
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(m.key),
+		FieldStepType.Field("map_analyze"),
+		FieldItemCount.Field(len(items)),
+		FieldTemperature.Field(m.analyze.temperature),
+	)
+
+	results := make([]T, len(items))
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, max(m.concurrency, 1))
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = m.analyzeItem(ctx, t, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	for i, itemErr := range errs {
+		if itemErr != nil {
+			err := fmt.Errorf("item %d: %w", i, itemErr)
+			m.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("map-analyze: %w", err)
+		}
+	}
+
+	// Store collected results as JSON
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		m.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("map-analyze: failed to marshal results: %w", err)
+	}
+	metadata := map[string]string{
+		"items_key":  m.listKey,
+		"item_count": strconv.Itoa(len(items)),
+	}
+	if err := t.SetNote(ctx, m.key, string(resultsJSON), noteSource("map-analyze", m.sourceTag), metadata); err != nil {
+		m.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("map-analyze: failed to persist note: %w", err)
+	}
+
+	// Emit step completed
+	duration := time.Since(start)
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(m.key),
+		FieldStepType.Field("map_analyze"),
+		FieldStepDuration.Field(duration),
+		FieldNoteCount.Field(t.NoteCount()),
+		FieldItemCount.Field(len(items)),
+	)
+
+	return t, nil
+}
+
+// resolveItems reads the list note, rendering string elements as-is and others as JSON.
+func (m *MapAnalyze[T]) resolveItems(t *Thought) ([]string, error) {
+	content, err := t.GetContent(m.listKey)
+	if err != nil {
+		return nil, fmt.Errorf("map-analyze: %w", err)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, fmt.Errorf("map-analyze: note %q is not a JSON array: %w", m.listKey, err)
+	}
+
+	items := make([]string, len(raw))
+	for i, element := range raw {
+		var s string
+		if err := json.Unmarshal(element, &s); err == nil {
+			items[i] = s
+		} else {
+			items[i] = string(element)
+		}
+	}
+	return items, nil
+}
+
+// analyzeItem runs the extraction on a clone seeded with a single item.
+// The clone uses NopMemory so per-item notes are never persisted.
+func (m *MapAnalyze[T]) analyzeItem(ctx context.Context, t *Thought, item string) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	clone := t.Clone()
+	clone.SetMemory(NopMemory{})
+	clone.ClearNotes()
+	clone.AddNoteWithoutPersist(Note{Key: mapItemKey, Content: item, Source: "map-analyze", Created: time.Now()})
+
+	result, err := m.analyze.Process(ctx, clone)
+	if err != nil {
+		return zero, err
+	}
+	return m.analyze.Scan(result)
+}
+
+// emitFailed emits a step failed event.
+func (m *MapAnalyze[T]) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(m.key),
+		FieldStepType.Field("map_analyze"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (m *MapAnalyze[T]) Identity() pipz.Identity {
+	return m.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (m *MapAnalyze[T]) Schema() pipz.Node {
+	return stepSchema(m.identity, "map_analyze", false, []string{m.listKey}, []string{m.key})
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (m *MapAnalyze[T]) Close() error {
+	return nil
+}
+
+// Scan retrieves the typed results from a thought, in input order.
+func (m *MapAnalyze[T]) Scan(t *Thought) ([]T, error) {
+	content, err := t.GetContent(m.key)
+	if err != nil {
+		return nil, fmt.Errorf("map-analyze scan: %w", err)
+	}
+	var results []T
+	if err := json.Unmarshal([]byte(content), &results); err != nil {
+		return nil, fmt.Errorf("map-analyze scan: failed to unmarshal results: %w", err)
+	}
+	return results, nil
+}
+
+// Builder methods
+
+// WithProvider sets the provider for every item's extraction.
+func (m *MapAnalyze[T]) WithProvider(p Provider) *MapAnalyze[T] {
+	m.analyze.WithProvider(p)
+	return m
+}
+
+// WithTemperature sets the temperature for every item's extraction.
+func (m *MapAnalyze[T]) WithTemperature(temp float32) *MapAnalyze[T] {
+	m.analyze.WithTemperature(temp)
+	return m
+}
+
+// WithConcurrency sets how many items are extracted at once. Values below 1 mean 1.
+func (m *MapAnalyze[T]) WithConcurrency(n int) *MapAnalyze[T] {
+	m.concurrency = n
+	return m
+}
+
+// WithStructuredOutput constrains each extraction to a JSON schema generated from T.
+func (m *MapAnalyze[T]) WithStructuredOutput() *MapAnalyze[T] {
+	m.analyze.WithStructuredOutput()
+	return m
+}
+
+// WithSourceTag qualifies the source of the result note as "map-analyze:<tag>".
+func (m *MapAnalyze[T]) WithSourceTag(tag string) *MapAnalyze[T] {
+	m.sourceTag = tag
+	return m
+}
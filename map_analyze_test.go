@@ -0,0 +1,105 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/zyn"
+)
+
+// mockMapProvider extracts a severity from the single item in its prompt.
+type mockMapProvider struct {
+	mu       sync.Mutex
+	prompts  []string
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (m *mockMapProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	prompt := messages[len(messages)-1].Content
+	m.mu.Lock()
+	m.prompts = append(m.prompts, prompt)
+	m.mu.Unlock()
+
+	severity := "low"
+	if strings.Contains(prompt, "outage") {
+		severity = "critical"
+	}
+	return &zyn.ProviderResponse{Content: `{"severity": "` + severity + `", "component": "api", "user_tier": "standard"}`}, nil
+}
+
+func (m *mockMapProvider) Name() string {
+	return "mock-map"
+}
+
+func TestMapAnalyze(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test map analyze")
+	thought.SetContent(ctx, "tickets", `["Full outage in eu-west", "Typo on pricing page", {"title": "Another outage"}]`, "initial")
+	before := thought.NoteCount()
+
+	provider := &mockMapProvider{}
+	step := NewMapAnalyze[TicketData]("ticket_data", "tickets", "ticket metadata").
+		WithProvider(provider).
+		WithConcurrency(2)
+
+	result, err := step.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tickets, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	want := []string{"critical", "low", "critical"}
+	if len(tickets) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(tickets))
+	}
+	for i, ticket := range tickets {
+		if ticket.Severity != want[i] {
+			t.Errorf("item %d: expected severity %q, got %q", i, want[i], ticket.Severity)
+		}
+	}
+
+	for _, prompt := range provider.prompts {
+		if strings.Contains(prompt, "outage") && strings.Contains(prompt, "Typo") {
+			t.Error("expected each extraction to see only its own item")
+		}
+	}
+	if peak := provider.peak.Load(); peak > 2 {
+		t.Errorf("expected at most 2 concurrent extractions, got %d", peak)
+	}
+	if got := result.NoteCount(); got != before+1 {
+		t.Errorf("expected only the result note added, got %d new notes", got-before)
+	}
+}
+
+func TestMapAnalyzeErrors(t *testing.T) {
+	ctx := context.Background()
+	step := NewMapAnalyze[TicketData]("ticket_data", "tickets", "ticket metadata").WithProvider(&mockMapProvider{})
+
+	if _, err := step.Process(ctx, newTestThought("missing list")); err == nil {
+		t.Error("expected error for missing list note")
+	}
+
+	thought := newTestThought("not an array")
+	thought.SetContent(ctx, "tickets", "just text", "initial")
+	if _, err := step.Process(ctx, thought); err == nil {
+		t.Error("expected error for non-array list note")
+	}
+}
@@ -1,6 +1,10 @@
 package cogito
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 // Memory defines the interface for thought persistence.
 // Implementations handle the storage and retrieval of Thoughts and Notes.
@@ -17,18 +21,47 @@ type Memory interface {
 	// GetThoughtsByTaskID loads all thoughts for a task, ordered by creation time.
 	GetThoughtsByTaskID(ctx context.Context, taskID string) ([]*Thought, error)
 
+	// GetThoughtsByIntent loads thoughts whose intent exactly matches intent, ordered by
+	// creation time, skipping offset thoughts and returning at most limit (all when limit <= 0).
+	GetThoughtsByIntent(ctx context.Context, intent string, limit, offset int) ([]*Thought, error)
+
+	// GetThoughtsByDateRange loads thoughts created at or after from and before to,
+	// ordered by creation time, with the pagination of GetThoughtsByIntent. Notes are
+	// not loaded when NoteHydrationSkipped(ctx) reports true.
+	GetThoughtsByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*Thought, error)
+
 	// GetChildThoughts loads all thoughts that have the given thought as parent.
 	GetChildThoughts(ctx context.Context, parentID string) ([]*Thought, error)
 
+	// GetConversation loads the chain of thoughts from the root down to leafThoughtID
+	// by following ParentID, returning them oldest-first with notes hydrated.
+	GetConversation(ctx context.Context, leafThoughtID string) ([]*Thought, error)
+
 	// AddNote persists a note and returns it with ID populated.
 	AddNote(ctx context.Context, note *Note) (*Note, error)
 
-	// GetNotes loads all notes for a thought.
+	// GetNotes loads all notes for a thought, ordered by creation time and then Seq,
+	// so a reloaded thought has its notes in the order they were added.
 	GetNotes(ctx context.Context, thoughtID string) ([]Note, error)
 
-	// UpdateThought updates thought metadata (timestamps, publishedCount).
+	// GetNotesBySourceSince loads the notes of a thought written by source after the
+	// (since, afterID) cursor, ordered by creation time and then ID, for incremental
+	// pulls. A note created at since itself is included when its ID sorts after
+	// afterID, so paging with the Created and ID of the last note seen never skips
+	// notes created in the same instant. An empty afterID returns only notes created
+	// strictly after since.
+	GetNotesBySourceSince(ctx context.Context, thoughtID, source string, since time.Time, afterID string) ([]Note, error)
+
+	// UpdateThought updates thought metadata (timestamps, ParentID, publishedCount),
+	// the session and persistent attributes, so a later Resume can continue where
+	// the thought left off.
+	// Every primitive that publishes notes calls it when the step completes.
 	UpdateThought(ctx context.Context, thought *Thought) error
 
+	// Resume loads a thought by trace ID ready for more steps: notes hydrated, and
+	// the publish count and session restored from the last completed step or UpdateThought.
+	Resume(ctx context.Context, traceID string) (*Thought, error)
+
 	// DeleteThought removes a thought and all its notes.
 	DeleteThought(ctx context.Context, id string) error
 
@@ -39,10 +72,47 @@ type Memory interface {
 	// SearchNotesByTask finds the most relevant note per task.
 	// Returns the most recent thought for each task that has matching notes.
 	SearchNotesByTask(ctx context.Context, embedding Vector, limit int) ([]*Thought, error)
+
+	// BackfillEmbeddings embeds notes that were stored without an embedding,
+	// processing batchSize notes at a time. Returns the number of notes updated.
+	// An empty vector from embedder fails with ErrEmptyEmbedding.
+	BackfillEmbeddings(ctx context.Context, embedder Embedder, batchSize int) (int, error)
+
+	// UpdateNoteEmbedding sets the embedding of an already persisted note.
+	UpdateNoteEmbedding(ctx context.Context, noteID string, embedding Vector) error
+
+	// Ping verifies the backing store is reachable, for readiness and health checks.
+	Ping(ctx context.Context) error
 }
 
+// ErrNotFound is returned, wrapped, when no thought matches a Memory lookup.
+// Implementations must wrap it so callers such as GetOrCreateByTrace can tell a
+// missing thought from a failed lookup.
+var ErrNotFound = errors.New("thought not found")
+
+// DefaultBackfillBatchSize is used when BackfillEmbeddings is called with a non-positive batch size.
+const DefaultBackfillBatchSize = 100
+
 // NoteWithThought pairs a note with its parent thought for search results.
 type NoteWithThought struct {
 	Note    Note
 	Thought *Thought
 }
+
+// skipNoteHydrationKey is the context key set by WithoutNoteHydration.
+type skipNoteHydrationKey struct{}
+
+// WithoutNoteHydration returns a context under which GetThoughtsByDateRange returns
+// thoughts without their notes, for reports that only need thought metadata.
+// Memory implementations read the request with NoteHydrationSkipped.
+func WithoutNoteHydration(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipNoteHydrationKey{}, true)
+}
+
+// NoteHydrationSkipped reports whether ctx came from WithoutNoteHydration. Memory
+// implementations call it in GetThoughtsByDateRange and, when it returns true,
+// return thoughts with identity, lineage and timestamps but no notes.
+func NoteHydrationSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipNoteHydrationKey{}).(bool)
+	return skip
+}
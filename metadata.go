@@ -0,0 +1,92 @@
+package cogito
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SetMetadataValue stores v in the note's metadata under field. Strings are stored
+// as-is; other values are JSON-encoded, so the stored metadata stays a
+// map[string]string while callers keep typed access through GetMetadataValue.
+//
+// The metadata map is copied before the write, so calling it on a Note returned by
+// Thought.GetNote never changes the thought's own note. Use Thought.SetMetadataValue
+// to change a note held by a thought.
+//
+// Example:
+//
+//	note := cogito.Note{Key: "score", Content: "..."}
+//	note.SetMetadataValue("confidence", 0.92)
+//	note.SetMetadataValue("tags", []string{"billing", "urgent"})
+func (n *Note) SetMetadataValue(field string, v any) error {
+	value, err := encodeMetadataValue(field, v)
+	if err != nil {
+		return err
+	}
+	n.Metadata = copyMetadata(n.Metadata)
+	n.Metadata[field] = value
+	return nil
+}
+
+// SetMetadataValue stores v, encoded as Note.SetMetadataValue does, in the metadata
+// of the most recent note with the given key. The note gets a new metadata map, so
+// copies returned earlier by GetNote are unaffected and concurrent readers are safe.
+//
+// The change is in memory only and is not persisted: memory keeps the note as it
+// was added. To record a value durably, write a new version with SetNote.
+func (t *Thought) SetMetadataValue(key, field string, v any) error {
+	value, err := encodeMetadataValue(field, v)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	idx, ok := t.index.Load(key)
+	i, isInt := idx.(int)
+	if !ok || !isInt || i < 0 || i >= len(t.notes) {
+		return fmt.Errorf("note not found: %s", key)
+	}
+	metadata := copyMetadata(t.notes[i].Metadata)
+	metadata[field] = value
+	t.notes[i].Metadata = metadata
+	return nil
+}
+
+// encodeMetadataValue returns v as stored in metadata: strings as-is, anything else JSON-encoded.
+func encodeMetadataValue(field string, v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode metadata field %q: %w", field, err)
+	}
+	return string(data), nil
+}
+
+// GetMetadataValue decodes a metadata field of a note as T. A string T receives
+// the stored value unchanged; any other T is JSON-decoded, which also reads the
+// numeric and boolean metadata primitives write, such as "0.87" or "true".
+//
+// Example:
+//
+//	note, _ := thought.GetNote("score")
+//	confidence, err := cogito.GetMetadataValue[float64](note, "confidence")
+func GetMetadataValue[T any](n Note, field string) (T, error) {
+	var v T
+	value, ok := n.Metadata[field]
+	if !ok {
+		return v, fmt.Errorf("metadata field not found: %s.%s", n.Key, field)
+	}
+
+	if s, ok := any(&v).(*string); ok {
+		*s = value
+		return v, nil
+	}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return v, fmt.Errorf("cannot parse metadata field %s.%s as %T: %w", n.Key, field, v, err)
+	}
+	return v, nil
+}
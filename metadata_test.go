@@ -0,0 +1,117 @@
+package cogito
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestNoteMetadataValue(t *testing.T) {
+	note := Note{Key: "score", Content: "high"}
+	if err := note.SetMetadataValue("confidence", 0.92); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := note.SetMetadataValue("attempts", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := note.SetMetadataValue("tags", []string{"billing", "urgent"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := note.SetMetadataValue("owner", "alex"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if note.Metadata["owner"] != "alex" {
+		t.Errorf("expected strings stored as-is, got %q", note.Metadata["owner"])
+	}
+
+	thought := newTestThought("metadata")
+	if err := thought.AddNote(context.Background(), note); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored, _ := thought.GetNote("score")
+
+	if v, err := GetMetadataValue[float64](stored, "confidence"); err != nil || v != 0.92 {
+		t.Errorf("expected confidence 0.92, got %v (%v)", v, err)
+	}
+	if v, err := GetMetadataValue[int](stored, "attempts"); err != nil || v != 3 {
+		t.Errorf("expected attempts 3, got %v (%v)", v, err)
+	}
+	if v, err := GetMetadataValue[[]string](stored, "tags"); err != nil || len(v) != 2 || v[1] != "urgent" {
+		t.Errorf("expected tags [billing urgent], got %v (%v)", v, err)
+	}
+	if v, err := GetMetadataValue[string](stored, "owner"); err != nil || v != "alex" {
+		t.Errorf("expected owner alex, got %q (%v)", v, err)
+	}
+
+	if _, err := GetMetadataValue[int](stored, "missing"); err == nil {
+		t.Error("expected error for missing field")
+	}
+	if _, err := GetMetadataValue[int](stored, "owner"); err == nil {
+		t.Error("expected error decoding a string as int")
+	}
+}
+
+func TestGetMetadataValueReadsPrimitiveMetadata(t *testing.T) {
+	note := Note{Key: "route", Metadata: map[string]string{"ambiguous": "true", "secondary_confidence": "0.48"}}
+
+	if v, err := GetMetadataValue[bool](note, "ambiguous"); err != nil || !v {
+		t.Errorf("expected ambiguous true, got %v (%v)", v, err)
+	}
+	if v, err := GetMetadataValue[float64](note, "secondary_confidence"); err != nil || v != 0.48 {
+		t.Errorf("expected 0.48, got %v (%v)", v, err)
+	}
+}
+
+func TestSetMetadataValueCopyOnWrite(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("metadata")
+	if err := thought.SetNote(ctx, "score", "high", "test", map[string]string{"confidence": "0.5"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Editing a returned copy leaves the thought's note alone
+	copied, _ := thought.GetNote("score")
+	if err := copied.SetMetadataValue("confidence", 0.9); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := thought.GetMetadata("score", "confidence"); v != "0.5" {
+		t.Errorf("expected thought note unchanged, got %q", v)
+	}
+
+	before, _ := thought.GetNote("score")
+	if err := thought.SetMetadataValue("score", "confidence", 0.9); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := thought.GetMetadata("score", "confidence"); v != "0.9" {
+		t.Errorf("expected thought note updated, got %q", v)
+	}
+	if before.Metadata["confidence"] != "0.5" {
+		t.Errorf("expected earlier copy unaffected, got %q", before.Metadata["confidence"])
+	}
+	if err := thought.SetMetadataValue("missing", "confidence", 1); err == nil {
+		t.Error("expected error for missing note")
+	}
+}
+
+func TestSetMetadataValueConcurrentReaders(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("metadata")
+	_ = thought.SetNote(ctx, "score", "high", "test", map[string]string{"confidence": "0.5"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = thought.SetMetadataValue("score", "attempt", i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			note, _ := thought.GetNote("score")
+			for range note.Metadata {
+			}
+		}()
+	}
+	wg.Wait()
+}
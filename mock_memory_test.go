@@ -3,6 +3,7 @@ package cogito
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,13 +14,22 @@ import (
 type mockMemory struct {
 	thoughts map[string]*Thought
 	notes    map[string][]Note
+	states   map[string]mockThoughtState
 	mu       sync.RWMutex
 }
 
+// mockThoughtState is the state UpdateThought saves for Resume, as SoyMemory stores it.
+type mockThoughtState struct {
+	publishedCount int
+	session        string
+	attrs          string
+}
+
 func newMockMemory() *mockMemory {
 	return &mockMemory{
 		thoughts: make(map[string]*Thought),
 		notes:    make(map[string][]Note),
+		states:   make(map[string]mockThoughtState),
 	}
 }
 
@@ -38,7 +48,7 @@ func (m *mockMemory) GetThought(_ context.Context, id string) (*Thought, error)
 
 	thought, ok := m.thoughts[id]
 	if !ok {
-		return nil, fmt.Errorf("thought not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
 	}
 	return thought, nil
 }
@@ -52,7 +62,7 @@ func (m *mockMemory) GetThoughtByTraceID(_ context.Context, traceID string) (*Th
 			return thought, nil
 		}
 	}
-	return nil, fmt.Errorf("thought not found for trace: %s", traceID)
+	return nil, fmt.Errorf("%w for trace: %s", ErrNotFound, traceID)
 }
 
 func (m *mockMemory) GetThoughtsByTaskID(_ context.Context, taskID string) ([]*Thought, error) {
@@ -68,6 +78,51 @@ func (m *mockMemory) GetThoughtsByTaskID(_ context.Context, taskID string) ([]*T
 	return thoughts, nil
 }
 
+func (m *mockMemory) GetThoughtsByIntent(_ context.Context, intent string, limit, offset int) ([]*Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var thoughts []*Thought
+	for _, thought := range m.thoughts {
+		if thought.Intent == intent {
+			thoughts = append(thoughts, thought)
+		}
+	}
+	return pageByCreation(thoughts, limit, offset), nil
+}
+
+// GetThoughtsByDateRange loads thoughts created in [from, to), ordered by creation time.
+func (m *mockMemory) GetThoughtsByDateRange(_ context.Context, from, to time.Time, limit, offset int) ([]*Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var thoughts []*Thought
+	for _, thought := range m.thoughts {
+		if !thought.CreatedAt.Before(from) && thought.CreatedAt.Before(to) {
+			thoughts = append(thoughts, thought)
+		}
+	}
+	return pageByCreation(thoughts, limit, offset), nil
+}
+
+// pageByCreation orders thoughts by creation time and applies limit and offset.
+func pageByCreation(thoughts []*Thought, limit, offset int) []*Thought {
+	sort.Slice(thoughts, func(i, j int) bool {
+		return thoughts[i].CreatedAt.Before(thoughts[j].CreatedAt)
+	})
+
+	if offset >= len(thoughts) {
+		return nil
+	}
+	if offset > 0 {
+		thoughts = thoughts[offset:]
+	}
+	if limit > 0 && limit < len(thoughts) {
+		thoughts = thoughts[:limit]
+	}
+	return thoughts
+}
+
 func (m *mockMemory) GetChildThoughts(_ context.Context, parentID string) ([]*Thought, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -81,6 +136,32 @@ func (m *mockMemory) GetChildThoughts(_ context.Context, parentID string) ([]*Th
 	return thoughts, nil
 }
 
+func (m *mockMemory) GetConversation(_ context.Context, leafThoughtID string) ([]*Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var chain []*Thought
+	seen := make(map[string]struct{})
+	id := leafThoughtID
+	for {
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("conversation cycle detected at thought %s", id)
+		}
+		seen[id] = struct{}{}
+
+		thought, ok := m.thoughts[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		chain = append([]*Thought{thought}, chain...)
+
+		if thought.ParentID == nil {
+			return chain, nil
+		}
+		id = *thought.ParentID
+	}
+}
+
 func (m *mockMemory) AddNote(_ context.Context, note *Note) (*Note, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -104,17 +185,79 @@ func (m *mockMemory) GetNotes(_ context.Context, thoughtID string) ([]Note, erro
 	return notes, nil
 }
 
+func (m *mockMemory) GetNotesBySourceSince(_ context.Context, thoughtID, source string, since time.Time, afterID string) ([]Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notes := []Note{}
+	for _, note := range m.notes[thoughtID] {
+		after := note.Created.After(since) || (afterID != "" && note.Created.Equal(since) && note.ID > afterID)
+		if note.Source == source && after {
+			notes = append(notes, note)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		if !notes[i].Created.Equal(notes[j].Created) {
+			return notes[i].Created.Before(notes[j].Created)
+		}
+		return notes[i].ID < notes[j].ID
+	})
+	return notes, nil
+}
+
 func (m *mockMemory) UpdateThought(_ context.Context, thought *Thought) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if _, ok := m.thoughts[thought.ID]; !ok {
-		return fmt.Errorf("thought not found: %s", thought.ID)
+		return fmt.Errorf("%w: %s", ErrNotFound, thought.ID)
 	}
 	m.thoughts[thought.ID] = thought
+
+	session, err := encodeSession(thought.Session)
+	if err != nil {
+		return err
+	}
+	attrs, err := encodeAttrs(thought)
+	if err != nil {
+		return err
+	}
+	m.states[thought.ID] = mockThoughtState{publishedCount: thought.PublishedCount(), session: session, attrs: attrs}
 	return nil
 }
 
+// Resume rebuilds the thought from stored notes and saved state, as SoyMemory does.
+func (m *mockMemory) Resume(_ context.Context, traceID string) (*Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, stored := range m.thoughts {
+		if stored.TraceID != traceID {
+			continue
+		}
+		thought := &Thought{
+			ID:        stored.ID,
+			Intent:    stored.Intent,
+			TraceID:   stored.TraceID,
+			ParentID:  stored.ParentID,
+			TaskID:    stored.TaskID,
+			memory:    m,
+			notes:     make([]Note, 0),
+			CreatedAt: stored.CreatedAt,
+			UpdatedAt: stored.UpdatedAt,
+		}
+		for _, note := range m.notes[stored.ID] {
+			thought.AddNoteWithoutPersist(note)
+		}
+		state := m.states[stored.ID]
+		if err := restoreState(thought, state.publishedCount, state.session, state.attrs); err != nil {
+			return nil, err
+		}
+		return thought, nil
+	}
+	return nil, fmt.Errorf("%w for trace: %s", ErrNotFound, traceID)
+}
+
 func (m *mockMemory) DeleteThought(_ context.Context, id string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -134,6 +277,51 @@ func (m *mockMemory) SearchNotesByTask(_ context.Context, _ Vector, limit int) (
 	return []*Thought{}, nil
 }
 
+func (m *mockMemory) BackfillEmbeddings(ctx context.Context, embedder Embedder, _ int) (int, error) {
+	if embedder == nil {
+		return 0, ErrNoEmbedder
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := 0
+	for thoughtID, notes := range m.notes {
+		for i := range notes {
+			if len(notes[i].Embedding) > 0 {
+				continue
+			}
+			embedding, err := embedder.Embed(ctx, notes[i].Content)
+			if err != nil {
+				return updated, err
+			}
+			notes[i].Embedding = embedding
+			updated++
+		}
+		m.notes[thoughtID] = notes
+	}
+	return updated, nil
+}
+
+func (m *mockMemory) UpdateNoteEmbedding(_ context.Context, noteID string, embedding Vector) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, notes := range m.notes {
+		for i := range notes {
+			if notes[i].ID == noteID {
+				notes[i].Embedding = embedding
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("note not found: %s", noteID)
+}
+
+func (m *mockMemory) Ping(_ context.Context) error {
+	return nil
+}
+
 // newTestThought creates a Thought with mock memory for testing.
 func newTestThought(intent string) *Thought {
 	mem := newMockMemory()
@@ -0,0 +1,458 @@
+package cogito
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// MultiDiscernResponse is the multi-label classification behind a MultiDiscern.
+type MultiDiscernResponse struct {
+	Categories []string `json:"categories"` // Every category that applies, in configured order
+	Confidence float64  `json:"confidence"` // Confidence in the selection (0.0-1.0)
+	Reasoning  []string `json:"reasoning"`  // Why these categories were chosen
+}
+
+// Validate implements zyn.Validator.
+func (r MultiDiscernResponse) Validate() error {
+	if r.Confidence < 0 || r.Confidence > 1 {
+		return fmt.Errorf("confidence must be between 0 and 1, got %f", r.Confidence)
+	}
+	return nil
+}
+
+// MultiDiscern is an LLM-powered multi-cast routing connector that implements pipz.Chainable[*Thought].
+// Unlike Discern, which takes a single route, it asks the LLM for every category that
+// applies and runs the route of each one.
+type MultiDiscern struct {
+	identity   pipz.Identity
+	key        string
+	question   string
+	categories []string
+	routes     map[string]pipz.Chainable[*Thought]
+	fallback   pipz.Chainable[*Thought]
+
+	// Configuration
+	concurrent           bool
+	useIntrospection     bool
+	sessionIntrospection bool
+	reasoningTemperature float32
+	sourceTag            string
+	locale               string
+	contextStrategy      ContextStrategy
+	summaryKey           string
+	provider             Provider
+	temperature          float32
+
+	mu sync.RWMutex
+}
+
+// NewMultiDiscern creates a new multi-cast routing connector.
+//
+// The LLM selects every applicable category; categories it returns that were not
+// configured are discarded. Matching routes run sequentially on the thought in
+// configured category order, or concurrently on clones with WithConcurrent. If no
+// selected category has a route, the fallback runs, or the thought passes through.
+// Categories must be non-empty and unique; Process returns ErrInvalidCategories otherwise.
+//
+// Output Notes:
+//   - {key}: JSON-serialized MultiDiscernResponse
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled and a route or fallback runs)
+//
+// Example:
+//
+//	notify := cogito.NewMultiDiscern(
+//	    "channels",
+//	    "Which channels should this alert go to?",
+//	    []string{"email", "sms", "slack"},
+//	).WithConcurrent()
+//	notify.AddRoute("email", emailPipeline)
+//	notify.AddRoute("sms", smsPipeline)
+//	notify.AddRoute("slack", slackPipeline)
+func NewMultiDiscern(key, question string, categories []string) *MultiDiscern {
+	return &MultiDiscern{
+		identity:             pipz.NewIdentity(key, "Multi-cast semantic routing connector"),
+		key:                  key,
+		question:             question,
+		categories:           categories,
+		routes:               make(map[string]pipz.Chainable[*Thought]),
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (d *MultiDiscern) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	if err := validateCategories(d.categories); err != nil {
+		return t, fmt.Errorf("multi-discern: %w", err)
+	}
+
+	// Resolve provider
+	provider, err := ResolveProviderForStep(ctx, "discern", d.provider)
+	if err != nil {
+		return t, fmt.Errorf("multi-discern: %w", err)
+	}
+
+	// Create zyn extraction synapse for multi-label selection
+	what := fmt.Sprintf("every category that applies to the question %q, choosing only from: %s",
+		d.question, strings.Join(d.categories, ", "))
+	selectSynapse, err := zyn.Extract[MultiDiscernResponse](localize(what, d.locale), provider)
+	if err != nil {
+		return t, fmt.Errorf("multi-discern: failed to create extraction synapse: %w", err)
+	}
+
+	// Get unpublished notes
+	unpublished := d.contextStrategy.apply(t.GetUnpublishedNotes())
+	noteContext := RenderNotesToContext(unpublished)
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("discern"),
+		FieldUnpublishedCount.Field(len(unpublished)),
+		FieldTemperature.Field(d.temperature),
+	)
+
+	// Determine reasoning temperature
+	reasoningTemp := d.temperature
+	if d.reasoningTemperature != 0 {
+		reasoningTemp = d.reasoningTemperature
+	}
+
+	emitContextRendered(ctx, t, d.key, "discern", noteContext, len(unpublished))
+
+	// PHASE 1: CLASSIFICATION - Select every applicable category
+	resp, err := selectSynapse.FireWithInput(ctx, t.Session, zyn.ExtractionInput{
+		Text:        noteContext,
+		Temperature: reasoningTemp,
+	})
+	if err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("multi-discern: classification failed: %w", err)
+	}
+	resp.Categories = d.selectedCategories(resp.Categories)
+
+	// Store classification response as JSON
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("multi-discern: failed to marshal response: %w", err)
+	}
+	metadata := recordCategories(contextKeysMetadata(unpublished), d.categories)
+	if setErr := t.SetNote(ctx, d.key, string(respJSON), noteSource("discern", d.sourceTag), metadata); setErr != nil {
+		d.emitFailed(ctx, t, start, setErr)
+		return t, fmt.Errorf("multi-discern: failed to persist note: %w", setErr)
+	}
+
+	// Resolve routes before introspection so pass-through skips the extra call
+	d.mu.RLock()
+	var categories []string
+	var processors []pipz.Chainable[*Thought]
+	for _, category := range resp.Categories {
+		if processor, ok := d.routes[category]; ok {
+			categories = append(categories, category)
+			processors = append(processors, processor)
+		}
+	}
+	fallback := d.fallback
+	d.mu.RUnlock()
+
+	// PHASE 2: INTROSPECTION - Semantic summary (optional, only if a route will run)
+	if d.useIntrospection && (len(processors) > 0 || fallback != nil) {
+		if introErr := d.runIntrospection(ctx, t, resp, unpublished, provider); introErr != nil {
+			d.emitFailed(ctx, t, start, introErr)
+			return t, introErr
+		}
+	}
+
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		d.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("multi-discern: %w", err)
+	}
+
+	// PHASE 3: ROUTING - Execute every matching processor
+	switch {
+	case len(processors) == 0 && fallback != nil:
+		t, err = fallback.Process(ctx, t)
+		if err != nil {
+			d.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("multi-discern: fallback failed: %w", err)
+		}
+	case d.concurrent:
+		if err := d.runConcurrent(ctx, t, categories, processors); err != nil {
+			d.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	default:
+		for i, processor := range processors {
+			t, err = processor.Process(ctx, t)
+			if err != nil {
+				d.emitFailed(ctx, t, start, err)
+				return t, fmt.Errorf("multi-discern: route %q failed: %w", categories[i], err)
+			}
+		}
+	}
+	// If no route and no fallback, pass through unchanged
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("discern"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldNoteCount.Field(t.NoteCount()),
+		FieldBranchCount.Field(len(processors)),
+	)
+
+	return t, nil
+}
+
+// selectedCategories keeps the configured categories the model selected, in configured order.
+func (d *MultiDiscern) selectedCategories(selected []string) []string {
+	chosen := make(map[string]bool, len(selected))
+	for _, category := range selected {
+		chosen[category] = true
+	}
+	categories := []string{}
+	for _, category := range d.categories {
+		if chosen[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// runConcurrent runs each route on its own clone and merges the notes they add
+// back into t, tagging each note's source with its category. Notes from routes
+// that succeed are merged even when another route fails.
+func (d *MultiDiscern) runConcurrent(ctx context.Context, t *Thought, categories []string, processors []pipz.Chainable[*Thought]) error {
+	originalNoteCount := t.NoteCount()
+	results := make([]*Thought, len(processors))
+	errs := make([]error, len(processors))
+
+	var wg sync.WaitGroup
+	for i, processor := range processors {
+		wg.Add(1)
+		go func(i int, p pipz.Chainable[*Thought]) {
+			defer wg.Done()
+			results[i], errs[i] = p.Process(ctx, t.Clone())
+		}(i, processor)
+	}
+	wg.Wait()
+
+	var routeErrors []error
+	for i, result := range results {
+		if errs[i] != nil {
+			routeErrors = append(routeErrors, fmt.Errorf("route %q: %w", categories[i], errs[i]))
+			continue
+		}
+		notes := result.AllNotes()
+		merged := make(map[string]string)
+		for _, note := range notes[min(originalNoteCount, len(notes)):] {
+			taggedSource := fmt.Sprintf("%s[%s]", note.Source, categories[i])
+			if err := t.mergeNote(ctx, note, taggedSource, merged); err != nil {
+				return fmt.Errorf("multi-discern: failed to merge note from route %q: %w", categories[i], err)
+			}
+		}
+	}
+	if len(routeErrors) > 0 {
+		return fmt.Errorf("multi-discern: %w", errors.Join(routeErrors...))
+	}
+	return nil
+}
+
+// runIntrospection executes the transform synapse for semantic summary.
+func (d *MultiDiscern) runIntrospection(ctx context.Context, t *Thought, resp MultiDiscernResponse, originalNotes []Note, provider Provider) error {
+	text := fmt.Sprintf("Routing Decision: %s (confidence: %.2f)\nReasoning:\n", strings.Join(resp.Categories, ", "), resp.Confidence)
+	for i, reason := range resp.Reasoning {
+		text += fmt.Sprintf("  %d. %s\n", i+1, reason)
+	}
+
+	return runIntrospection(ctx, t, provider, zyn.TransformInput{
+		Text:    text,
+		Context: RenderNotesToContext(originalNotes),
+		Style:   "Synthesize this routing decision into rich semantic context for the next reasoning step. Focus on why these routes were chosen, what they imply for downstream processing, and actionable insights. Be concise but comprehensive.",
+	}, introspectionConfig{
+		stepType:       "discern",
+		key:            d.key,
+		summaryKey:     d.summaryKey,
+		synapsePrompt:  "Synthesize routing decision into context for next reasoning step",
+		sourceTag:      d.sourceTag,
+		locale:         d.locale,
+		sessionContext: d.sessionIntrospection,
+	})
+}
+
+// emitFailed emits a step failed event.
+func (d *MultiDiscern) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(d.key),
+		FieldStepType.Field("discern"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (d *MultiDiscern) Identity() pipz.Identity {
+	return d.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (d *MultiDiscern) Schema() pipz.Node {
+	node := reasoningSchema(d.identity, "discern", nil, d.key, d.summaryKey, d.useIntrospection, false)
+	node.Flow = routeFlow(&d.mu, d.routes, d.fallback)
+	return node
+}
+
+// Close implements pipz.Chainable[*Thought].
+// Propagates Close to all registered routes and fallback.
+func (d *MultiDiscern) Close() error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var errs []error
+	for name, route := range d.routes {
+		if err := route.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("route %q: %w", name, err))
+		}
+	}
+	if d.fallback != nil {
+		if err := d.fallback.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("fallback: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Scan retrieves the typed multi-label response from a thought.
+func (d *MultiDiscern) Scan(t *Thought) (*MultiDiscernResponse, error) {
+	content, err := t.GetContent(d.key)
+	if err != nil {
+		return nil, fmt.Errorf("multi-discern scan: %w", err)
+	}
+	var resp MultiDiscernResponse
+	if err := json.Unmarshal([]byte(content), &resp); err != nil {
+		return nil, fmt.Errorf("multi-discern scan: failed to unmarshal response: %w", err)
+	}
+	return &resp, nil
+}
+
+// Builder methods
+
+// WithProvider sets the provider for classification.
+func (d *MultiDiscern) WithProvider(p Provider) *MultiDiscern {
+	d.provider = p
+	return d
+}
+
+// WithTemperature sets the default temperature for classification.
+func (d *MultiDiscern) WithTemperature(temp float32) *MultiDiscern {
+	d.temperature = temp
+	return d
+}
+
+// WithReasoningTemperature sets the temperature for the classification phase.
+func (d *MultiDiscern) WithReasoningTemperature(temp float32) *MultiDiscern {
+	d.reasoningTemperature = temp
+	return d
+}
+
+// WithIntrospection enables the introspection phase.
+func (d *MultiDiscern) WithIntrospection() *MultiDiscern {
+	d.useIntrospection = true
+	return d
+}
+
+// WithSessionIntrospection sends introspection only the selected categories; see DefaultSessionIntrospection.
+func (d *MultiDiscern) WithSessionIntrospection() *MultiDiscern {
+	d.sessionIntrospection = true
+	return d
+}
+
+// WithSummaryKey sets a custom key for the introspection summary note.
+func (d *MultiDiscern) WithSummaryKey(key string) *MultiDiscern {
+	d.summaryKey = key
+	return d
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "discern:<tag>".
+func (d *MultiDiscern) WithSourceTag(tag string) *MultiDiscern {
+	d.sourceTag = tag
+	return d
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (d *MultiDiscern) WithLocale(lang string) *MultiDiscern {
+	d.locale = lang
+	return d
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (d *MultiDiscern) WithContextStrategy(strategy ContextStrategy) *MultiDiscern {
+	d.contextStrategy = strategy
+	return d
+}
+
+// WithConcurrent runs matching routes in parallel on clones of the thought, then
+// merges the notes each route added with its category appended to the note source.
+// A failing route does not stop the others, but Process still returns its error.
+func (d *MultiDiscern) WithConcurrent() *MultiDiscern {
+	d.concurrent = true
+	return d
+}
+
+// Route management methods
+
+// AddRoute adds or updates a route for a category.
+func (d *MultiDiscern) AddRoute(category string, processor pipz.Chainable[*Thought]) *MultiDiscern {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.routes[category] = processor
+	return d
+}
+
+// RemoveRoute removes a route for a category.
+func (d *MultiDiscern) RemoveRoute(category string) *MultiDiscern {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.routes, category)
+	return d
+}
+
+// SetFallback sets the processor run when no selected category has a route.
+func (d *MultiDiscern) SetFallback(processor pipz.Chainable[*Thought]) *MultiDiscern {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = processor
+	return d
+}
+
+// HasRoute checks if a route exists for a category.
+func (d *MultiDiscern) HasRoute(category string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, exists := d.routes[category]
+	return exists
+}
+
+var _ pipz.Chainable[*Thought] = (*MultiDiscern)(nil)
@@ -0,0 +1,147 @@
+package cogito
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+// mockMultiDiscernProvider selects a fixed set of categories.
+type mockMultiDiscernProvider struct {
+	response string
+}
+
+func (m *mockMultiDiscernProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	if strings.Contains(messages[len(messages)-1].Content, "Transform:") {
+		return &zyn.ProviderResponse{
+			Content: `{"output": "Alert fans out to email and sms", "confidence": 0.9, "changes": [], "reasoning": []}`,
+		}, nil
+	}
+	return &zyn.ProviderResponse{Content: m.response}, nil
+}
+
+func (m *mockMultiDiscernProvider) Name() string {
+	return "mock-multi-discern"
+}
+
+func newMultiDiscernThought() *Thought {
+	thought := newTestThought("notify")
+	thought.SetContent(context.Background(), "alert", "Database is down in production", "input")
+	return thought
+}
+
+func TestMultiDiscernSequential(t *testing.T) {
+	provider := &mockMultiDiscernProvider{
+		response: `{"categories": ["sms", "pager", "email"], "confidence": 0.9, "reasoning": ["Production outage"]}`,
+	}
+	email := newMockRouteProcessor("email", "emailed")
+	sms := newMockRouteProcessor("sms", "texted")
+	slack := newMockRouteProcessor("slack", "slacked")
+
+	router := NewMultiDiscern("channels", "Which channels?", []string{"email", "sms", "slack"}).
+		WithProvider(provider).
+		AddRoute("email", email).
+		AddRoute("sms", sms).
+		AddRoute("slack", slack)
+
+	result, err := router.Process(context.Background(), newMultiDiscernThought())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !email.called || !sms.called {
+		t.Error("expected email and sms routes to run")
+	}
+	if slack.called {
+		t.Error("expected unselected slack route not to run")
+	}
+
+	resp, err := router.Scan(result)
+	if err != nil {
+		t.Fatalf("unexpected scan error: %v", err)
+	}
+	if strings.Join(resp.Categories, ",") != "email,sms" {
+		t.Errorf("expected configured categories in configured order, got %v", resp.Categories)
+	}
+}
+
+func TestMultiDiscernConcurrent(t *testing.T) {
+	provider := &mockMultiDiscernProvider{
+		response: `{"categories": ["email", "sms"], "confidence": 0.9, "reasoning": ["Production outage"]}`,
+	}
+
+	router := NewMultiDiscern("channels", "Which channels?", []string{"email", "sms", "slack"}).
+		WithProvider(provider).
+		WithConcurrent().
+		AddRoute("email", newMockRouteProcessor("email", "emailed")).
+		AddRoute("sms", newMockRouteProcessor("sms", "texted"))
+
+	result, err := router.Process(context.Background(), newMultiDiscernThought())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, source := range map[string]string{"emailed": "email[email]", "texted": "sms[sms]"} {
+		note, ok := result.GetNote(key)
+		if !ok {
+			t.Errorf("expected merged note %q", key)
+			continue
+		}
+		if note.Source != source {
+			t.Errorf("expected source %q for %q, got %q", source, key, note.Source)
+		}
+	}
+}
+
+func TestMultiDiscernConcurrentRouteError(t *testing.T) {
+	provider := &mockMultiDiscernProvider{
+		response: `{"categories": ["email", "sms"], "confidence": 0.9, "reasoning": []}`,
+	}
+	routeErr := errors.New("sms gateway down")
+
+	router := NewMultiDiscern("channels", "Which channels?", []string{"email", "sms"}).
+		WithProvider(provider).
+		WithConcurrent().
+		AddRoute("email", newMockRouteProcessor("email", "emailed")).
+		AddRoute("sms", newMockFailingProcessor("sms", routeErr))
+
+	result, err := router.Process(context.Background(), newMultiDiscernThought())
+	if !errors.Is(err, routeErr) {
+		t.Fatalf("expected route error, got %v", err)
+	}
+	if _, ok := result.GetNote("emailed"); !ok {
+		t.Error("expected notes from the successful route to be merged")
+	}
+}
+
+func TestMultiDiscernFallback(t *testing.T) {
+	provider := &mockMultiDiscernProvider{
+		response: `{"categories": [], "confidence": 0.7, "reasoning": ["Nothing applies"]}`,
+	}
+	fallback := newMockRouteProcessor("fallback", "logged")
+
+	router := NewMultiDiscern("channels", "Which channels?", []string{"email", "sms"}).
+		WithProvider(provider).
+		AddRoute("email", newMockRouteProcessor("email", "emailed")).
+		SetFallback(fallback)
+
+	if _, err := router.Process(context.Background(), newMultiDiscernThought()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fallback.called {
+		t.Error("expected fallback to run when no route matches")
+	}
+}
+
+func TestMultiDiscernInvalidCategories(t *testing.T) {
+	router := NewMultiDiscern("channels", "Which channels?", []string{"email", "email"}).
+		WithProvider(&mockMultiDiscernProvider{})
+
+	_, err := router.Process(context.Background(), newMultiDiscernThought())
+	if !errors.Is(err, ErrInvalidCategories) {
+		t.Errorf("expected ErrInvalidCategories, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NopMemory is a Memory that persists nothing.
+// Writes echo their input back with a locally generated ID, list and search
+// queries return empty results, and lookups by ID report not found.
+// Use it for pure in-process reasoning where thoughts are discarded after use:
+//
+//	thought, _ := cogito.New(ctx, cogito.NopMemory{}, "classify ticket")
+type NopMemory struct{}
+
+// CreateThought assigns an ID and returns the thought without persisting it.
+func (NopMemory) CreateThought(_ context.Context, thought *Thought) (*Thought, error) {
+	thought.ID = NewID()
+	return thought, nil
+}
+
+// GetThought always reports not found.
+func (NopMemory) GetThought(_ context.Context, id string) (*Thought, error) {
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// GetThoughtByTraceID always reports not found.
+func (NopMemory) GetThoughtByTraceID(_ context.Context, traceID string) (*Thought, error) {
+	return nil, fmt.Errorf("%w for trace: %s", ErrNotFound, traceID)
+}
+
+// GetThoughtsByTaskID returns no thoughts.
+func (NopMemory) GetThoughtsByTaskID(_ context.Context, _ string) ([]*Thought, error) {
+	return nil, nil
+}
+
+// GetThoughtsByIntent returns no thoughts.
+func (NopMemory) GetThoughtsByIntent(_ context.Context, _ string, _, _ int) ([]*Thought, error) {
+	return nil, nil
+}
+
+// GetThoughtsByDateRange returns no thoughts.
+func (NopMemory) GetThoughtsByDateRange(_ context.Context, _, _ time.Time, _, _ int) ([]*Thought, error) {
+	return nil, nil
+}
+
+// GetChildThoughts returns no thoughts.
+func (NopMemory) GetChildThoughts(_ context.Context, _ string) ([]*Thought, error) {
+	return nil, nil
+}
+
+// GetConversation always reports not found.
+func (NopMemory) GetConversation(_ context.Context, leafThoughtID string) ([]*Thought, error) {
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, leafThoughtID)
+}
+
+// AddNote assigns an ID and returns the note without persisting it.
+func (NopMemory) AddNote(_ context.Context, note *Note) (*Note, error) {
+	note.ID = NewID()
+	if note.Created.IsZero() {
+		note.Created = time.Now()
+	}
+	return note, nil
+}
+
+// GetNotes returns no notes.
+func (NopMemory) GetNotes(_ context.Context, _ string) ([]Note, error) {
+	return []Note{}, nil
+}
+
+// GetNotesBySourceSince returns no notes.
+func (NopMemory) GetNotesBySourceSince(_ context.Context, _, _ string, _ time.Time, _ string) ([]Note, error) {
+	return []Note{}, nil
+}
+
+// UpdateThought is a no-op.
+func (NopMemory) UpdateThought(_ context.Context, _ *Thought) error {
+	return nil
+}
+
+// Resume always reports not found.
+func (NopMemory) Resume(_ context.Context, traceID string) (*Thought, error) {
+	return nil, fmt.Errorf("%w for trace: %s", ErrNotFound, traceID)
+}
+
+// DeleteThought is a no-op.
+func (NopMemory) DeleteThought(_ context.Context, _ string) error {
+	return nil
+}
+
+// SearchNotes returns no results.
+func (NopMemory) SearchNotes(_ context.Context, _ Vector, _ int) ([]NoteWithThought, error) {
+	return nil, nil
+}
+
+// SearchNotesByTask returns no results.
+func (NopMemory) SearchNotesByTask(_ context.Context, _ Vector, _ int) ([]*Thought, error) {
+	return nil, nil
+}
+
+// BackfillEmbeddings has nothing to backfill.
+func (NopMemory) BackfillEmbeddings(_ context.Context, _ Embedder, _ int) (int, error) {
+	return 0, nil
+}
+
+// UpdateNoteEmbedding discards the embedding.
+func (NopMemory) UpdateNoteEmbedding(_ context.Context, _ string, _ Vector) error {
+	return nil
+}
+
+// Ping always succeeds; there is no backing store to reach.
+func (NopMemory) Ping(_ context.Context) error {
+	return nil
+}
+
+var _ Memory = NopMemory{}
@@ -0,0 +1,50 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNopMemory(t *testing.T) {
+	ctx := context.Background()
+
+	thought, err := New(ctx, NopMemory{}, "in-process reasoning")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if thought.ID == "" {
+		t.Error("expected thought to have a local ID")
+	}
+
+	if err := thought.SetContent(ctx, "key", "value", "test"); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+	content, err := thought.GetContent("key")
+	if err != nil || content != "value" {
+		t.Errorf("expected content 'value', got %q (err: %v)", content, err)
+	}
+
+	note, _ := thought.GetNote("key")
+	if note.ID == "" {
+		t.Error("expected note to have a local ID")
+	}
+
+	// Nothing is persisted
+	if _, err := (NopMemory{}).GetThought(ctx, thought.ID); err == nil {
+		t.Error("expected GetThought to report not found")
+	}
+	if _, err := (NopMemory{}).GetConversation(ctx, thought.ID); err == nil {
+		t.Error("expected GetConversation to report not found")
+	}
+	notes, err := NopMemory{}.GetNotes(ctx, thought.ID)
+	if err != nil || len(notes) != 0 {
+		t.Errorf("expected no notes, got %d (err: %v)", len(notes), err)
+	}
+	results, err := NopMemory{}.SearchNotes(ctx, Vector{1}, 10)
+	if err != nil || len(results) != 0 {
+		t.Errorf("expected no search results, got %d (err: %v)", len(results), err)
+	}
+	if err := (NopMemory{}).Ping(ctx); err != nil {
+		t.Errorf("expected Ping to succeed, got %v", err)
+	}
+}
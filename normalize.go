@@ -0,0 +1,242 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// defaultNormalizeRules remove the usual noise around an email or chat message:
+// quoted lines, quoted reply history, signatures and mobile footers.
+var defaultNormalizeRules = []string{
+	`(?m)^>.*\n?`,                                  // quoted lines
+	`(?ms)^On [^\n]+wrote:\s*$.*`,                  // "On <date>, <name> wrote:" and the history after it
+	`(?ms)^-{2,}\s*(Original|Forwarded) Message.*`, // Outlook-style quoted history
+	`(?ms)^-- ?$.*`,                                // signature delimiter and everything after it
+	`(?m)^Sent from my [^\n]*$`,                    // mobile footers
+}
+
+// blankRuns matches runs of blank lines left behind by removed text.
+var blankRuns = regexp.MustCompile(`\n\s*\n(\s*\n)+`)
+
+// Normalize is an input-cleaning primitive that implements pipz.Chainable[*Thought].
+// It strips quoted replies, signatures and boilerplate from a raw note so that later
+// steps reason over the message itself. It is meant to sit at the front of a chain.
+type Normalize struct {
+	identity    pipz.Identity
+	key         string
+	targetKey   string
+	rules       []*regexp.Regexp
+	compileErr  error
+	rulesOnly   bool
+	prompt      string
+	temperature float32
+	provider    Provider
+}
+
+// NewNormalize creates a new input normalization primitive.
+//
+// The primitive:
+//  1. Reads the note at targetKey
+//  2. Removes every match of its rules: by default quoted lines, "On ... wrote:"
+//     reply history, forwarded or original message blocks, "-- " signatures and
+//     "Sent from my ..." footers
+//  3. Removes any remaining boilerplate (sign-offs, disclaimers, legal footers)
+//     via LLM transform synapse, unless WithRulesOnly is set
+//  4. Withholds the raw note from later steps' context if it is still unpublished
+//
+// Output Notes:
+//   - {key}: The cleaned text, with metadata "normalized_from", "original_length"
+//     and "supersedes"
+//
+// Example:
+//
+//	chain := cogito.Sequence(pipz.NewIdentity("support", "Support triage"),
+//	    cogito.NewNormalize("email", "raw_email"),
+//	    cogito.NewCategorize("intent", "What is the customer asking for?", intents),
+//	)
+func NewNormalize(key, targetKey string) *Normalize {
+	n := &Normalize{
+		identity:    pipz.NewIdentity(key, "Input normalization primitive"),
+		key:         key,
+		targetKey:   targetKey,
+		prompt:      "Remove quoted replies, signatures, greetings and sign-offs, disclaimers and other boilerplate, keeping the message itself",
+		temperature: DefaultReasoningTemperature,
+	}
+	return n.WithRules(defaultNormalizeRules)
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (n *Normalize) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(n.key),
+		FieldStepType.Field("normalize"),
+		FieldNoteCount.Field(t.NoteCount()),
+		FieldTemperature.Field(n.temperature),
+	)
+
+	if n.compileErr != nil {
+		n.emitFailed(ctx, t, start, n.compileErr)
+		return t, fmt.Errorf("normalize: %w", n.compileErr)
+	}
+
+	original, err := t.GetContent(n.targetKey)
+	if err != nil {
+		n.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("normalize: %w", err)
+	}
+
+	cleaned := n.applyRules(original)
+
+	// Rules catch the predictable noise; the model handles what they cannot describe
+	if !n.rulesOnly && cleaned != "" {
+		cleaned, err = n.transform(ctx, t, cleaned)
+		if err != nil {
+			n.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+
+	// The cleaned note stands in for the raw one in later prompts
+	metadata := map[string]string{
+		"normalized_from": n.targetKey,
+		"original_length": strconv.Itoa(len(original)),
+	}
+	if ids := t.unpublishedIDs(n.targetKey); ids != "" {
+		metadata[SupersedesMetadataKey] = ids
+	}
+	if err := t.SetNote(ctx, n.key, cleaned, "normalize", metadata); err != nil {
+		n.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("normalize: failed to persist note: %w", err)
+	}
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(n.key),
+		FieldStepType.Field("normalize"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldContentSize.Field(len(cleaned)),
+	)
+
+	return t, nil
+}
+
+// applyRules removes every rule match and tidies the blank lines left behind.
+func (n *Normalize) applyRules(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	for _, re := range n.rules {
+		content = re.ReplaceAllLiteralString(content, "")
+	}
+	content = blankRuns.ReplaceAllLiteralString(content, "\n\n")
+	return strings.TrimSpace(content)
+}
+
+// transform removes the remaining boilerplate via LLM transform synapse.
+func (n *Normalize) transform(ctx context.Context, t *Thought, content string) (string, error) {
+	provider, err := ResolveProviderForStep(ctx, "normalize", n.provider)
+	if err != nil {
+		return "", fmt.Errorf("normalize: %w", err)
+	}
+
+	transformSynapse, err := zyn.Transform(n.prompt, provider)
+	if err != nil {
+		return "", fmt.Errorf("normalize: failed to create transform synapse: %w", err)
+	}
+
+	emitContextRendered(ctx, t, n.key, "normalize", content, 1)
+
+	cleaned, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
+		Text:        content,
+		Style:       "Return the remaining text verbatim. Do not summarize, rephrase, translate or correct it; only delete the parts that are not the message itself.",
+		Temperature: n.temperature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("normalize: transform synapse execution failed: %w", err)
+	}
+	return strings.TrimSpace(cleaned), nil
+}
+
+// emitFailed emits a step failed event.
+func (n *Normalize) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(n.key),
+		FieldStepType.Field("normalize"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (n *Normalize) Identity() pipz.Identity {
+	return n.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (n *Normalize) Schema() pipz.Node {
+	return stepSchema(n.identity, "normalize", false, []string{n.targetKey}, []string{n.key})
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (n *Normalize) Close() error {
+	return nil
+}
+
+// Builder methods
+
+// WithRules replaces the default rules with patterns (Go regular expressions);
+// every match is removed before the LLM pass. Pass nil to rely on the LLM alone.
+// An invalid pattern is reported when the step runs.
+func (n *Normalize) WithRules(patterns []string) *Normalize {
+	n.rules = nil
+	n.compileErr = nil
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			n.compileErr = fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			break
+		}
+		n.rules = append(n.rules, re)
+	}
+	return n
+}
+
+// WithRulesOnly skips the LLM pass, so normalization is deterministic and free.
+func (n *Normalize) WithRulesOnly() *Normalize {
+	n.rulesOnly = true
+	return n
+}
+
+// WithPrompt sets a custom prompt for the LLM pass, for example to also drop
+// ticket-system headers specific to a help desk.
+func (n *Normalize) WithPrompt(prompt string) *Normalize {
+	n.prompt = prompt
+	return n
+}
+
+// WithTemperature sets the temperature for the LLM pass.
+func (n *Normalize) WithTemperature(temp float32) *Normalize {
+	n.temperature = temp
+	return n
+}
+
+// WithProvider sets the provider for the LLM pass.
+func (n *Normalize) WithProvider(p Provider) *Normalize {
+	n.provider = p
+	return n
+}
+
+var _ pipz.Chainable[*Thought] = (*Normalize)(nil)
@@ -0,0 +1,161 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+const rawEmail = "Hi team,\r\n\r\nThe export button does nothing since this morning.\r\n\r\nThanks,\r\nDana\r\n-- \r\nDana Reyes | Acme Corp\r\nSent from my phone\r\n"
+
+const rawReply = `Yes, please go ahead with the refund.
+
+
+
+Sent from my iPhone
+
+On Tue, 3 Mar 2026 at 10:12, Support <support@example.com> wrote:
+> Would you like a refund?
+> Order 42`
+
+// normalizeProvider records the text it was asked to clean and drops the greeting.
+type normalizeProvider struct {
+	prompt string
+}
+
+func (p *normalizeProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	p.prompt = messages[len(messages)-1].Content
+	return &zyn.ProviderResponse{
+		Content: `{"output": "The export button does nothing since this morning.", "confidence": 0.9, "changes": ["removed greeting and sign-off"], "reasoning": []}`,
+	}, nil
+}
+
+func (p *normalizeProvider) Name() string {
+	return "normalize-provider"
+}
+
+func TestNormalizeRulesOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"signature", rawEmail, "Hi team,\n\nThe export button does nothing since this morning.\n\nThanks,\nDana"},
+		{"quoted reply", rawReply, "Yes, please go ahead with the refund."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thought := newTestThought("normalize")
+			thought.SetContent(context.Background(), "raw", tt.raw, "input")
+
+			result, err := NewNormalize("clean", "raw").WithRulesOnly().Process(context.Background(), thought)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			cleaned, _ := result.GetContent("clean")
+			if cleaned != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, cleaned)
+			}
+			if from, _ := result.GetMetadata("clean", "normalized_from"); from != "raw" {
+				t.Errorf("expected normalized_from 'raw', got %q", from)
+			}
+		})
+	}
+}
+
+func TestNormalizeTransform(t *testing.T) {
+	thought := newTestThought("normalize")
+	thought.SetContent(context.Background(), "raw", rawEmail, "input")
+
+	provider := &normalizeProvider{}
+	result, err := NewNormalize("clean", "raw").WithProvider(provider).Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cleaned, _ := result.GetContent("clean"); cleaned != "The export button does nothing since this morning." {
+		t.Errorf("unexpected cleaned text %q", cleaned)
+	}
+	// Rules run first, so the model never sees the signature
+	if strings.Contains(provider.prompt, "Acme Corp") {
+		t.Error("expected signature removed before the LLM pass")
+	}
+}
+
+func TestNormalizeInvalidRule(t *testing.T) {
+	thought := newTestThought("normalize")
+	thought.SetContent(context.Background(), "raw", rawEmail, "input")
+
+	if _, err := NewNormalize("clean", "raw").WithRules([]string{"("}).WithRulesOnly().Process(context.Background(), thought); err == nil {
+		t.Fatal("expected error for invalid rule")
+	}
+}
+
+func TestNormalizeWithholdsRawNote(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("normalize")
+	thought.SetContent(ctx, "raw", rawReply, "input")
+
+	provider := &capturingDecideProvider{}
+	chain := Sequence(pipz.NewIdentity("support", "Support triage"),
+		NewNormalize("clean", "raw").WithRulesOnly(),
+		NewDecide("refund", "Does the customer want a refund?").WithProvider(provider),
+	)
+	if _, err := chain.Process(ctx, thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	prompt := provider.prompts[0]
+	if strings.Contains(prompt, "Would you like a refund?") || strings.Contains(prompt, "Sent from my iPhone") {
+		t.Errorf("expected raw note withheld from the next prompt, got %q", prompt)
+	}
+	if strings.Count(prompt, "Yes, please go ahead with the refund.") != 1 {
+		t.Errorf("expected the cleaned text exactly once, got %q", prompt)
+	}
+}
+
+func TestNormalizeInConvergeBranch(t *testing.T) {
+	ctx := context.Background()
+	provider := &mockRecordingConvergeProvider{}
+
+	// The branch writes the raw reply and normalizes it before Converge merges it back
+	intake := Sequence(pipz.NewIdentity("intake", "Intake branch"),
+		newAnalysisProcessor("intake", rawReply),
+		NewNormalize("intake_clean", "intake_result").WithRulesOnly(),
+	)
+	converge := NewConverge("triage", "Combine the findings", intake, newAnalysisProcessor("risk", "Churn risk: low")).
+		WithProvider(provider)
+
+	thought := newTestThought("test converge normalize")
+	thought.SetContent(ctx, "customer", "premium tier", "input")
+	thought.SetContent(ctx, "order", "Order 42", "input")
+
+	result, err := converge.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(provider.prompts[0], "Sent from my iPhone") {
+		t.Errorf("expected raw branch note withheld from synthesis, got %q", provider.prompts[0])
+	}
+
+	// Reopen the whole log: only the merged raw copy is withheld
+	result.SetPublishedCount(0)
+	keys := make(map[string]bool)
+	for _, note := range result.GetUnpublishedNotes() {
+		if strings.Contains(note.Content, "Sent from my iPhone") {
+			t.Errorf("expected merged raw note withheld, got %s: %q", note.Key, note.Content)
+		}
+		keys[note.Key] = true
+	}
+	for _, key := range []string{"customer", "order", "intake_clean", "risk_result", "triage"} {
+		if !keys[key] {
+			t.Errorf("expected %s in context, got %v", key, keys)
+		}
+	}
+}
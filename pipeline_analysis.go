@@ -0,0 +1,333 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/zoobzio/pipz"
+)
+
+// Schema metadata keys through which primitives declare the notes they use.
+// AnalyzePipeline reads them from each node's pipz.Node.Metadata.
+const (
+	// SchemaInputKeys lists the note keys a step reads by name ([]string).
+	SchemaInputKeys = "input_keys"
+	// SchemaOutputKeys lists the note keys a step writes ([]string).
+	SchemaOutputKeys = "output_keys"
+	// SchemaReadsContext marks a step that consumes every unpublished note (bool).
+	SchemaReadsContext = "reads_context"
+	// SchemaSummaryKey names the note a step's introspection writes, when enabled (string).
+	SchemaSummaryKey = "summary_key"
+)
+
+// WarningKind classifies a pipeline wiring problem found by AnalyzePipeline.
+type WarningKind string
+
+const (
+	// WarningUnconsumedKey reports a note no later step reads.
+	WarningUnconsumedKey WarningKind = "unconsumed_key"
+	// WarningMissingKey reports a note a step reads that no earlier step writes.
+	WarningMissingKey WarningKind = "missing_key"
+)
+
+// Warning describes a pipeline wiring problem found by AnalyzePipeline.
+type Warning struct {
+	Kind WarningKind
+	Step string // Name of the step that writes or reads the key
+	Key  string
+}
+
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	switch w.Kind {
+	case WarningUnconsumedKey:
+		return fmt.Sprintf("step %q writes %q but no later step reads it", w.Step, w.Key)
+	case WarningMissingKey:
+		return fmt.Sprintf("step %q reads %q but no earlier step writes it", w.Step, w.Key)
+	default:
+		return fmt.Sprintf("step %q: %s %q", w.Step, w.Kind, w.Key)
+	}
+}
+
+// declaredStep is a schema node that declares the notes it uses.
+type declaredStep struct {
+	name         string
+	inputs       []string
+	outputs      []string
+	readsContext bool
+	summaryKey   string
+}
+
+// AnalyzePipeline statically checks how notes flow through a pipeline, using the
+// schema graph and the keys each primitive declares. It reports keys a step writes
+// that no later step reads, and keys a step reads that no earlier step writes.
+// provided lists keys set on the thought before the pipeline runs.
+//
+// A key counts as read by any later step that consumes unpublished context, such as
+// Decide or Categorize, so unconsumed-key warnings mostly point at outputs written
+// after the last reasoning step. Those are expected for a pipeline's final results,
+// which callers Scan. Custom processors built with Do, Transform and similar
+// helpers declare nothing and are invisible to the analysis. Branches of routers
+// and parallel connectors are treated as if they ran in order.
+//
+// Example:
+//
+//	for _, w := range cogito.AnalyzePipeline(pipeline, "ticket") {
+//	    log.Println(w)
+//	}
+func AnalyzePipeline(root pipz.Chainable[*Thought], provided ...string) []Warning {
+	var steps []declaredStep
+	collectDeclaredSteps(root.Schema(), &steps)
+
+	available := make(map[string]bool, len(provided))
+	for _, key := range provided {
+		available[key] = true
+	}
+
+	var warnings []Warning
+	for i, step := range steps {
+		for _, key := range step.inputs {
+			if !available[key] {
+				warnings = append(warnings, Warning{Kind: WarningMissingKey, Step: step.name, Key: key})
+			}
+		}
+		for _, key := range step.outputs {
+			available[key] = true
+			if !consumedAfter(steps[i+1:], key) {
+				warnings = append(warnings, Warning{Kind: WarningUnconsumedKey, Step: step.name, Key: key})
+			}
+		}
+	}
+	return warnings
+}
+
+// introspectionSkipKey is the context key holding the steps whose introspection
+// IntrospectIfConsumed has found unnecessary.
+type introspectionSkipKey struct{}
+
+// IntrospectIfConsumed wraps processor so that a step inside it runs introspection
+// only when its summary is used: when some later step in processor reads the
+// summary key, or consumes unpublished context the way Decide and Categorize do.
+// scanned lists summary keys the caller reads after the pipeline, which are
+// always produced. Steps must still enable introspection themselves; the wrapper
+// only removes calls whose summary nothing would read.
+//
+// The check uses the same declared keys as AnalyzePipeline, so in practice it
+// drops the summaries of steps after the last reasoning step, which are otherwise
+// paid for and never read. Steps inside a Converge branch keep their summaries,
+// since the synthesis reads what its branches wrote.
+//
+// Example:
+//
+//	pipeline := cogito.IntrospectIfConsumed(pipz.NewIdentity("triage", "Triage pipeline"),
+//	    cogito.Sequence(pipz.NewIdentity("steps", "Triage steps"), classify, decide, extract),
+//	)
+func IntrospectIfConsumed(identity pipz.Identity, processor pipz.Chainable[*Thought], scanned ...string) pipz.Chainable[*Thought] {
+	return &introspectIfConsumed{identity: identity, processor: processor, scanned: scanned}
+}
+
+// introspectIfConsumed is the processor returned by IntrospectIfConsumed.
+type introspectIfConsumed struct {
+	identity  pipz.Identity
+	processor pipz.Chainable[*Thought]
+	scanned   []string
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (p *introspectIfConsumed) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	var steps []declaredStep
+	collectDeclaredSteps(p.processor.Schema(), &steps)
+
+	scanned := make(map[string]bool, len(p.scanned))
+	for _, key := range p.scanned {
+		scanned[key] = true
+	}
+
+	skip := make(map[string]bool)
+	for i, step := range steps {
+		if step.summaryKey != "" && !scanned[step.summaryKey] && !consumedAfter(steps[i+1:], step.summaryKey) {
+			skip[step.name] = true
+		}
+	}
+
+	return p.processor.Process(context.WithValue(ctx, introspectionSkipKey{}, skip), t)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (p *introspectIfConsumed) Identity() pipz.Identity {
+	return p.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (p *introspectIfConsumed) Schema() pipz.Node {
+	return pipz.Node{Identity: p.identity, Type: "introspect_if_consumed", Flow: pipz.PipelineFlow{Root: p.processor.Schema()}}
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (p *introspectIfConsumed) Close() error {
+	return p.processor.Close()
+}
+
+// introspectionSkipped reports whether IntrospectIfConsumed found that nothing
+// reads the summary of the step named key.
+func introspectionSkipped(ctx context.Context, key string) bool {
+	skip, _ := ctx.Value(introspectionSkipKey{}).(map[string]bool)
+	return skip[key]
+}
+
+// consumedAfter reports whether any of steps reads key.
+func consumedAfter(steps []declaredStep, key string) bool {
+	for _, step := range steps {
+		if step.readsContext {
+			return true
+		}
+		for _, input := range step.inputs {
+			if input == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectDeclaredSteps walks the schema tree depth-first, appending every node that
+// declares note keys. A node is recorded before its children, since routers and
+// gates decide before their routes run, except for a node with parallel tasks:
+// Converge runs its branches first and then synthesizes what they wrote.
+func collectDeclaredSteps(node pipz.Node, steps *[]declaredStep) {
+	_, childrenFirst := node.Flow.(pipz.ConcurrentFlow)
+	if childrenFirst {
+		for _, child := range schemaChildren(node) {
+			collectDeclaredSteps(child, steps)
+		}
+	}
+
+	inputs, hasInputs := node.Metadata[SchemaInputKeys].([]string)
+	outputs, hasOutputs := node.Metadata[SchemaOutputKeys].([]string)
+	readsContext, _ := node.Metadata[SchemaReadsContext].(bool)
+	summaryKey, _ := node.Metadata[SchemaSummaryKey].(string)
+	if hasInputs || hasOutputs || readsContext {
+		*steps = append(*steps, declaredStep{
+			name:         node.Identity.Name(),
+			inputs:       inputs,
+			outputs:      outputs,
+			readsContext: readsContext,
+			summaryKey:   summaryKey,
+		})
+	}
+
+	if !childrenFirst {
+		for _, child := range schemaChildren(node) {
+			collectDeclaredSteps(child, steps)
+		}
+	}
+}
+
+// schemaChildren returns the child nodes of a connector in execution order.
+// Switch routes are returned sorted by route key for a stable result.
+func schemaChildren(node pipz.Node) []pipz.Node {
+	switch flow := node.Flow.(type) {
+	case pipz.SequenceFlow:
+		return flow.Steps
+	case pipz.FallbackFlow:
+		return append([]pipz.Node{flow.Primary}, flow.Backups...)
+	case pipz.RaceFlow:
+		return flow.Competitors
+	case pipz.ContestFlow:
+		return flow.Competitors
+	case pipz.ConcurrentFlow:
+		return flow.Tasks
+	case pipz.SwitchFlow:
+		keys := make([]string, 0, len(flow.Routes))
+		for key := range flow.Routes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		children := make([]pipz.Node, len(keys))
+		for i, key := range keys {
+			children[i] = flow.Routes[key]
+		}
+		return children
+	case pipz.FilterFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.HandleFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.ScaffoldFlow:
+		return flow.Processors
+	case pipz.BackoffFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.RetryFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.TimeoutFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.RateLimiterFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.CircuitBreakerFlow:
+		return []pipz.Node{flow.Processor}
+	case pipz.WorkerpoolFlow:
+		return flow.Processors
+	case pipz.PipelineFlow:
+		return []pipz.Node{flow.Root}
+	default:
+		return nil
+	}
+}
+
+// stepSchema builds a primitive's schema node, declaring the note keys it reads by
+// name and writes so AnalyzePipeline can follow them.
+func stepSchema(identity pipz.Identity, stepType string, readsContext bool, inputs, outputs []string) pipz.Node {
+	metadata := map[string]any{SchemaOutputKeys: outputs}
+	if len(inputs) > 0 {
+		metadata[SchemaInputKeys] = inputs
+	}
+	if readsContext {
+		metadata[SchemaReadsContext] = true
+	}
+	return pipz.Node{Identity: identity, Type: stepType, Metadata: metadata}
+}
+
+// routeFlow describes a router's routes, plus its fallback under the "fallback" key.
+func routeFlow(mu *sync.RWMutex, routes map[string]pipz.Chainable[*Thought], fallback pipz.Chainable[*Thought]) pipz.SwitchFlow {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	flow := pipz.SwitchFlow{Routes: make(map[string]pipz.Node, len(routes)+1)}
+	for category, route := range routes {
+		flow.Routes[category] = route.Schema()
+	}
+	if fallback != nil {
+		flow.Routes["fallback"] = fallback.Schema()
+	}
+	return flow
+}
+
+// reasoningSchema builds the schema node of a reasoning primitive, which reads
+// unpublished context and declares its introspection summary key when enabled.
+func reasoningSchema(identity pipz.Identity, stepType string, inputs []string, key, summaryKey string, introspection, captureRaw bool) pipz.Node {
+	node := stepSchema(identity, stepType, true, inputs, reasoningOutputs(key, summaryKey, introspection, captureRaw))
+	if introspection {
+		if summaryKey == "" {
+			summaryKey = key + "_summary"
+		}
+		node.Metadata[SchemaSummaryKey] = summaryKey
+	}
+	return node
+}
+
+// reasoningOutputs returns the keys a reasoning primitive writes: the result note,
+// plus the raw and introspection summary notes when enabled.
+func reasoningOutputs(key, summaryKey string, introspection, captureRaw bool) []string {
+	outputs := []string{key}
+	if captureRaw {
+		outputs = append(outputs, key+"_raw")
+	}
+	if introspection {
+		if summaryKey == "" {
+			summaryKey = key + "_summary"
+		}
+		outputs = append(outputs, summaryKey)
+	}
+	return outputs
+}
@@ -0,0 +1,138 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zoobzio/pipz"
+)
+
+func TestAnalyzePipeline(t *testing.T) {
+	t.Run("missing and unconsumed keys", func(t *testing.T) {
+		pipeline := Sequence(pipz.NewIdentity("triage", "Triage"),
+			NewDeduplicate("issues", "raw_issues"),
+			NewPrioritizeFrom("ranked", "by customer impact", "issue_list"),
+			NewAmplify("final", "draft", "Tighten", "Concise", 2),
+		)
+
+		warnings := AnalyzePipeline(pipeline, "raw_issues")
+
+		want := map[Warning]bool{
+			{Kind: WarningMissingKey, Step: "ranked", Key: "issue_list"}: true,
+			{Kind: WarningMissingKey, Step: "final", Key: "draft"}:       true,
+			{Kind: WarningUnconsumedKey, Step: "final", Key: "final"}:    true,
+		}
+		if len(warnings) != len(want) {
+			t.Fatalf("expected %d warnings, got %v", len(want), warnings)
+		}
+		for _, w := range warnings {
+			if !want[w] {
+				t.Errorf("unexpected warning: %s", w)
+			}
+		}
+	})
+
+	t.Run("context readers consume earlier outputs", func(t *testing.T) {
+		pipeline := Sequence(pipz.NewIdentity("review", "Review"),
+			NewAnalyze[TicketData]("facts", "key facts").WithIntrospection(),
+			NewDecide("urgent", "Is this urgent?"),
+		)
+
+		for _, w := range AnalyzePipeline(pipeline) {
+			if w.Step == "facts" {
+				t.Errorf("expected facts outputs to be consumed by Decide, got %s", w)
+			}
+		}
+	})
+
+	t.Run("sees inside routers", func(t *testing.T) {
+		router := NewDiscern("route", "Which team?", []string{"billing", "support"})
+		router.AddRoute("billing", NewAmplify("reply", "billing_draft", "Polish", "Polished", 1))
+
+		warnings := AnalyzePipeline(router)
+
+		found := false
+		for _, w := range warnings {
+			if w == (Warning{Kind: WarningMissingKey, Step: "reply", Key: "billing_draft"}) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected missing-key warning from a route, got %v", warnings)
+		}
+	})
+
+	t.Run("converge synthesis consumes its branches", func(t *testing.T) {
+		pipeline := Sequence(pipz.NewIdentity("review", "Review"),
+			NewConverge("verdict", "Combine the assessments",
+				NewDecide("a", "Is this urgent?").WithIntrospection(),
+				NewDecide("b", "Is this an outage?").WithIntrospection(),
+			),
+		)
+
+		for _, w := range AnalyzePipeline(pipeline) {
+			if w.Step == "a" || w.Step == "b" {
+				t.Errorf("expected branch outputs to be consumed by the synthesis, got %s", w)
+			}
+		}
+	})
+
+	t.Run("custom processors are invisible", func(t *testing.T) {
+		pipeline := Sequence(pipz.NewIdentity("custom", "Custom"),
+			Transform(pipz.NewIdentity("noop", "No-op"), func(_ context.Context, th *Thought) *Thought { return th }),
+		)
+		if warnings := AnalyzePipeline(pipeline); len(warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", warnings)
+		}
+	})
+}
+
+func TestIntrospectIfConsumed(t *testing.T) {
+	run := func(scanned ...string) *Thought {
+		pipeline := IntrospectIfConsumed(pipz.NewIdentity("triage", "Triage"),
+			Sequence(pipz.NewIdentity("steps", "Triage steps"),
+				NewDecide("is_urgent", "Is this urgent?").WithProvider(&mockDecideProvider{}).WithIntrospection(),
+				NewDecide("is_outage", "Is this an outage?").WithProvider(&mockDecideProvider{}).WithIntrospection(),
+			),
+			scanned...,
+		)
+		result, err := pipeline.Process(context.Background(), newTestThought("introspect if consumed"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := run()
+	if _, err := result.GetContent("is_urgent_summary"); err != nil {
+		t.Error("expected summary read by a later reasoning step to be produced")
+	}
+	if _, err := result.GetContent("is_outage_summary"); err == nil {
+		t.Error("expected unread summary of the last step to be skipped")
+	}
+	if _, err := result.GetContent("is_outage"); err != nil {
+		t.Error("expected the last step's decision to be stored")
+	}
+
+	if _, err := run("is_outage_summary").GetContent("is_outage_summary"); err != nil {
+		t.Error("expected scanned summary to be produced")
+	}
+}
+
+func TestIntrospectIfConsumedConverge(t *testing.T) {
+	pipeline := IntrospectIfConsumed(pipz.NewIdentity("review", "Review"),
+		NewConverge("verdict", "Combine the assessments",
+			NewDecide("a", "Is this urgent?").WithProvider(&mockDecideProvider{}).WithIntrospection(),
+		).WithProvider(&mockConvergeProvider{}),
+	)
+
+	result, err := pipeline.Process(context.Background(), newTestThought("introspect converge"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The synthesis reads the branch's notes, so its summary is still produced
+	if _, err := result.GetContent("a_summary"); err != nil {
+		t.Error("expected summary of a Converge branch to be produced")
+	}
+}
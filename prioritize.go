@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/zoobzio/capitan"
@@ -17,12 +19,23 @@ type Prioritize struct {
 	identity                 pipz.Identity
 	key                      string
 	criteria                 string
-	items                    []string // Explicit items to rank (mode 1)
-	itemsKey                 string   // Note key to read items from (mode 2)
+	items                    []string           // Explicit items to rank (mode 1)
+	itemsKey                 string             // Note key to read items from (mode 2)
+	itemsKeys                []string           // Note keys whose items are concatenated (mode 2, several keys)
+	dedupeItems              bool               // Drop repeated items when concatenating itemsKeys
+	objects                  bool               // Items note holds JSON objects (mode 3)
+	displayField             string             // Object field used as item text (mode 3)
+	dimensions               map[string]float64 // Weighted criteria dimensions (multi-criteria mode)
 	summaryKey               string
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
 	provider                 Provider
 	temperature              float32
 }
@@ -33,8 +46,12 @@ type Prioritize struct {
 //  1. Ranking synapse: Prioritizes items by criteria
 //  2. Transform synapse: Synthesizes a semantic summary for context accumulation
 //
+// A single item is ranked directly with confidence 1.0 and no ranking synapse call
+// (and no {key}_raw note); this applies to every Prioritize constructor.
+//
 // Output Notes:
 //   - {key}: JSON-serialized zyn.RankingResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
 // Example:
@@ -50,12 +67,13 @@ type Prioritize struct {
 //	fmt.Println(resp.Ranked, resp.Confidence, resp.Reasoning)
 func NewPrioritize(key, criteria string, items []string) *Prioritize {
 	return &Prioritize{
-		identity:         pipz.NewIdentity(key, "Prioritization primitive"),
-		key:              key,
-		criteria:         criteria,
-		items:            items,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Prioritization primitive"),
+		key:                  key,
+		criteria:             criteria,
+		items:                items,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
@@ -68,6 +86,7 @@ func NewPrioritize(key, criteria string, items []string) *Prioritize {
 //
 // Output Notes:
 //   - {key}: JSON-serialized zyn.RankingResponse
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
 // Example:
@@ -82,39 +101,135 @@ func NewPrioritize(key, criteria string, items []string) *Prioritize {
 //	cogito.NewPrioritizeFrom("ticket_priority", "urgency and impact", "ticket_list"),
 func NewPrioritizeFrom(key, criteria, itemsKey string) *Prioritize {
 	return &Prioritize{
-		identity:         pipz.NewIdentity(key, "Prioritization primitive (from note)"),
-		key:              key,
-		criteria:         criteria,
-		itemsKey:         itemsKey,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Prioritization primitive (from note)"),
+		key:                  key,
+		criteria:             criteria,
+		itemsKey:             itemsKey,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// NewPrioritizeFromKeys creates a prioritization primitive that ranks the union of items
+// read from several notes. Each note must hold a JSON array of strings; the arrays are
+// concatenated in key order before ranking. Use WithDedupe to drop repeated items.
+//
+// Example:
+//
+//	step := cogito.NewPrioritizeFromKeys("issue_priority", "customer impact",
+//	    "support_issues", "monitoring_issues").WithDedupe()
+func NewPrioritizeFromKeys(key, criteria string, itemsKeys ...string) *Prioritize {
+	return &Prioritize{
+		identity:             pipz.NewIdentity(key, "Prioritization primitive (from notes)"),
+		key:                  key,
+		criteria:             criteria,
+		itemsKeys:            itemsKeys,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// NewPrioritizeFromObjects creates a new prioritization primitive that ranks JSON objects read from a note.
+// The items note must hold a JSON array of objects. Each object is ranked by the value of
+// displayField, or by its compact JSON rendering when displayField is empty or missing.
+// The full objects are preserved in ranked order so downstream steps keep their IDs.
+//
+// Output Notes:
+//   - {key}: JSON-serialized zyn.RankingResponse (ranked display texts)
+//   - {key}_objects: JSON array of the original objects in ranked order
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled)
+//
+// Example:
+//
+//	// ticket_list: [{"id": "T-1", "title": "Login broken"}, {"id": "T-2", "title": "Outage"}]
+//	step := cogito.NewPrioritizeFromObjects("ticket_priority", "urgency and impact", "ticket_list", "title")
+//	result, _ := step.Process(ctx, thought)
+//	tickets, _ := step.ScanObjects(result)
+//	fmt.Println(string(tickets[0])) // {"id": "T-2", "title": "Outage"}
+func NewPrioritizeFromObjects(key, criteria, itemsKey, displayField string) *Prioritize {
+	return &Prioritize{
+		identity:             pipz.NewIdentity(key, "Prioritization primitive (from objects)"),
+		key:                  key,
+		criteria:             criteria,
+		itemsKey:             itemsKey,
+		objects:              true,
+		displayField:         displayField,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// NewPrioritizeMulti creates a prioritization primitive that ranks items along several
+// weighted criteria dimensions.
+//
+// Each dimension is ranked by its own ranking synapse call. An item's position in a
+// dimension's ranking is converted to a score in [0, 1] (first = 1, last = 0, omitted = 0),
+// and the final order sorts items by the weighted sum of their dimension scores.
+// Weights must be positive and are normalized to sum to 1.
+//
+// Output Notes:
+//   - {key}: JSON-serialized zyn.RankingResponse (combined order, reasoning prefixed by dimension)
+//     with metadata "weights" (normalized weights) and "scores_{dimension}" (item scores), both JSON
+//   - {key}_raw: JSON object of raw provider responses by dimension (if raw capture enabled)
+//   - {key}_summary: Semantic summary for next steps (if introspection enabled)
+//
+// Example:
+//
+//	step := cogito.NewPrioritizeMulti("ticket_priority", map[string]float64{
+//	    "urgency": 0.5,
+//	    "impact":  0.3,
+//	    "effort":  0.2,
+//	}, tickets)
+//	result, _ := step.Process(ctx, thought)
+//	urgency, _ := result.GetMetadata("ticket_priority", "scores_urgency")
+func NewPrioritizeMulti(key string, criteria map[string]float64, items []string) *Prioritize {
+	names := make([]string, 0, len(criteria))
+	for name := range criteria {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &Prioritize{
+		identity:             pipz.NewIdentity(key, "Multi-criteria prioritization primitive"),
+		key:                  key,
+		criteria:             strings.Join(names, ", "),
+		items:                items,
+		dimensions:           criteria,
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
 	}
 }
 
 // Process implements pipz.Chainable[*Thought].
 func (r *Prioritize) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, r.provider)
+	provider, err := ResolveProviderForStep(ctx, "prioritize", r.provider)
 	if err != nil {
 		return t, fmt.Errorf("prioritize: %w", err)
 	}
 
-	// Resolve items (two-mode resolution)
-	items, err := r.resolveItems(t)
+	// Resolve items (explicit, note strings, or note objects)
+	items, objects, err := r.resolveItems(t)
 	if err != nil {
 		return t, err
 	}
 
-	// Create zyn ranking synapse
-	rankingSynapse, err := zyn.NewRanking(r.criteria, provider)
+	// Normalize weighted dimensions before any LLM calls
+	weights, err := r.normalizedWeights()
 	if err != nil {
-		return t, fmt.Errorf("prioritize: failed to create ranking synapse: %w", err)
+		return t, err
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := r.contextStrategy.apply(t.GetUnpublishedNotes())
 
 	// Emit step started
 	capitan.Emit(ctx, StepStarted,
@@ -131,15 +246,28 @@ func (r *Prioritize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		reasoningTemp = r.reasoningTemperature
 	}
 
-	// PHASE 1: REASONING - Ranking
-	rankResponse, err := rankingSynapse.FireWithInput(ctx, t.Session, zyn.RankingInput{
-		Items:       items,
-		Context:     r.criteria,
-		Temperature: reasoningTemp,
-	})
+	// PHASE 1: REASONING - Ranking (a single item ranks itself without a provider call)
+	metadata := contextKeysMetadata(unpublished)
+	singleItem := len(items) == 1
+	var rankResponse zyn.RankingResponse
+	var raws *dimensionRaws
+	if singleItem {
+		rankResponse = zyn.RankingResponse{
+			Ranked:     items,
+			Confidence: 1.0,
+			Reasoning:  []string{"Only one item to rank"},
+		}
+	} else if weights != nil {
+		if r.captureRaw {
+			raws = &dimensionRaws{responses: make(map[string]string, len(weights)), metadata: make(map[string]string)}
+		}
+		rankResponse, err = r.rankDimensions(ctx, t, provider, items, weights, reasoningTemp, metadata, raws)
+	} else {
+		rankResponse, err = r.rank(ctx, t, provider, r.criteria, items, reasoningTemp)
+	}
 	if err != nil {
 		r.emitFailed(ctx, t, start, err)
-		return t, fmt.Errorf("prioritize: ranking synapse execution failed: %w", err)
+		return t, err
 	}
 
 	// Store full response as JSON
@@ -148,10 +276,22 @@ func (r *Prioritize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		r.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("prioritize: failed to marshal response: %w", err)
 	}
-	if err := t.SetContent(ctx, r.key, string(respJSON), "prioritize"); err != nil {
+	if err := t.SetNote(ctx, r.key, string(respJSON), noteSource("prioritize", r.sourceTag), metadata); err != nil {
 		r.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("prioritize: failed to persist note: %w", err)
 	}
+	if r.captureRaw && !singleItem {
+		if err := r.storeRawResponse(ctx, t, raws); err != nil {
+			r.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+	if objects != nil {
+		if err := r.storeRankedObjects(ctx, t, items, objects, rankResponse.Ranked); err != nil {
+			r.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
 
 	// PHASE 2: INTROSPECTION - Semantic summary (optional)
 	if r.useIntrospection {
@@ -161,8 +301,11 @@ func (r *Prioritize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		r.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("prioritize: %w", err)
+	}
 
 	// Emit step completed
 	duration := time.Since(start)
@@ -171,36 +314,279 @@ func (r *Prioritize) Process(ctx context.Context, t *Thought) (*Thought, error)
 		FieldStepName.Field(r.key),
 		FieldStepType.Field("prioritize"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
 }
 
+// rank fires a single ranking synapse for the given criteria.
+func (r *Prioritize) rank(ctx context.Context, t *Thought, provider Provider, criteria string, items []string, temperature float32) (zyn.RankingResponse, error) {
+	rankingSynapse, err := zyn.NewRanking(localize(criteria, r.locale), provider)
+	if err != nil {
+		return zyn.RankingResponse{}, fmt.Errorf("prioritize: failed to create ranking synapse: %w", err)
+	}
+
+	// Ranking sends the items and criteria rather than note context
+	emitContextRendered(ctx, t, r.key, "prioritize", criteria+strings.Join(items, "\n"), 0)
+
+	resp, err := rankingSynapse.FireWithInput(ctx, t.Session, zyn.RankingInput{
+		Items:       items,
+		Context:     criteria,
+		Temperature: temperature,
+	})
+	if err != nil {
+		return zyn.RankingResponse{}, fmt.Errorf("prioritize: ranking synapse execution failed: %w", err)
+	}
+	return resp, nil
+}
+
+// normalizedWeights validates the weighted dimensions and scales them to sum to 1.
+// Returns nil weights when the step is not in multi-criteria mode.
+func (r *Prioritize) normalizedWeights() (map[string]float64, error) {
+	if r.dimensions == nil {
+		return nil, nil
+	}
+	if len(r.dimensions) == 0 {
+		return nil, fmt.Errorf("prioritize: at least one criteria dimension is required")
+	}
+
+	total := 0.0
+	for name, weight := range r.dimensions {
+		if name == "" {
+			return nil, fmt.Errorf("prioritize: criteria dimension name is empty")
+		}
+		if weight <= 0 {
+			return nil, fmt.Errorf("prioritize: criteria dimension %q has non-positive weight %v", name, weight)
+		}
+		total += weight
+	}
+
+	weights := make(map[string]float64, len(r.dimensions))
+	for name, weight := range r.dimensions {
+		weights[name] = weight / total
+	}
+	return weights, nil
+}
+
+// rankDimensions ranks items once per dimension and combines the rankings by weighted score.
+// Per-dimension scores and the normalized weights are recorded in metadata.
+// When raws is non-nil, each dimension's raw response, prompt and usage are recorded in it.
+func (r *Prioritize) rankDimensions(ctx context.Context, t *Thought, provider Provider, items []string, weights map[string]float64, temperature float32, metadata map[string]string, raws *dimensionRaws) (zyn.RankingResponse, error) {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	combined := make(map[string]float64, len(items))
+	var combinedResp zyn.RankingResponse
+	for _, name := range names {
+		resp, err := r.rank(ctx, t, provider, name, items, temperature)
+		if err != nil {
+			return zyn.RankingResponse{}, fmt.Errorf("%w (dimension %q)", err, name)
+		}
+		if raws != nil {
+			raws.record(name, t.Session)
+		}
+
+		scores := rankScores(items, resp.Ranked)
+		for item, score := range scores {
+			combined[item] += weights[name] * score
+		}
+		combinedResp.Confidence += weights[name] * resp.Confidence
+		for _, reason := range resp.Reasoning {
+			combinedResp.Reasoning = append(combinedResp.Reasoning, fmt.Sprintf("[%s] %s", name, reason))
+		}
+
+		scoresJSON, err := json.Marshal(scores)
+		if err != nil {
+			return zyn.RankingResponse{}, fmt.Errorf("prioritize: failed to marshal %q scores: %w", name, err)
+		}
+		metadata["scores_"+name] = string(scoresJSON)
+	}
+
+	weightsJSON, err := json.Marshal(weights)
+	if err != nil {
+		return zyn.RankingResponse{}, fmt.Errorf("prioritize: failed to marshal weights: %w", err)
+	}
+	metadata["weights"] = string(weightsJSON)
+
+	combinedResp.Ranked = append([]string(nil), items...)
+	sort.SliceStable(combinedResp.Ranked, func(i, j int) bool {
+		return combined[combinedResp.Ranked[i]] > combined[combinedResp.Ranked[j]]
+	})
+	return combinedResp, nil
+}
+
+// rankScores converts a ranked order into per-item scores in [0, 1].
+// The first item scores 1 and the last 0; items missing from the ranking score 0.
+func rankScores(items, ranked []string) map[string]float64 {
+	known := make(map[string]bool, len(items))
+	scores := make(map[string]float64, len(items))
+	for _, item := range items {
+		known[item] = true
+		scores[item] = 0
+	}
+
+	// Only positions of recognized items count, so invented entries do not shift scores
+	var order []string
+	placed := make(map[string]bool, len(items))
+	for _, item := range ranked {
+		if known[item] && !placed[item] {
+			placed[item] = true
+			order = append(order, item)
+		}
+	}
+
+	n := len(items)
+	for pos, item := range order {
+		if n == 1 {
+			scores[item] = 1
+			continue
+		}
+		scores[item] = 1 - float64(pos)/float64(n-1)
+	}
+	return scores
+}
+
 // resolveItems resolves items from explicit list or note key.
-func (r *Prioritize) resolveItems(t *Thought) ([]string, error) {
+// Objects are returned alongside their display texts when ranking objects.
+func (r *Prioritize) resolveItems(t *Thought) ([]string, []json.RawMessage, error) {
 	// Mode 1: Explicit items provided at construction time
 	if len(r.items) > 0 {
-		return r.items, nil
+		return r.items, nil, nil
 	}
 
-	// Mode 2: Read items from specific note key
+	// Mode 2/3: Read items from specific note key
 	if r.itemsKey != "" {
 		itemsJSON, err := t.GetContent(r.itemsKey)
 		if err != nil {
-			return nil, fmt.Errorf("prioritize: items note %q not found: %w", r.itemsKey, err)
+			return nil, nil, fmt.Errorf("prioritize: items note %q not found: %w", r.itemsKey, err)
+		}
+		if r.objects {
+			return r.parseObjects(itemsJSON)
 		}
 		var items []string
 		if err := json.Unmarshal([]byte(itemsJSON), &items); err != nil {
-			return nil, fmt.Errorf("prioritize: failed to parse items from %q: %w", r.itemsKey, err)
+			return nil, nil, fmt.Errorf("prioritize: failed to parse items from %q: %w", r.itemsKey, err)
 		}
 		if len(items) == 0 {
-			return nil, fmt.Errorf("prioritize: no items to rank")
+			return nil, nil, fmt.Errorf("prioritize: no items to rank")
 		}
-		return items, nil
+		return items, nil, nil
+	}
+
+	if len(r.itemsKeys) > 0 {
+		return r.collectItems(t)
 	}
 
-	return nil, fmt.Errorf("prioritize: requires either explicit items or itemsKey")
+	return nil, nil, fmt.Errorf("prioritize: requires either explicit items or itemsKey")
+}
+
+// collectItems concatenates the string arrays held by itemsKeys, in key order,
+// dropping exact repeats when dedupeItems is set.
+func (r *Prioritize) collectItems(t *Thought) ([]string, []json.RawMessage, error) {
+	var items []string
+	seen := make(map[string]bool)
+	for _, key := range r.itemsKeys {
+		itemsJSON, err := t.GetContent(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("prioritize: items note %q not found: %w", key, err)
+		}
+		var keyItems []string
+		if err := json.Unmarshal([]byte(itemsJSON), &keyItems); err != nil {
+			return nil, nil, fmt.Errorf("prioritize: failed to parse items from %q: %w", key, err)
+		}
+		for _, item := range keyItems {
+			if r.dedupeItems {
+				if seen[item] {
+					continue
+				}
+				seen[item] = true
+			}
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("prioritize: no items to rank")
+	}
+	return items, nil, nil
+}
+
+// parseObjects parses a JSON array of objects and derives a unique display text for each.
+func (r *Prioritize) parseObjects(itemsJSON string) ([]string, []json.RawMessage, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal([]byte(itemsJSON), &objects); err != nil {
+		return nil, nil, fmt.Errorf("prioritize: failed to parse objects from %q: %w", r.itemsKey, err)
+	}
+	if len(objects) == 0 {
+		return nil, nil, fmt.Errorf("prioritize: no items to rank")
+	}
+
+	items := make([]string, len(objects))
+	taken := make(map[string]bool, len(objects))
+	for i, obj := range objects {
+		var fields map[string]any
+		if err := json.Unmarshal(obj, &fields); err != nil {
+			return nil, nil, fmt.Errorf("prioritize: item %d in %q is not an object: %w", i, r.itemsKey, err)
+		}
+
+		text := ""
+		if value, ok := fields[r.displayField]; ok && r.displayField != "" {
+			text = fmt.Sprint(value)
+		} else {
+			compact, err := json.Marshal(fields)
+			if err != nil {
+				return nil, nil, fmt.Errorf("prioritize: failed to render item %d: %w", i, err)
+			}
+			text = string(compact)
+		}
+
+		// Disambiguate duplicate texts so ranked results map back to a single object.
+		// Another item may already read "text (#2)", so count up to an unused suffix.
+		if taken[text] {
+			base := text
+			for n := 2; taken[text]; n++ {
+				text = fmt.Sprintf("%s (#%d)", base, n)
+			}
+		}
+		taken[text] = true
+		items[i] = text
+	}
+	return items, objects, nil
+}
+
+// storeRankedObjects writes the original objects in ranked order.
+// Objects the LLM omitted or renamed are appended in their original order.
+func (r *Prioritize) storeRankedObjects(ctx context.Context, t *Thought, items []string, objects []json.RawMessage, ranked []string) error {
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item] = i
+	}
+
+	used := make([]bool, len(objects))
+	ordered := make([]json.RawMessage, 0, len(objects))
+	for _, text := range ranked {
+		if i, ok := index[text]; ok && !used[i] {
+			used[i] = true
+			ordered = append(ordered, objects[i])
+		}
+	}
+	for i, obj := range objects {
+		if !used[i] {
+			ordered = append(ordered, obj)
+		}
+	}
+
+	orderedJSON, err := json.Marshal(ordered)
+	if err != nil {
+		return fmt.Errorf("prioritize: failed to marshal ranked objects: %w", err)
+	}
+	if err := t.SetContent(ctx, r.key+"_objects", string(orderedJSON), noteSource("prioritize", r.sourceTag)); err != nil {
+		return fmt.Errorf("prioritize: failed to persist ranked objects: %w", err)
+	}
+	return nil
 }
 
 // runIntrospection executes the transform synapse for semantic summary.
@@ -211,6 +597,10 @@ func (r *Prioritize) runIntrospection(ctx context.Context, t *Thought, resp zyn.
 		summaryKey:               r.summaryKey,
 		introspectionTemperature: r.introspectionTemperature,
 		synapsePrompt:            "Synthesize ranking into context for next reasoning step",
+		style:                    r.introspectionStyle,
+		sourceTag:                r.sourceTag,
+		locale:                   r.locale,
+		sessionContext:           r.sessionIntrospection,
 	})
 }
 
@@ -233,9 +623,52 @@ func (r *Prioritize) buildIntrospectionInput(resp zyn.RankingResponse, originalN
 	}
 }
 
+// dimensionRaws collects the raw response of each dimension's ranking call, and
+// the call's prompt and token usage as "{dimension}.prompt" style metadata.
+type dimensionRaws struct {
+	responses map[string]string
+	metadata  map[string]string
+}
+
+// record stores the session's latest call as the call for dimension.
+func (d *dimensionRaws) record(dimension string, session *zyn.Session) {
+	d.responses[dimension], _ = lastAssistantContent(session)
+	for k, v := range rawCallMetadata(session, dimension+".") {
+		d.metadata[k] = v
+	}
+}
+
+// storeRawResponse persists the raw ranking response as {key}_raw.
+// In multi-criteria mode the note holds a JSON object of raw responses keyed by dimension,
+// with "dimensions" metadata listing them and each call's prompt and usage under
+// "{dimension}.prompt", "{dimension}.prompt_tokens" and so on.
+func (r *Prioritize) storeRawResponse(ctx context.Context, t *Thought, raws *dimensionRaws) error {
+	if raws == nil {
+		return captureRawResponse(ctx, t, "prioritize", r.key, r.sourceTag)
+	}
+	rawJSON, err := json.Marshal(raws.responses)
+	if err != nil {
+		return fmt.Errorf("prioritize: failed to marshal raw responses: %w", err)
+	}
+	dimensions := make([]string, 0, len(raws.responses))
+	for name := range raws.responses {
+		dimensions = append(dimensions, name)
+	}
+	sort.Strings(dimensions)
+	dimensionsJSON, err := json.Marshal(dimensions)
+	if err != nil {
+		return fmt.Errorf("prioritize: failed to marshal raw dimensions: %w", err)
+	}
+	raws.metadata["dimensions"] = string(dimensionsJSON)
+	if err := t.SetNote(ctx, r.key+"_raw", string(rawJSON), noteSource("prioritize-raw", r.sourceTag), raws.metadata); err != nil {
+		return fmt.Errorf("prioritize: failed to persist raw response: %w", err)
+	}
+	return nil
+}
+
 // emitFailed emits a step failed event.
 func (r *Prioritize) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(r.key),
 		FieldStepType.Field("prioritize"),
@@ -251,7 +684,12 @@ func (r *Prioritize) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (r *Prioritize) Schema() pipz.Node {
-	return pipz.Node{Identity: r.identity, Type: "prioritize"}
+	var inputs []string
+	if r.itemsKey != "" {
+		inputs = []string{r.itemsKey}
+	}
+	inputs = append(inputs, r.itemsKeys...)
+	return reasoningSchema(r.identity, "prioritize", inputs, r.key, r.summaryKey, r.useIntrospection, r.captureRaw)
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -272,6 +710,25 @@ func (r *Prioritize) Scan(t *Thought) (*zyn.RankingResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (r *Prioritize) ScanIfConfident(t *Thought, min float32) (*zyn.RankingResponse, bool, error) {
+	return scanIfConfident(r.Scan, func(r *zyn.RankingResponse) float64 { return r.Confidence }, t, min)
+}
+
+// ScanObjects retrieves the ranked objects written by NewPrioritizeFromObjects.
+func (r *Prioritize) ScanObjects(t *Thought) ([]json.RawMessage, error) {
+	content, err := t.GetContent(r.key + "_objects")
+	if err != nil {
+		return nil, fmt.Errorf("prioritize scan: %w", err)
+	}
+	var objects []json.RawMessage
+	if err := json.Unmarshal([]byte(content), &objects); err != nil {
+		return nil, fmt.Errorf("prioritize scan: failed to unmarshal objects: %w", err)
+	}
+	return objects, nil
+}
+
 // Builder methods
 
 // WithProvider sets the provider for this step.
@@ -292,6 +749,14 @@ func (r *Prioritize) WithIntrospection() *Prioritize {
 	return r
 }
 
+// WithSessionIntrospection sends introspection only the ranking; see DefaultSessionIntrospection.
+// The ranking call sends items and criteria rather than notes, so in this mode the
+// summary draws on the ranked items alone.
+func (r *Prioritize) WithSessionIntrospection() *Prioritize {
+	r.sessionIntrospection = true
+	return r
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (r *Prioritize) WithSummaryKey(key string) *Prioritize {
 	r.summaryKey = key
@@ -309,3 +774,45 @@ func (r *Prioritize) WithIntrospectionTemperature(temp float32) *Prioritize {
 	r.introspectionTemperature = temp
 	return r
 }
+
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (r *Prioritize) WithIntrospectionStyle(style string) *Prioritize {
+	r.introspectionStyle = style
+	return r
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (r *Prioritize) WithRawCapture() *Prioritize {
+	r.captureRaw = true
+	return r
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "prioritize:<tag>",
+// distinguishing notes from multiple Prioritize steps in the same pipeline.
+func (r *Prioritize) WithSourceTag(tag string) *Prioritize {
+	r.sourceTag = tag
+	return r
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (r *Prioritize) WithLocale(lang string) *Prioritize {
+	r.locale = lang
+	return r
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (r *Prioritize) WithContextStrategy(strategy ContextStrategy) *Prioritize {
+	r.contextStrategy = strategy
+	return r
+}
+
+// WithDedupe drops repeated items when NewPrioritizeFromKeys concatenates its notes,
+// keeping the first occurrence.
+func (r *Prioritize) WithDedupe() *Prioritize {
+	r.dedupeItems = true
+	return r
+}
@@ -182,6 +182,56 @@ func TestPrioritizeWithIntrospection(t *testing.T) {
 	}
 }
 
+// mockRecordingPrioritizeProvider records each prompt and answers like mockPrioritizeProvider.
+type mockRecordingPrioritizeProvider struct {
+	mockPrioritizeProvider
+	prompts []string
+}
+
+func (m *mockRecordingPrioritizeProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	m.prompts = append(m.prompts, messages[len(messages)-1].Content)
+	return m.mockPrioritizeProvider.Call(ctx, messages, temperature)
+}
+
+func TestPrioritizeSessionIntrospection(t *testing.T) {
+	items := []string{"Login bug affecting users", "Critical outage in production", "Minor UI glitch"}
+	run := func(t *testing.T, step *Prioritize, provider *mockRecordingPrioritizeProvider) {
+		t.Helper()
+		thought := newTestThought("test session introspection")
+		thought.SetContent(context.Background(), "context", "Enterprise customers report checkout failures", "initial")
+
+		result, err := step.WithProvider(provider).WithIntrospection().Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(provider.prompts) != 2 {
+			t.Fatalf("expected 2 provider calls, got %d", len(provider.prompts))
+		}
+		if _, err := result.GetContent("ticket_priority_summary"); err != nil {
+			t.Errorf("expected summary note: %v", err)
+		}
+		if !strings.Contains(provider.prompts[1], "Critical outage in production") {
+			t.Errorf("expected introspection to receive the ranking, got %q", provider.prompts[1])
+		}
+	}
+
+	t.Run("re-sends notes by default", func(t *testing.T) {
+		provider := &mockRecordingPrioritizeProvider{}
+		run(t, NewPrioritize("ticket_priority", "urgency", items), provider)
+		if !strings.Contains(provider.prompts[1], "checkout failures") {
+			t.Error("expected introspection prompt to include the notes")
+		}
+	})
+
+	t.Run("omits notes when enabled", func(t *testing.T) {
+		provider := &mockRecordingPrioritizeProvider{}
+		run(t, NewPrioritize("ticket_priority", "urgency", items).WithSessionIntrospection(), provider)
+		if strings.Contains(provider.prompts[1], "checkout failures") {
+			t.Error("expected introspection prompt to omit the notes")
+		}
+	})
+}
+
 func TestPrioritizeWithSummaryKey(t *testing.T) {
 	provider := &mockPrioritizeProvider{}
 	SetProvider(provider)
@@ -257,6 +307,44 @@ func TestNewPrioritizeFrom(t *testing.T) {
 	}
 }
 
+func TestNewPrioritizeFromKeys(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test rank from keys")
+	thought.SetContent(ctx, "support_issues", `["Critical outage in production", "Login bug affecting users"]`, "prep")
+	thought.SetContent(ctx, "monitoring_issues", `["Critical outage in production", "Minor UI glitch"]`, "prep")
+
+	step := NewPrioritizeFromKeys("priority", "urgency", "support_issues", "monitoring_issues")
+	items, _, err := step.resolveItems(thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 4 {
+		t.Errorf("expected 4 concatenated items, got %v", items)
+	}
+
+	step.WithDedupe()
+	items, _, err = step.resolveItems(thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Critical outage in production", "Login bug affecting users", "Minor UI glitch"}
+	if strings.Join(items, "|") != strings.Join(want, "|") {
+		t.Errorf("expected %v, got %v", want, items)
+	}
+
+	result, err := step.WithProvider(&mockPrioritizeProvider{}).Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp, _ := step.Scan(result); len(resp.Ranked) != 3 {
+		t.Errorf("expected 3 ranked items, got %v", resp.Ranked)
+	}
+
+	if _, err := NewPrioritizeFromKeys("priority", "urgency", "support_issues", "missing").Process(ctx, thought); err == nil {
+		t.Error("expected error for a missing items note")
+	}
+}
+
 func TestPrioritizeFromMissingNote(t *testing.T) {
 	provider := &mockPrioritizeProvider{}
 	SetProvider(provider)
@@ -296,3 +384,243 @@ func TestPrioritizeFromInvalidJSON(t *testing.T) {
 		t.Errorf("expected parse error, got: %v", err)
 	}
 }
+
+func TestNewPrioritizeFromObjects(t *testing.T) {
+	provider := &mockPrioritizeProvider{}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	thought := newTestThought("test prioritize objects")
+	thought.SetContent(context.Background(), "tickets", `[
+		{"id": "T-1", "title": "Minor UI glitch"},
+		{"id": "T-2", "title": "Login bug affecting users"},
+		{"id": "T-3", "title": "Critical outage in production"}
+	]`, "initial")
+
+	step := NewPrioritizeFromObjects("ticket_priority", "urgency", "tickets", "title")
+
+	result, err := step.Process(context.Background(), thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	objects, err := step.ScanObjects(result)
+	if err != nil {
+		t.Fatalf("scan objects failed: %v", err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objects))
+	}
+
+	var ids []string
+	for _, obj := range objects {
+		var ticket struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(obj, &ticket); err != nil {
+			t.Fatalf("failed to parse object: %v", err)
+		}
+		ids = append(ids, ticket.ID)
+	}
+
+	expected := []string{"T-3", "T-2", "T-1"}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("expected ranked ids %v, got %v", expected, ids)
+			break
+		}
+	}
+}
+
+func TestPrioritizeFromObjectsDuplicateTitles(t *testing.T) {
+	step := NewPrioritizeFromObjects("ticket_priority", "urgency", "tickets", "title")
+
+	// The third title already reads like a disambiguated duplicate
+	items, _, err := step.parseObjects(`[
+		{"id": "T-1", "title": "Outage"},
+		{"id": "T-2", "title": "Outage"},
+		{"id": "T-3", "title": "Outage (#2)"},
+		{"id": "T-4", "title": "Outage"}
+	]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item] {
+			t.Fatalf("expected unique display texts, got %v", items)
+		}
+		seen[item] = true
+	}
+	if items[0] != "Outage" || items[1] != "Outage (#2)" {
+		t.Errorf("expected the first duplicate numbered from 2, got %v", items)
+	}
+}
+
+func TestPrioritizeFromObjectsInvalid(t *testing.T) {
+	provider := &mockPrioritizeProvider{}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	thought := newTestThought("test prioritize invalid objects")
+	thought.SetContent(context.Background(), "tickets", `["not", "objects"]`, "initial")
+
+	step := NewPrioritizeFromObjects("ticket_priority", "urgency", "tickets", "title")
+
+	_, err := step.Process(context.Background(), thought)
+	if err == nil {
+		t.Fatal("expected error for non-object items")
+	}
+	if provider.callCount != 0 {
+		t.Errorf("expected no provider calls, got %d", provider.callCount)
+	}
+}
+
+// mockDimensionProvider ranks items differently depending on the criteria dimension in the prompt.
+type mockDimensionProvider struct {
+	callCount int
+}
+
+func (m *mockDimensionProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.callCount++
+	prompt := messages[len(messages)-1].Content
+	content := `{"ranked": ["outage", "login", "typo"], "confidence": 0.9, "reasoning": ["Outage blocks everyone"]}`
+	if strings.Contains(prompt, "effort") {
+		content = `{"ranked": ["typo", "login", "outage"], "confidence": 0.7, "reasoning": ["Typo is a one-line fix"]}`
+	}
+	return &zyn.ProviderResponse{
+		Content: content,
+		Usage:   zyn.TokenUsage{Prompt: 10, Completion: 20, Total: 30},
+	}, nil
+}
+
+func (m *mockDimensionProvider) Name() string {
+	return "mock-dimension"
+}
+
+func TestPrioritizeMulti(t *testing.T) {
+	items := []string{"login", "typo", "outage"}
+
+	t.Run("weighted combination", func(t *testing.T) {
+		provider := &mockDimensionProvider{}
+		step := NewPrioritizeMulti("priority", map[string]float64{"urgency": 3, "effort": 1}, items).
+			WithProvider(provider)
+
+		result, err := step.Process(context.Background(), newTestThought("multi"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if provider.callCount != 2 {
+			t.Errorf("expected one call per dimension, got %d", provider.callCount)
+		}
+
+		resp, err := step.Scan(result)
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		// outage: 0.75*1 + 0.25*0 = 0.75, login: 0.5, typo: 0.25
+		if strings.Join(resp.Ranked, ",") != "outage,login,typo" {
+			t.Errorf("unexpected combined order: %v", resp.Ranked)
+		}
+		if len(resp.Reasoning) != 2 || !strings.HasPrefix(resp.Reasoning[0], "[effort]") {
+			t.Errorf("expected dimension-prefixed reasoning, got %v", resp.Reasoning)
+		}
+
+		raw, err := result.GetMetadata("priority", "scores_effort")
+		if err != nil {
+			t.Fatalf("expected effort scores metadata: %v", err)
+		}
+		var scores map[string]float64
+		if err := json.Unmarshal([]byte(raw), &scores); err != nil {
+			t.Fatalf("invalid scores metadata: %v", err)
+		}
+		if scores["typo"] != 1 || scores["outage"] != 0 {
+			t.Errorf("unexpected effort scores: %v", scores)
+		}
+		if w, _ := result.GetMetadata("priority", "weights"); !strings.Contains(w, `"urgency":0.75`) {
+			t.Errorf("expected normalized weights, got %q", w)
+		}
+	})
+
+	t.Run("weights change the order", func(t *testing.T) {
+		step := NewPrioritizeMulti("priority", map[string]float64{"urgency": 1, "effort": 3}, items).
+			WithProvider(&mockDimensionProvider{})
+
+		result, err := step.Process(context.Background(), newTestThought("multi"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, _ := step.Scan(result)
+		if resp.Ranked[0] != "typo" {
+			t.Errorf("expected typo first when effort dominates, got %v", resp.Ranked)
+		}
+	})
+
+	t.Run("invalid weights", func(t *testing.T) {
+		provider := &mockDimensionProvider{}
+		step := NewPrioritizeMulti("priority", map[string]float64{"urgency": 1, "effort": 0}, items).
+			WithProvider(provider)
+
+		if _, err := step.Process(context.Background(), newTestThought("multi")); err == nil {
+			t.Error("expected error for non-positive weight")
+		}
+		if provider.callCount != 0 {
+			t.Errorf("expected no provider calls, got %d", provider.callCount)
+		}
+	})
+}
+
+func TestPrioritizeMultiRawCapture(t *testing.T) {
+	step := NewPrioritizeMulti("priority", map[string]float64{"urgency": 1, "effort": 1}, []string{"login", "typo", "outage"}).
+		WithProvider(&mockDimensionProvider{}).
+		WithRawCapture()
+
+	result, err := step.Process(context.Background(), newTestThought("multi raw"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := result.GetContent("priority_raw")
+	if err != nil {
+		t.Fatalf("expected raw note: %v", err)
+	}
+	var raws map[string]string
+	if err := json.Unmarshal([]byte(content), &raws); err != nil {
+		t.Fatalf("expected JSON object of raw responses: %v", err)
+	}
+	if !strings.Contains(raws["effort"], "one-line fix") || !strings.Contains(raws["urgency"], "blocks everyone") {
+		t.Errorf("unexpected raw responses: %v", raws)
+	}
+}
+
+func TestPrioritizeSingleItemSkipsProvider(t *testing.T) {
+	provider := &mockPrioritizeProvider{}
+
+	step := NewPrioritize("priority", "urgency", []string{"only_ticket"}).
+		WithProvider(provider).
+		WithRawCapture()
+
+	result, err := step.Process(context.Background(), newTestThought("single item"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if provider.callCount != 0 {
+		t.Errorf("expected no provider calls for a single item, got %d", provider.callCount)
+	}
+
+	resp, err := step.Scan(result)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(resp.Ranked) != 1 || resp.Ranked[0] != "only_ticket" || resp.Confidence != 1.0 {
+		t.Errorf("unexpected single-item ranking: %+v", resp)
+	}
+	if _, err := result.GetContent("priority_raw"); err == nil {
+		t.Error("expected no raw note when no provider call was made")
+	}
+	if len(result.GetUnpublishedNotes()) != 0 {
+		t.Error("expected notes to be published after step")
+	}
+}
@@ -15,6 +15,41 @@ type Provider interface {
 	Name() string
 }
 
+// StructuredOutputProvider is implemented by providers that can constrain
+// responses to a JSON schema (structured output or JSON mode).
+// Primitives configured for structured output use CallWithSchema when the
+// resolved provider supports it, and fall back to Call otherwise.
+type StructuredOutputProvider interface {
+	Provider
+	CallWithSchema(ctx context.Context, messages []zyn.Message, temperature float32, schema string) (*zyn.ProviderResponse, error)
+}
+
+// schemaProvider adapts a StructuredOutputProvider to Provider with a fixed schema.
+type schemaProvider struct {
+	StructuredOutputProvider
+	schema string
+}
+
+// Call forwards the request with the configured schema.
+func (s *schemaProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	return s.CallWithSchema(ctx, messages, temperature, s.schema)
+}
+
+// responseRecorder wraps a Provider and keeps the content of its latest response.
+type responseRecorder struct {
+	Provider
+	response string
+}
+
+// Call forwards the request and records the response content.
+func (r *responseRecorder) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	resp, err := r.Provider.Call(ctx, messages, temperature)
+	if err == nil && resp != nil {
+		r.response = resp.Content
+	}
+	return resp, err
+}
+
 // Context key for provider.
 type providerKeyType struct{}
 
@@ -26,11 +61,19 @@ var (
 	globalProviderMu sync.RWMutex
 )
 
+// Per-step-type providers, keyed by step type (e.g. "decide", "converge").
+var (
+	stepTypeProviders   = make(map[string]Provider)
+	stepTypeProvidersMu sync.RWMutex
+)
+
 // ErrNoProvider is returned when no provider can be resolved.
 var ErrNoProvider = errors.New("no provider configured: set via context, step-level, or global")
 
 // SetProvider sets the global fallback provider.
 // This provider is used when no context or step-level provider is available.
+// It is shared by the whole process: when the provider differs between concurrent
+// requests, such as per tenant, use WithProvider instead of swapping it here.
 func SetProvider(p Provider) {
 	globalProviderMu.Lock()
 	defer globalProviderMu.Unlock()
@@ -44,8 +87,36 @@ func GetProvider() Provider {
 	return globalProvider
 }
 
+// SetProviderForStepType sets the provider used by all steps of the given type,
+// such as "categorize" or "converge". Passing nil removes the mapping.
+// This lets cheap models handle classification while stronger models handle synthesis.
+func SetProviderForStepType(stepType string, p Provider) {
+	stepTypeProvidersMu.Lock()
+	defer stepTypeProvidersMu.Unlock()
+	if p == nil {
+		delete(stepTypeProviders, stepType)
+		return
+	}
+	stepTypeProviders[stepType] = p
+}
+
+// GetProviderForStepType returns the provider configured for a step type, if set.
+func GetProviderForStepType(stepType string) Provider {
+	stepTypeProvidersMu.RLock()
+	defer stepTypeProvidersMu.RUnlock()
+	return stepTypeProviders[stepType]
+}
+
 // WithProvider adds a provider to the context.
-// This is the preferred method for provider management.
+// This is the preferred method for provider management, and the recommended way to
+// choose a provider per request: each request carries its own provider, so there is
+// no shared state to race on. Every primitive resolves its provider from the context
+// passed to Process, including introspection calls and Converge branches.
+//
+// Example:
+//
+//	ctx = cogito.WithProvider(ctx, tenantProviders[tenantID])
+//	result, err := pipeline.Process(ctx, thought)
 func WithProvider(ctx context.Context, p Provider) context.Context {
 	return context.WithValue(ctx, providerKey, p)
 }
@@ -62,6 +133,16 @@ func ProviderFromContext(ctx context.Context) (Provider, bool) {
 // 3. Global provider
 // 4. Error if none found.
 func ResolveProvider(ctx context.Context, stepProvider Provider) (Provider, error) {
+	return ResolveProviderForStep(ctx, "", stepProvider)
+}
+
+// ResolveProviderForStep determines which provider to use for a step type:
+// 1. Step-level provider (passed as argument)
+// 2. Context provider
+// 3. Step-type provider (see SetProviderForStepType)
+// 4. Global provider
+// 5. Error if none found.
+func ResolveProviderForStep(ctx context.Context, stepType string, stepProvider Provider) (Provider, error) {
 	// 1. Step-level provider takes highest priority
 	if stepProvider != nil {
 		return stepProvider, nil
@@ -72,7 +153,14 @@ func ResolveProvider(ctx context.Context, stepProvider Provider) (Provider, erro
 		return p, nil
 	}
 
-	// 3. Global provider
+	// 3. Step-type provider
+	if stepType != "" {
+		if p := GetProviderForStepType(stepType); p != nil {
+			return p, nil
+		}
+	}
+
+	// 4. Global provider
 	globalProviderMu.RLock()
 	p := globalProvider
 	globalProviderMu.RUnlock()
@@ -81,6 +169,6 @@ func ResolveProvider(ctx context.Context, stepProvider Provider) (Provider, erro
 		return p, nil
 	}
 
-	// 4. No provider found
+	// 5. No provider found
 	return nil, ErrNoProvider
 }
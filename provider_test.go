@@ -200,6 +200,71 @@ func TestResolveProviderPriority(t *testing.T) {
 	}
 }
 
+func TestResolveProviderForStep(t *testing.T) {
+	global := &mockProvider{name: "global"}
+	contextProvider := &mockProvider{name: "context"}
+	stepProvider := &mockProvider{name: "step"}
+	cheap := &mockProvider{name: "cheap"}
+
+	SetProvider(global)
+	defer SetProvider(nil)
+	SetProviderForStepType("categorize", cheap)
+	defer SetProviderForStepType("categorize", nil)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		stepType     string
+		stepProvider Provider
+		expected     string
+	}{
+		{
+			name:         "step level wins over step type",
+			ctx:          context.Background(),
+			stepType:     "categorize",
+			stepProvider: stepProvider,
+			expected:     "step",
+		},
+		{
+			name:     "context wins over step type",
+			ctx:      WithProvider(context.Background(), contextProvider),
+			stepType: "categorize",
+			expected: "context",
+		},
+		{
+			name:     "step type wins over global",
+			ctx:      context.Background(),
+			stepType: "categorize",
+			expected: "cheap",
+		},
+		{
+			name:     "unmapped step type uses global",
+			ctx:      context.Background(),
+			stepType: "converge",
+			expected: "global",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ResolveProviderForStep(tt.ctx, tt.stepType, tt.stepProvider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if p.Name() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, p.Name())
+			}
+		})
+	}
+
+	// Removing the mapping falls back to global
+	SetProviderForStepType("categorize", nil)
+	if p := GetProviderForStepType("categorize"); p != nil {
+		t.Errorf("expected no step type provider after removal, got %q", p.Name())
+	}
+}
+
 func TestConcurrentProviderAccess(t *testing.T) {
 	// Test thread safety of global provider
 	mock := &mockProvider{name: "concurrent"}
@@ -49,10 +49,11 @@ func NewRecall(key, thoughtID string) *Recall {
 
 // Process implements pipz.Chainable[*Thought].
 func (r *Recall) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, r.provider)
+	provider, err := ResolveProviderForStep(ctx, "recall", r.provider)
 	if err != nil {
 		return t, fmt.Errorf("recall: %w", err)
 	}
@@ -86,6 +87,8 @@ func (r *Recall) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		return t, fmt.Errorf("recall: failed to create transform synapse: %w", err)
 	}
 
+	emitContextRendered(ctx, t, r.key, "recall", noteContext, len(targetNotes))
+
 	// Summarize via LLM
 	summary, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 		Text:  noteContext,
@@ -119,7 +122,7 @@ func (r *Recall) Process(ctx context.Context, t *Thought) (*Thought, error) {
 
 // emitFailed emits a step failed event.
 func (r *Recall) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(r.key),
 		FieldStepType.Field("recall"),
@@ -135,7 +138,7 @@ func (r *Recall) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (r *Recall) Schema() pipz.Node {
-	return pipz.Node{Identity: r.identity, Type: "recall"}
+	return stepSchema(r.identity, "recall", false, nil, []string{r.key})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -0,0 +1,175 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+)
+
+// DefaultRedaction is the text that replaces each redacted span.
+const DefaultRedaction = "[REDACTED]"
+
+// Redact is a data-minimization primitive that implements pipz.Chainable[*Thought].
+// It rewrites a note with every span matching a set of regular expressions replaced,
+// so the sanitized note, rather than the raw one, flows downstream. No LLM call is made.
+type Redact struct {
+	identity    pipz.Identity
+	key         string
+	targetKey   string
+	patterns    []*regexp.Regexp
+	compileErr  error
+	replacement string
+	replace     bool
+}
+
+// NewRedact creates a new redaction primitive.
+//
+// The primitive reads the note at targetKey, replaces every match of patterns
+// (Go regular expressions) with DefaultRedaction, and stores the result at key.
+// If the raw note is still unpublished it is withheld from later steps' context,
+// so only the redacted text is sent to the LLM. An invalid pattern is reported
+// when the step runs.
+//
+// Output Notes:
+//   - {key}: The redacted content, with metadata "redacted_from", "redaction_count"
+//     and "supersedes"
+//
+// Example:
+//
+//	redact := cogito.NewRedact("ticket_clean", "ticket", []string{
+//	    `[\w.+-]+@[\w-]+\.[\w.]+`, // email addresses
+//	    `\b\d{3}-\d{2}-\d{4}\b`,   // SSNs
+//	}).WithReplaceOriginal()
+//	result, _ := redact.Process(ctx, thought)
+func NewRedact(key, targetKey string, patterns []string) *Redact {
+	r := &Redact{
+		identity:    pipz.NewIdentity(key, "Note redaction primitive"),
+		key:         key,
+		targetKey:   targetKey,
+		replacement: DefaultRedaction,
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			r.compileErr = fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			break
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (r *Redact) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(r.key),
+		FieldStepType.Field("redact"),
+		FieldNoteCount.Field(t.NoteCount()),
+	)
+
+	if r.compileErr != nil {
+		r.emitFailed(ctx, t, start, r.compileErr)
+		return t, fmt.Errorf("redact: %w", r.compileErr)
+	}
+
+	content, err := t.GetContent(r.targetKey)
+	if err != nil {
+		r.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("redact: %w", err)
+	}
+
+	// Redact every pattern in turn, counting the spans removed
+	count := 0
+	for _, re := range r.patterns {
+		count += len(re.FindAllStringIndex(content, -1))
+		content = re.ReplaceAllLiteralString(content, r.replacement)
+	}
+
+	// The raw note must not reach the next prompt alongside its redacted copy
+	metadata := map[string]string{
+		"redacted_from":   r.targetKey,
+		"redaction_count": strconv.Itoa(count),
+	}
+	if ids := t.unpublishedIDs(r.targetKey); ids != "" {
+		metadata[SupersedesMetadataKey] = ids
+	}
+	if err := t.SetNote(ctx, r.key, content, "redact", metadata); err != nil {
+		r.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("redact: failed to persist note: %w", err)
+	}
+
+	// Shadow the original so later reads of targetKey see the redacted content
+	if r.replace && r.targetKey != r.key {
+		if err := t.SetNote(ctx, r.targetKey, content, "redact", copyMetadata(metadata)); err != nil {
+			r.emitFailed(ctx, t, start, err)
+			return t, fmt.Errorf("redact: failed to replace original note: %w", err)
+		}
+	}
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(r.key),
+		FieldStepType.Field("redact"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldNoteCount.Field(t.NoteCount()),
+	)
+
+	return t, nil
+}
+
+// emitFailed emits a step failed event.
+func (r *Redact) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(r.key),
+		FieldStepType.Field("redact"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (r *Redact) Identity() pipz.Identity {
+	return r.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (r *Redact) Schema() pipz.Node {
+	outputs := []string{r.key}
+	if r.replace && r.targetKey != r.key {
+		outputs = append(outputs, r.targetKey)
+	}
+	return stepSchema(r.identity, "redact", false, []string{r.targetKey}, outputs)
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (r *Redact) Close() error {
+	return nil
+}
+
+// Builder methods
+
+// WithReplacement sets the text that replaces each redacted span.
+func (r *Redact) WithReplacement(text string) *Redact {
+	r.replacement = text
+	return r
+}
+
+// WithReplaceOriginal also writes the redacted content under targetKey, so
+// GetNote and GetContent on the original key return the sanitized version.
+// Notes are append-only: the raw note remains in the thought's history.
+func (r *Redact) WithReplaceOriginal() *Redact {
+	r.replace = true
+	return r
+}
@@ -0,0 +1,97 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/pipz"
+)
+
+func TestRedact(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("redact")
+	thought.SetContent(ctx, "ticket", "Contact jane@example.com or bob@example.org, SSN 123-45-6789", "input")
+
+	redact := NewRedact("ticket_clean", "ticket", []string{
+		`[\w.+-]+@[\w-]+\.[\w.]+`,
+		`\b\d{3}-\d{2}-\d{4}\b`,
+	})
+	result, err := redact.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clean, _ := result.GetContent("ticket_clean")
+	if strings.Contains(clean, "@") || strings.Contains(clean, "6789") {
+		t.Errorf("expected sensitive spans removed, got %q", clean)
+	}
+	if strings.Count(clean, DefaultRedaction) != 3 {
+		t.Errorf("expected 3 redactions, got %q", clean)
+	}
+	if count, _ := result.GetMetadata("ticket_clean", "redaction_count"); count != "3" {
+		t.Errorf("expected redaction_count 3, got %q", count)
+	}
+	if original, _ := result.GetContent("ticket"); !strings.Contains(original, "jane@example.com") {
+		t.Error("expected original note untouched without WithReplaceOriginal")
+	}
+}
+
+func TestRedactReplaceOriginal(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("redact")
+	thought.SetContent(ctx, "ticket", "card 4111 1111 1111 1111", "input")
+
+	redact := NewRedact("ticket_clean", "ticket", []string{`\d{4}( \d{4}){3}`}).
+		WithReplacement("<card>").
+		WithReplaceOriginal()
+	result, err := redact.Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if original, _ := result.GetContent("ticket"); original != "card <card>" {
+		t.Errorf("expected original key to read redacted content, got %q", original)
+	}
+}
+
+func TestRedactWithholdsRawNote(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("redact")
+	thought.SetContent(ctx, "customer", "premium tier", "input")
+	thought.SetContent(ctx, "ticket", "contact bob@example.com", "input")
+
+	redact := NewRedact("ticket_clean", "ticket", []string{`[\w.+-]+@[\w-]+\.[\w.]+`})
+	provider := &capturingDecideProvider{}
+	decide := NewDecide("escalate", "Should this be escalated?").WithProvider(provider)
+
+	if _, err := Sequence(pipz.NewIdentity("triage", "Triage"), redact, decide).Process(ctx, thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	prompt := provider.prompts[0]
+	if strings.Contains(prompt, "bob@example.com") {
+		t.Errorf("expected raw note withheld from the next prompt, got %q", prompt)
+	}
+	if !strings.Contains(prompt, "contact [REDACTED]") || !strings.Contains(prompt, "premium tier") {
+		t.Errorf("expected redacted note and other context in the next prompt, got %q", prompt)
+	}
+
+	// The raw note is still readable by key
+	if original, _ := thought.GetContent("ticket"); original != "contact bob@example.com" {
+		t.Errorf("expected original note kept, got %q", original)
+	}
+}
+
+func TestRedactErrors(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("redact")
+	thought.SetContent(ctx, "ticket", "text", "input")
+
+	if _, err := NewRedact("clean", "ticket", []string{"("}).Process(ctx, thought); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+	if _, err := NewRedact("clean", "missing", []string{"x"}).Process(ctx, thought); err == nil {
+		t.Error("expected error for missing target note")
+	}
+}
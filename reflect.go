@@ -48,10 +48,11 @@ func NewReflect(key string) *Reflect {
 
 // Process implements pipz.Chainable[*Thought].
 func (r *Reflect) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, r.provider)
+	provider, err := ResolveProviderForStep(ctx, "reflect", r.provider)
 	if err != nil {
 		return t, fmt.Errorf("reflect: %w", err)
 	}
@@ -86,6 +87,8 @@ func (r *Reflect) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		return t, fmt.Errorf("reflect: failed to create transform synapse: %w", err)
 	}
 
+	emitContextRendered(ctx, t, r.key, "reflect", noteContext, len(notes))
+
 	// Generate reflection via LLM
 	reflection, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 		Text:  noteContext,
@@ -97,10 +100,10 @@ func (r *Reflect) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	}
 
 	// Store reflection as note
-	if err := t.SetNote(ctx, r.key, reflection, "reflect", map[string]string{
-		"source_note_count": fmt.Sprintf("%d", len(notes)),
-		"unpublished_only":  fmt.Sprintf("%t", r.unpublishedOnly),
-	}); err != nil {
+	metadata := contextKeysMetadata(notes)
+	metadata["source_note_count"] = fmt.Sprintf("%d", len(notes))
+	metadata["unpublished_only"] = fmt.Sprintf("%t", r.unpublishedOnly)
+	if err := t.SetNote(ctx, r.key, reflection, "reflect", metadata); err != nil {
 		r.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("reflect: failed to persist reflection: %w", err)
 	}
@@ -119,7 +122,7 @@ func (r *Reflect) Process(ctx context.Context, t *Thought) (*Thought, error) {
 
 // emitFailed emits a step failed event.
 func (r *Reflect) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(r.key),
 		FieldStepType.Field("reflect"),
@@ -135,7 +138,7 @@ func (r *Reflect) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (r *Reflect) Schema() pipz.Node {
-	return pipz.Node{Identity: r.identity, Type: "reflect"}
+	return stepSchema(r.identity, "reflect", true, nil, []string{r.key})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -49,3 +49,23 @@ func TestReflect_FailsOnEmptyUnpublished(t *testing.T) {
 		t.Error("reflect should fail when no unpublished notes")
 	}
 }
+
+func TestReflect_RecordsContextKeys(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test")
+	thought.SetContent(ctx, "old", "already sent", "test")
+	thought.MarkNotesPublished()
+	thought.SetContent(ctx, "ticket", "Login broken", "test")
+	thought.SetContent(ctx, "severity", "high", "test")
+
+	result, err := NewReflect("reflection").
+		WithUnpublishedOnly().
+		WithProvider(&summarizeProvider{}).
+		Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys, _ := result.GetMetadata("reflection", "context_keys"); keys != "ticket,severity" {
+		t.Errorf("expected context_keys 'ticket,severity', got %q", keys)
+	}
+}
@@ -40,6 +40,7 @@ func NewReset(key string) *Reset {
 
 // Process implements pipz.Chainable[*Thought].
 func (r *Reset) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	previousCount := t.Session.Len()
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
 	"github.com/zoobzio/zyn"
@@ -55,6 +54,7 @@ func NewRestore(key, thoughtID string) *Restore {
 
 // Process implements pipz.Chainable[*Thought].
 func (r *Restore) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Emit step started
@@ -82,7 +82,7 @@ func (r *Restore) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	parentID := targetThought.ID
 	newThought := &Thought{
 		Intent:         targetThought.Intent,
-		TraceID:        uuid.New().String(),
+		TraceID:        NewID(),
 		ParentID:       &parentID,
 		TaskID:         targetThought.TaskID,
 		Session:        zyn.NewSession(),
@@ -145,7 +145,7 @@ func (r *Restore) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(r.key),
 		FieldStepType.Field("restore"),
 		FieldStepDuration.Field(time.Since(start)),
-		FieldNoteCount.Field(len(newThought.AllNotes())),
+		FieldNoteCount.Field(newThought.NoteCount()),
 	)
 
 	return newThought, nil
@@ -0,0 +1,73 @@
+package cogito
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zoobzio/zyn"
+)
+
+// encodeSession serializes a session's messages for persistence.
+func encodeSession(session *zyn.Session) (string, error) {
+	if session == nil {
+		return "[]", nil
+	}
+	messages := session.Messages()
+	if messages == nil {
+		messages = []zyn.Message{}
+	}
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session: %w", err)
+	}
+	return string(data), nil
+}
+
+// encodeAttrs serializes the attributes set with SetPersistentAttr for persistence.
+func encodeAttrs(t *Thought) (string, error) {
+	attrs := make(map[string]any)
+	t.persistedAttrs.Range(func(k, _ any) bool {
+		if v, ok := t.attrs.Load(k); ok {
+			attrs[k.(string)] = v
+		}
+		return true
+	})
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return string(data), nil
+}
+
+// restoreState applies a persisted publish count, session and persistent attributes
+// to a hydrated thought.
+func restoreState(t *Thought, publishedCount int, session, attrs string) error {
+	if publishedCount < 0 {
+		publishedCount = 0
+	}
+	if notes := t.NoteCount(); publishedCount > notes {
+		publishedCount = notes
+	}
+	t.SetPublishedCount(publishedCount)
+
+	if attrs != "" {
+		var values map[string]any
+		if err := json.Unmarshal([]byte(attrs), &values); err != nil {
+			return fmt.Errorf("failed to decode attributes: %w", err)
+		}
+		for k, v := range values {
+			t.SetPersistentAttr(k, v)
+		}
+	}
+
+	t.Session = zyn.NewSession()
+	if session == "" {
+		return nil
+	}
+	var messages []zyn.Message
+	if err := json.Unmarshal([]byte(session), &messages); err != nil {
+		return fmt.Errorf("failed to decode session: %w", err)
+	}
+	t.Session.SetMessages(messages)
+	return nil
+}
@@ -0,0 +1,131 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+func TestMemoryResume(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+
+	thought, err := NewWithTrace(ctx, mem, "support chat", "chat-42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought.SetContent(ctx, "message", "My invoice is wrong", "input")
+	thought.SetContent(ctx, "reply", "Let me check", "respond")
+	thought.MarkNotesPublishedUpTo(1)
+	thought.Session.Append(zyn.RoleUser, "My invoice is wrong")
+	thought.Session.Append(zyn.RoleAssistant, "Let me check")
+
+	if err := mem.UpdateThought(ctx, thought); err != nil {
+		t.Fatalf("UpdateThought failed: %v", err)
+	}
+
+	resumed, err := mem.Resume(ctx, "chat-42")
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if resumed == thought {
+		t.Fatal("expected a rebuilt thought")
+	}
+	if resumed.NoteCount() != 2 {
+		t.Errorf("expected 2 notes, got %d", resumed.NoteCount())
+	}
+	if resumed.PublishedCount() != 1 {
+		t.Errorf("expected publish count 1, got %d", resumed.PublishedCount())
+	}
+	messages := resumed.Session.Messages()
+	if len(messages) != 2 || messages[1].Content != "Let me check" {
+		t.Errorf("expected session restored, got %v", messages)
+	}
+
+	// The resumed thought accepts more steps
+	if err := resumed.SetContent(ctx, "message_2", "It's from March", "input"); err != nil {
+		t.Fatalf("expected resumed thought to persist notes, got %v", err)
+	}
+	if unpublished := resumed.GetUnpublishedNotes(); len(unpublished) != 2 {
+		t.Errorf("expected 2 unpublished notes, got %d", len(unpublished))
+	}
+
+	if _, err := mem.Resume(ctx, "missing"); err == nil {
+		t.Error("expected error for unknown trace")
+	}
+}
+
+func TestMemoryResumeAfterStep(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+
+	thought, err := NewWithTrace(ctx, mem, "support chat", "chat-43")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought.SetContent(ctx, "message", "Production database is down", "input")
+
+	// No UpdateThought call: the step persists its own state
+	result, err := NewDecide("is_urgent", "Is this urgent?").
+		WithProvider(&mockDecideProvider{}).
+		Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resumed, err := mem.Resume(ctx, "chat-43")
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if resumed.PublishedCount() != result.NoteCount() {
+		t.Errorf("expected publish count %d, got %d", result.NoteCount(), resumed.PublishedCount())
+	}
+	if resumed.Session.Len() != result.Session.Len() || resumed.Session.Len() == 0 {
+		t.Errorf("expected session of %d messages, got %d", result.Session.Len(), resumed.Session.Len())
+	}
+}
+
+func TestMemoryResumePersistentAttrs(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+
+	thought, err := NewWithTrace(ctx, mem, "support chat", "chat-44")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought.SetPersistentAttr("tenant", "acme")
+	thought.SetPersistentAttr("retries", 2)
+	thought.SetAttr("request_id", "req-1")
+	thought.SetPersistentAttr("locale", "fr")
+	thought.SetAttr("locale", "de") // SetAttr opts the key back out
+
+	if err := mem.UpdateThought(ctx, thought); err != nil {
+		t.Fatalf("UpdateThought failed: %v", err)
+	}
+
+	resumed, err := mem.Resume(ctx, "chat-44")
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if v, ok := resumed.GetAttr("tenant"); !ok || v != "acme" {
+		t.Errorf("expected tenant restored, got %v (%v)", v, ok)
+	}
+	if v, ok := resumed.GetAttr("retries"); !ok || v != float64(2) {
+		t.Errorf("expected retries restored as its JSON form, got %v (%v)", v, ok)
+	}
+	for _, key := range []string{"request_id", "locale"} {
+		if v, ok := resumed.GetAttr(key); ok {
+			t.Errorf("expected %s not to be persisted, got %v", key, v)
+		}
+	}
+
+	// Restored attributes stay persistent for the next save
+	if err := mem.UpdateThought(ctx, resumed); err != nil {
+		t.Fatalf("UpdateThought failed: %v", err)
+	}
+	again, _ := mem.Resume(ctx, "chat-44")
+	if v, ok := again.GetAttr("tenant"); !ok || v != "acme" {
+		t.Errorf("expected tenant to survive a second resume, got %v (%v)", v, ok)
+	}
+}
@@ -0,0 +1,40 @@
+package cogito
+
+// Scanner is implemented by primitives that expose typed results via Scan.
+type Scanner[R any] interface {
+	Scan(t *Thought) (R, error)
+}
+
+// ScanAll applies a primitive's Scan to each thought and collects results positionally.
+// results[i] and errs[i] correspond to thoughts[i]; errs[i] is nil when the scan succeeded.
+// The result type must be given explicitly since Go cannot infer it from a method set.
+//
+// Example:
+//
+//	decide := cogito.NewDecide("is_urgent", "Is this urgent?")
+//	// ... run decide over a batch of thoughts ...
+//	results, errs := cogito.ScanAll[*zyn.BinaryResponse](decide, thoughts)
+//	for i, resp := range results {
+//	    if errs[i] != nil {
+//	        continue
+//	    }
+//	    fmt.Println(resp.Decision)
+//	}
+func ScanAll[R any](scanner Scanner[R], thoughts []*Thought) ([]R, []error) {
+	results := make([]R, len(thoughts))
+	errs := make([]error, len(thoughts))
+	for i, t := range thoughts {
+		results[i], errs[i] = scanner.Scan(t)
+	}
+	return results, errs
+}
+
+// scanIfConfident runs scan and reports whether the response's confidence, read by
+// confidence, is at least min. It backs each primitive's ScanIfConfident.
+func scanIfConfident[R any](scan func(*Thought) (*R, error), confidence func(*R) float64, t *Thought, min float32) (*R, bool, error) {
+	resp, err := scan(t)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, float32(confidence(resp)) >= min, nil
+}
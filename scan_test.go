@@ -0,0 +1,38 @@
+package cogito
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+func TestScanAll(t *testing.T) {
+	provider := &mockDecideProvider{}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	step := NewDecide("is_urgent", "Is this urgent?")
+
+	processed := newTestThought("processed")
+	processed.SetContent(context.Background(), "input_text", "URGENT: System is down!", "initial")
+	if _, err := step.Process(context.Background(), processed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unprocessed := newTestThought("unprocessed")
+
+	results, errs := ScanAll[*zyn.BinaryResponse](step, []*Thought{processed, unprocessed})
+
+	if len(results) != 2 || len(errs) != 2 {
+		t.Fatalf("expected 2 results and errors, got %d and %d", len(results), len(errs))
+	}
+	if errs[0] != nil {
+		t.Errorf("expected no error for processed thought, got %v", errs[0])
+	}
+	if results[0] == nil || !results[0].Decision {
+		t.Error("expected decision true for processed thought")
+	}
+	if errs[1] == nil {
+		t.Error("expected error for unprocessed thought")
+	}
+}
@@ -0,0 +1,133 @@
+package cogito
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zoobzio/zyn"
+)
+
+// JSON Schema type names.
+const (
+	schemaTypeObject  = "object"
+	schemaTypeString  = "string"
+	schemaTypeInteger = "integer"
+	schemaTypeNumber  = "number"
+	schemaTypeBoolean = "boolean"
+	schemaTypeArray   = "array"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generateJSONSchema builds a JSON schema for T via reflection.
+// Field names follow encoding/json tags; fields without omitempty are required.
+func generateJSONSchema[T any]() (string, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	schema := buildTypeSchema(t, true, map[reflect.Type]bool{})
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to generate JSON schema: %w", err)
+	}
+	return string(data), nil
+}
+
+// buildTypeSchema constructs the schema for a Go type.
+// The visiting set guards against infinite recursion on self-referential types.
+func buildTypeSchema(t reflect.Type, isRoot bool, visiting map[reflect.Type]bool) *zyn.JSONSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &zyn.JSONSchema{Type: schemaTypeString}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &zyn.JSONSchema{Type: schemaTypeString}
+	case reflect.Bool:
+		return &zyn.JSONSchema{Type: schemaTypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &zyn.JSONSchema{Type: schemaTypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &zyn.JSONSchema{Type: schemaTypeNumber}
+	case reflect.Slice, reflect.Array:
+		// encoding/json renders []byte as a base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &zyn.JSONSchema{Type: schemaTypeString}
+		}
+		return &zyn.JSONSchema{
+			Type:  schemaTypeArray,
+			Items: buildTypeSchema(t.Elem(), false, visiting),
+		}
+	case reflect.Map:
+		return &zyn.JSONSchema{
+			Type:                 schemaTypeObject,
+			AdditionalProperties: buildTypeSchema(t.Elem(), false, visiting),
+		}
+	case reflect.Struct:
+		if visiting[t] {
+			return &zyn.JSONSchema{Type: schemaTypeObject}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &zyn.JSONSchema{
+			Type:                    schemaTypeObject,
+			Properties:              make(map[string]*zyn.JSONSchema),
+			DisallowAdditionalProps: isRoot,
+		}
+		addStructFields(schema, t, visiting)
+		return schema
+	default:
+		// Interfaces and other kinds accept any value
+		return &zyn.JSONSchema{}
+	}
+}
+
+// addStructFields adds the JSON-visible fields of a struct to the schema.
+// Untagged embedded structs are flattened, matching encoding/json.
+func addStructFields(schema *zyn.JSONSchema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(schema, embedded, visiting)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := buildTypeSchema(field.Type, false, visiting)
+		if desc := field.Tag.Get("desc"); desc != "" {
+			fieldSchema.Description = desc
+		}
+		schema.Properties[name] = fieldSchema
+
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cogito
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type schemaInner struct {
+	Label string `json:"label"`
+}
+
+type schemaBase struct {
+	ID string `json:"id"`
+}
+
+type schemaSample struct {
+	schemaBase
+	Name     string            `json:"name" desc:"Display name"`
+	Count    int               `json:"count"`
+	Score    float64           `json:"score,omitempty"`
+	Active   bool              `json:"active"`
+	Tags     []string          `json:"tags"`
+	Attrs    map[string]int    `json:"attrs"`
+	Inner    *schemaInner      `json:"inner"`
+	Items    []schemaInner     `json:"items"`
+	When     time.Time         `json:"when"`
+	Skipped  string            `json:"-"`
+	Extra    map[string]string `json:"extra,omitempty"`
+	internal string
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	raw, err := generateJSONSchema[schemaSample]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if schema["type"] != "object" {
+		t.Errorf("expected root type object, got %v", schema["type"])
+	}
+	if schema["additionalProperties"] != false {
+		t.Error("expected root to disallow additional properties")
+	}
+
+	props := schema["properties"].(map[string]any)
+	expected := map[string]string{
+		"id":     "string",
+		"name":   "string",
+		"count":  "integer",
+		"score":  "number",
+		"active": "boolean",
+		"tags":   "array",
+		"attrs":  "object",
+		"inner":  "object",
+		"items":  "array",
+		"when":   "string",
+	}
+	for name, typ := range expected {
+		prop, ok := props[name].(map[string]any)
+		if !ok {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if prop["type"] != typ {
+			t.Errorf("property %q: expected type %q, got %v", name, typ, prop["type"])
+		}
+	}
+
+	for _, name := range []string{"Skipped", "internal", "schemaBase"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("unexpected property %q", name)
+		}
+	}
+
+	if desc := props["name"].(map[string]any)["description"]; desc != "Display name" {
+		t.Errorf("expected description from desc tag, got %v", desc)
+	}
+
+	required := map[string]bool{}
+	for _, r := range schema["required"].([]any) {
+		required[r.(string)] = true
+	}
+	if required["score"] || required["extra"] {
+		t.Error("omitempty fields should not be required")
+	}
+	if !required["name"] || !required["id"] {
+		t.Error("expected name and id to be required")
+	}
+}
+
+type schemaNode struct {
+	Value    string        `json:"value"`
+	Children []*schemaNode `json:"children"`
+}
+
+func TestGenerateJSONSchemaRecursive(t *testing.T) {
+	if _, err := generateJSONSchema[schemaNode](); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -77,6 +77,7 @@ func (s *Seek) WithProvider(p Provider) *Seek {
 
 // Process executes the semantic search and synthesis.
 func (s *Seek) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	capitan.Emit(ctx, StepStarted,
@@ -102,8 +103,8 @@ func (s *Seek) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		return t, fmt.Errorf("seek: failed to embed query: %w", err)
 	}
 
-	// Search for similar notes
-	results, err := t.memory.SearchNotes(ctx, queryEmbedding, s.limit)
+	// Search for similar notes, skipping other models' vectors; their distances are meaningless
+	results, err := t.memory.SearchNotes(searchContext(ctx, embedder), queryEmbedding, s.limit)
 	if err != nil {
 		s.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("seek: search failed: %w", err)
@@ -129,7 +130,7 @@ func (s *Seek) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	// Synthesize results if we have any
 	var summary string
 	if len(results) > 0 {
-		provider, err := ResolveProvider(ctx, s.provider)
+		provider, err := ResolveProviderForStep(ctx, "seek", s.provider)
 		if err != nil {
 			s.emitFailed(ctx, t, start, err)
 			return t, fmt.Errorf("seek: %w", err)
@@ -144,6 +145,8 @@ func (s *Seek) Process(ctx context.Context, t *Thought) (*Thought, error) {
 			return t, fmt.Errorf("seek: failed to create transform synapse: %w", err)
 		}
 
+		emitContextRendered(ctx, t, s.key, "seek", contextBuilder.String(), len(results))
+
 		summary, err = transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 			Text:        contextBuilder.String(),
 			Context:     fmt.Sprintf("Query: %s\n\nSynthesize the relevant information from these search results.", s.query),
@@ -200,7 +203,11 @@ func (s *Seek) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (s *Seek) Schema() pipz.Node {
-	return pipz.Node{Identity: s.identity, Type: "seek"}
+	summaryKey := s.summaryKey
+	if summaryKey == "" {
+		summaryKey = s.key
+	}
+	return stepSchema(s.identity, "seek", false, nil, []string{summaryKey})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -209,7 +216,7 @@ func (s *Seek) Close() error {
 }
 
 func (s *Seek) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("seek"),
@@ -219,3 +226,12 @@ func (s *Seek) emitFailed(ctx context.Context, t *Thought, start time.Time, err
 }
 
 var _ pipz.Chainable[*Thought] = (*Seek)(nil)
+
+// searchContext restricts a memory search to the embedding space of embedder,
+// when it names its model.
+func searchContext(ctx context.Context, embedder Embedder) context.Context {
+	if model := EmbeddingModel(embedder); model != "" {
+		return WithEmbeddingSpace(ctx, model)
+	}
+	return ctx
+}
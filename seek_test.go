@@ -14,6 +14,7 @@ type mockSearchMemory struct {
 	*mockMemory
 	searchResults      []NoteWithThought
 	searchByTaskResult []*Thought
+	space              string // embedding space of the last search
 }
 
 func newMockSearchMemory() *mockSearchMemory {
@@ -22,11 +23,22 @@ func newMockSearchMemory() *mockSearchMemory {
 	}
 }
 
-func (m *mockSearchMemory) SearchNotes(_ context.Context, _ Vector, _ int) ([]NoteWithThought, error) {
-	return m.searchResults, nil
+func (m *mockSearchMemory) SearchNotes(ctx context.Context, _ Vector, _ int) ([]NoteWithThought, error) {
+	m.space = EmbeddingSpaceFrom(ctx)
+	if m.space == "" {
+		return m.searchResults, nil
+	}
+	var results []NoteWithThought
+	for _, r := range m.searchResults {
+		if r.Note.EmbeddingModel == "" || r.Note.EmbeddingModel == m.space {
+			results = append(results, r)
+		}
+	}
+	return results, nil
 }
 
-func (m *mockSearchMemory) SearchNotesByTask(_ context.Context, _ Vector, _ int) ([]*Thought, error) {
+func (m *mockSearchMemory) SearchNotesByTask(ctx context.Context, _ Vector, _ int) ([]*Thought, error) {
+	m.space = EmbeddingSpaceFrom(ctx)
 	return m.searchByTaskResult, nil
 }
 
@@ -142,6 +154,35 @@ func TestSeekProcess(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps to the query embedding model", func(t *testing.T) {
+		mem := newMockSearchMemory()
+		ctx := context.Background()
+		thought, _ := New(ctx, mem, "test seek")
+
+		mem.searchResults = []NoteWithThought{
+			{Note: Note{Key: "same", Content: "same model", EmbeddingModel: "prose-model"}, Thought: &Thought{}},
+			{Note: Note{Key: "other", Content: "other model", EmbeddingModel: "code-model"}, Thought: &Thought{}},
+			{Note: Note{Key: "unknown", Content: "no model recorded"}, Thought: &Thought{}},
+		}
+
+		embedder := &mockModelEmbedder{mockEmbedder{embedding: []float32{0.1}, dimensions: 1}, "prose-model"}
+		seek := NewSeek("context", "query").
+			WithEmbedder(embedder).
+			WithProvider(&mockTestProvider{})
+
+		if _, err := seek.Process(ctx, thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mem.space != "prose-model" {
+			t.Errorf("expected search restricted to prose-model, got %q", mem.space)
+		}
+		notes := seek.Scan().Notes
+		if len(notes) != 2 || notes[0].Note.Key != "same" || notes[1].Note.Key != "unknown" {
+			t.Errorf("expected [same unknown], got %v", notes)
+		}
+	})
+
 	t.Run("fails without embedder", func(t *testing.T) {
 		mem := newMockSearchMemory()
 		ctx := context.Background()
@@ -0,0 +1,81 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SentimentPoint is the assessed sentiment of one turn in a conversation.
+type SentimentPoint struct {
+	ThoughtID  string
+	TraceID    string
+	Time       time.Time // When the turn's thought was created
+	Overall    string    // positive, negative, neutral or mixed
+	Confidence float64
+	Score      float64 // Positive minus negative score, from -1 (negative) to 1 (positive)
+}
+
+// SentimentTrend assesses every turn of the conversation ending at leafThoughtID and
+// returns the results oldest-first, so callers can watch sentiment move across turns
+// rather than reacting to a single reading.
+//
+// Each turn is assessed with assess on the turn's own notes. A turn that already
+// holds a note under the assess key is read instead of assessed again, so repeated
+// calls only pay for new turns. The assessment note is persisted to each turn.
+//
+// Example:
+//
+//	trend, _ := cogito.SentimentTrend(ctx, memory, latest.ID, cogito.NewAssess("mood"))
+//	if cogito.SentimentSlope(trend) < 0 {
+//	    escalate(latest)
+//	}
+func SentimentTrend(ctx context.Context, memory Memory, leafThoughtID string, assess *Assess) ([]SentimentPoint, error) {
+	conversation, err := memory.GetConversation(ctx, leafThoughtID)
+	if err != nil {
+		return nil, fmt.Errorf("sentiment trend: %w", err)
+	}
+
+	points := make([]SentimentPoint, 0, len(conversation))
+	for _, turn := range conversation {
+		if _, ok := turn.GetNote(assess.key); !ok {
+			if _, err := assess.Process(ctx, turn); err != nil {
+				return nil, fmt.Errorf("sentiment trend: turn %s: %w", turn.ID, err)
+			}
+		}
+
+		resp, err := assess.Scan(turn)
+		if err != nil {
+			return nil, fmt.Errorf("sentiment trend: turn %s: %w", turn.ID, err)
+		}
+		points = append(points, SentimentPoint{
+			ThoughtID:  turn.ID,
+			TraceID:    turn.TraceID,
+			Time:       turn.CreatedAt,
+			Overall:    resp.Overall,
+			Confidence: resp.Confidence,
+			Score:      resp.Scores.Positive - resp.Scores.Negative,
+		})
+	}
+
+	return points, nil
+}
+
+// SentimentSlope returns the least-squares slope of Score per turn. A negative slope
+// means sentiment is trending negative. Fewer than two points yield 0.
+func SentimentSlope(points []SentimentPoint) float64 {
+	n := float64(len(points))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, p := range points {
+		x := float64(i)
+		sumX += x
+		sumY += p.Score
+		sumXY += x * p.Score
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
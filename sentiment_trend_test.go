@@ -0,0 +1,90 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+// mockTrendProvider scores each turn by the mood word in its context.
+type mockTrendProvider struct {
+	callCount int
+}
+
+func (m *mockTrendProvider) Call(_ context.Context, messages []zyn.Message, _ float32) (*zyn.ProviderResponse, error) {
+	m.callCount++
+	prompt := messages[len(messages)-1].Content
+
+	content := `{"overall": "neutral", "confidence": 0.8, "scores": {"positive": 0.3, "negative": 0.3, "neutral": 0.4}, "aspects": {}, "emotions": [], "reasoning": ["neutral"]}`
+	switch {
+	case strings.Contains(prompt, "delighted"):
+		content = `{"overall": "positive", "confidence": 0.9, "scores": {"positive": 0.9, "negative": 0.0, "neutral": 0.1}, "aspects": {}, "emotions": ["joy"], "reasoning": ["happy"]}`
+	case strings.Contains(prompt, "furious"):
+		content = `{"overall": "negative", "confidence": 0.9, "scores": {"positive": 0.0, "negative": 0.9, "neutral": 0.1}, "aspects": {}, "emotions": ["anger"], "reasoning": ["angry"]}`
+	}
+	return &zyn.ProviderResponse{Content: content}, nil
+}
+
+func (m *mockTrendProvider) Name() string {
+	return "mock-trend"
+}
+
+func TestSentimentTrend(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+
+	var parentID *string
+	var leaf *Thought
+	for _, message := range []string{"I am delighted", "Still waiting", "I am furious"} {
+		turn, err := New(ctx, mem, "support")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		turn.ParentID = parentID
+		turn.SetContent(ctx, "message", message, "user")
+		id := turn.ID
+		parentID = &id
+		leaf = turn
+	}
+
+	provider := &mockTrendProvider{}
+	assess := NewAssess("mood").WithProvider(provider)
+
+	trend, err := SentimentTrend(ctx, mem, leaf.ID, assess)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trend) != 3 {
+		t.Fatalf("expected 3 points, got %d", len(trend))
+	}
+
+	want := []string{"positive", "neutral", "negative"}
+	for i, p := range trend {
+		if p.Overall != want[i] {
+			t.Errorf("point %d: expected %q, got %q", i, want[i], p.Overall)
+		}
+	}
+	if trend[0].Score <= trend[2].Score {
+		t.Errorf("expected score to fall, got %v then %v", trend[0].Score, trend[2].Score)
+	}
+	if slope := SentimentSlope(trend); slope >= 0 {
+		t.Errorf("expected negative slope, got %v", slope)
+	}
+
+	// A second call reuses stored assessments.
+	calls := provider.callCount
+	if _, err := SentimentTrend(ctx, mem, leaf.ID, assess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.callCount != calls {
+		t.Errorf("expected no new provider calls, got %d", provider.callCount-calls)
+	}
+}
+
+func TestSentimentSlopeFewPoints(t *testing.T) {
+	if slope := SentimentSlope([]SentimentPoint{{Score: 0.5}}); slope != 0 {
+		t.Errorf("expected 0 for a single point, got %v", slope)
+	}
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // Sift is an LLM-powered conditional gate that implements pipz.Chainable[*Thought].
-// It uses semantic reasoning to decide whether to execute a wrapped processor or pass through unchanged.
+// It uses semantic reasoning to decide whether to execute wrapped processors or pass through unchanged.
 //
 // Unlike pipz.Filter which uses a programmatic condition, Sift uses an LLM to make the decision
 // based on semantic understanding of the context. This enables gates based on meaning rather than.
@@ -25,8 +25,15 @@ type Sift struct {
 
 	// Configuration
 	useIntrospection         bool
+	sessionIntrospection     bool
 	reasoningTemperature     float32
+	captureRaw               bool
+	sourceTag                string
+	locale                   string
+	contextStrategy          ContextStrategy
 	introspectionTemperature float32
+	introspectionStyle       string
+	reasoningPrompt          string
 	summaryKey               string
 	provider                 Provider
 	temperature              float32
@@ -34,11 +41,13 @@ type Sift struct {
 
 // NewSift creates a new semantic gate primitive.
 //
-// The primitive uses zyn.Binary to decide whether to execute the processor.
-// If the decision is true, the processor is executed. Otherwise, the thought passes through unchanged.
+// The primitive uses zyn.Binary to decide whether to execute the processors.
+// If the decision is true, the processors run in order. Otherwise, the thought passes through unchanged.
+// Multiple processors are composed into a Sequence named {key}_sequence.
 //
 // Output Notes:
 //   - {key}: JSON-serialized zyn.BinaryResponse (the gate decision)
+//   - {key}_raw: Raw provider response content (if raw capture enabled)
 //   - {key}_summary: Semantic summary for next steps (if introspection enabled)
 //
 // Example:
@@ -46,40 +55,56 @@ type Sift struct {
 //	gate := cogito.NewSift(
 //	    "escalation_gate",
 //	    "Does this ticket require human escalation?",
-//	    humanEscalationPipeline,
+//	    notifyOnCall,
+//	    openIncident,
 //	)
 //	result, _ := gate.Process(ctx, thought)
 //	resp, _ := gate.Scan(result)
 //	fmt.Println("Escalated:", resp.Decision)
-func NewSift(key, question string, processor pipz.Chainable[*Thought]) *Sift {
+func NewSift(key, question string, processors ...pipz.Chainable[*Thought]) *Sift {
 	return &Sift{
-		identity:         pipz.NewIdentity(key, "Semantic gate primitive"),
-		key:              key,
-		question:         question,
-		processor:        processor,
-		useIntrospection: DefaultIntrospection,
-		temperature:      DefaultReasoningTemperature,
+		identity:             pipz.NewIdentity(key, "Semantic gate primitive"),
+		key:                  key,
+		question:             question,
+		processor:            composeProcessors(key, processors),
+		useIntrospection:     DefaultIntrospection,
+		sessionIntrospection: DefaultSessionIntrospection,
+		temperature:          DefaultReasoningTemperature,
+	}
+}
+
+// composeProcessors returns the single processor as-is, or a Sequence running them in order.
+// Returns nil when no processors are given, leaving the gate as a pure decision step.
+func composeProcessors(key string, processors []pipz.Chainable[*Thought]) pipz.Chainable[*Thought] {
+	switch len(processors) {
+	case 0:
+		return nil
+	case 1:
+		return processors[0]
+	default:
+		return Sequence(pipz.NewIdentity(key+"_sequence", "Sift gated processors"), processors...)
 	}
 }
 
 // Process implements pipz.Chainable[*Thought].
 func (s *Sift) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	// Resolve provider
-	provider, err := ResolveProvider(ctx, s.provider)
+	provider, err := ResolveProviderForStep(ctx, "sift", s.provider)
 	if err != nil {
 		return t, fmt.Errorf("sift: %w", err)
 	}
 
 	// Create zyn binary synapse for gate decision
-	binarySynapse, err := zyn.Binary(s.question, provider)
+	binarySynapse, err := zyn.Binary(localize(promptOr(s.reasoningPrompt, s.question), s.locale), provider)
 	if err != nil {
 		return t, fmt.Errorf("sift: failed to create binary synapse: %w", err)
 	}
 
 	// Get unpublished notes
-	unpublished := t.GetUnpublishedNotes()
+	unpublished := s.contextStrategy.apply(t.GetUnpublishedNotes())
 	noteContext := RenderNotesToContext(unpublished)
 
 	// Emit step started
@@ -97,6 +122,8 @@ func (s *Sift) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		reasoningTemp = s.reasoningTemperature
 	}
 
+	emitContextRendered(ctx, t, s.key, "sift", noteContext, len(unpublished))
+
 	// PHASE 1: REASONING - Gate decision
 	binaryResponse, err := binarySynapse.FireWithInput(ctx, t.Session, zyn.BinaryInput{
 		Subject:     s.question,
@@ -114,10 +141,16 @@ func (s *Sift) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		s.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("sift: failed to marshal response: %w", err)
 	}
-	if setErr := t.SetContent(ctx, s.key, string(respJSON), "sift"); setErr != nil {
+	if setErr := t.SetNote(ctx, s.key, string(respJSON), noteSource("sift", s.sourceTag), contextKeysMetadata(unpublished)); setErr != nil {
 		s.emitFailed(ctx, t, start, setErr)
 		return t, fmt.Errorf("sift: failed to persist note: %w", setErr)
 	}
+	if s.captureRaw {
+		if setErr := captureRawResponse(ctx, t, "sift", s.key, s.sourceTag); setErr != nil {
+			s.emitFailed(ctx, t, start, setErr)
+			return t, setErr
+		}
+	}
 
 	// Emit gate decision
 	capitan.Emit(ctx, SiftDecided,
@@ -135,11 +168,14 @@ func (s *Sift) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		}
 	}
 
-	// Mark notes as published
-	t.MarkNotesPublished()
+	// Mark notes as published and persist the boundary for Resume
+	if err := t.publishNotes(ctx); err != nil {
+		s.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("sift: %w", err)
+	}
 
 	// PHASE 3: CONDITIONAL EXECUTION - Execute processor if gate opened
-	if binaryResponse.Decision {
+	if binaryResponse.Decision && s.processor != nil {
 		t, err = s.processor.Process(ctx, t)
 		if err != nil {
 			s.emitFailed(ctx, t, start, err)
@@ -154,7 +190,7 @@ func (s *Sift) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("sift"),
 		FieldStepDuration.Field(duration),
-		FieldNoteCount.Field(len(t.AllNotes())),
+		FieldNoteCount.Field(t.NoteCount()),
 	)
 
 	return t, nil
@@ -168,6 +204,10 @@ func (s *Sift) runIntrospection(ctx context.Context, t *Thought, resp zyn.Binary
 		summaryKey:               s.summaryKey,
 		introspectionTemperature: s.introspectionTemperature,
 		synapsePrompt:            "Synthesize gate decision into context for next reasoning step",
+		style:                    s.introspectionStyle,
+		sourceTag:                s.sourceTag,
+		locale:                   s.locale,
+		sessionContext:           s.sessionIntrospection,
 	})
 }
 
@@ -192,7 +232,7 @@ func (s *Sift) buildIntrospectionInput(resp zyn.BinaryResponse, originalNotes []
 
 // emitFailed emits a step failed event.
 func (s *Sift) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("sift"),
@@ -208,11 +248,15 @@ func (s *Sift) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (s *Sift) Schema() pipz.Node {
-	return pipz.Node{Identity: s.identity, Type: "sift"}
+	node := reasoningSchema(s.identity, "sift", nil, s.key, s.summaryKey, s.useIntrospection, s.captureRaw)
+	if s.processor != nil {
+		node.Flow = pipz.FilterFlow{Processor: s.processor.Schema()}
+	}
+	return node
 }
 
 // Close implements pipz.Chainable[*Thought].
-// Propagates Close to the wrapped processor.
+// Propagates Close to the wrapped processors.
 func (s *Sift) Close() error {
 	if s.processor != nil {
 		return s.processor.Close()
@@ -233,6 +277,12 @@ func (s *Sift) Scan(t *Thought) (*zyn.BinaryResponse, error) {
 	return &resp, nil
 }
 
+// ScanIfConfident retrieves the typed response and reports whether its confidence
+// is at least min. The response is returned either way so callers can log or fall back.
+func (s *Sift) ScanIfConfident(t *Thought, min float32) (*zyn.BinaryResponse, bool, error) {
+	return scanIfConfident(s.Scan, func(r *zyn.BinaryResponse) float64 { return r.Confidence }, t, min)
+}
+
 // Builder methods
 
 // WithProvider sets the provider for this step.
@@ -253,6 +303,12 @@ func (s *Sift) WithIntrospection() *Sift {
 	return s
 }
 
+// WithSessionIntrospection sends introspection only the gate decision; see DefaultSessionIntrospection.
+func (s *Sift) WithSessionIntrospection() *Sift {
+	s.sessionIntrospection = true
+	return s
+}
+
 // WithSummaryKey sets a custom key for the introspection summary note.
 func (s *Sift) WithSummaryKey(key string) *Sift {
 	s.summaryKey = key
@@ -271,8 +327,49 @@ func (s *Sift) WithIntrospectionTemperature(temp float32) *Sift {
 	return s
 }
 
-// SetProcessor updates the wrapped processor.
-func (s *Sift) SetProcessor(processor pipz.Chainable[*Thought]) *Sift {
-	s.processor = processor
+// WithIntrospectionStyle overrides the style guidance given to the introspection synapse.
+func (s *Sift) WithIntrospectionStyle(style string) *Sift {
+	s.introspectionStyle = style
+	return s
+}
+
+// WithRawCapture stores the provider's raw response content as a {key}_raw note,
+// giving an auditable record of exactly what the model returned before parsing.
+func (s *Sift) WithRawCapture() *Sift {
+	s.captureRaw = true
+	return s
+}
+
+// WithSourceTag qualifies the source of notes written by this step as "sift:<tag>",
+// distinguishing notes from multiple Sift steps in the same pipeline.
+func (s *Sift) WithSourceTag(tag string) *Sift {
+	s.sourceTag = tag
+	return s
+}
+
+// WithLocale asks for reasoning and introspection summaries in the given language,
+// such as "French" or "pt-BR". Categories, items and JSON field names stay as given,
+// so routing and Scan are unaffected.
+func (s *Sift) WithLocale(lang string) *Sift {
+	s.locale = lang
+	return s
+}
+
+// WithContextStrategy sets how unpublished notes are selected before they are
+// rendered into the prompt, for example PreferSummaries.
+func (s *Sift) WithContextStrategy(strategy ContextStrategy) *Sift {
+	s.contextStrategy = strategy
+	return s
+}
+
+// WithReasoningPrompt overrides the task prompt of the reasoning synapse.
+func (s *Sift) WithReasoningPrompt(prompt string) *Sift {
+	s.reasoningPrompt = prompt
+	return s
+}
+
+// SetProcessors replaces the wrapped processors, which run in order when the gate opens.
+func (s *Sift) SetProcessors(processors ...pipz.Chainable[*Thought]) *Sift {
+	s.processor = composeProcessors(s.key, processors)
 	return s
 }
@@ -285,7 +285,7 @@ func TestSiftBuilderMethods(t *testing.T) {
 	}
 }
 
-func TestSiftSetProcessor(t *testing.T) {
+func TestSiftSetProcessors(t *testing.T) {
 	provider := &mockSiftProvider{decisionValue: true}
 	SetProvider(provider)
 	defer SetProvider(nil)
@@ -294,7 +294,7 @@ func TestSiftSetProcessor(t *testing.T) {
 	newProcessor := newMockProcessor("new-processor")
 
 	sift := NewSift("escalation_gate", "Does this require escalation?", originalProcessor)
-	sift.SetProcessor(newProcessor)
+	sift.SetProcessors(newProcessor)
 
 	thought := newTestThought("test sift set processor")
 	thought.SetContent(context.Background(), "ticket", "URGENT: Production is down!", "initial")
@@ -333,3 +333,40 @@ func TestSiftClose(t *testing.T) {
 		t.Errorf("unexpected error on close: %v", err)
 	}
 }
+
+func TestSiftMultipleProcessors(t *testing.T) {
+	provider := &mockSiftProvider{decisionValue: true}
+	SetProvider(provider)
+	defer SetProvider(nil)
+
+	var order []string
+	step := func(name string) pipz.Chainable[*Thought] {
+		return Transform(pipz.NewIdentity(name, "Records execution order"), func(_ context.Context, th *Thought) *Thought {
+			order = append(order, name)
+			return th
+		})
+	}
+
+	sift := NewSift("escalation_gate", "Does this require escalation?", step("notify"), step("open_incident"), step("page"))
+
+	thought := newTestThought("test sift multiple processors")
+	thought.SetContent(context.Background(), "ticket", "URGENT: Production is down!", "initial")
+
+	if _, err := sift.Process(context.Background(), thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(order, ",") != "notify,open_incident,page" {
+		t.Errorf("expected processors to run in order, got %v", order)
+	}
+
+	// No processors: gate records its decision and passes through
+	order = nil
+	sift.SetProcessors()
+	if _, err := sift.Process(context.Background(), newTestThought("no processors")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(order) != 0 {
+		t.Errorf("expected no processors to run, got %v", order)
+	}
+}
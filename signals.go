@@ -24,6 +24,16 @@ var (
 		"cogito.step.failed",
 		"Reasoning step encountered an error",
 	)
+	StepSkipped = capitan.NewSignal(
+		"cogito.step.skipped",
+		"Conditional step skipped because its predicate was false",
+	)
+
+	// Context signals.
+	ContextRendered = capitan.NewSignal(
+		"cogito.context.rendered",
+		"Context rendered for a provider call",
+	)
 
 	// Note management signals.
 	NoteAdded = capitan.NewSignal(
@@ -35,12 +45,28 @@ var (
 		"Notes marked as published to LLM",
 	)
 
+	// Embedding signals (for AsyncEmbedder).
+	EmbeddingGenerated = capitan.NewSignal(
+		"cogito.embedding.generated",
+		"Background embedding stored for a persisted note",
+	)
+	EmbeddingFailed = capitan.NewSignal(
+		"cogito.embedding.failed",
+		"Background embedding could not be generated or stored",
+	)
+
 	// Introspection signals.
 	IntrospectionCompleted = capitan.NewSignal(
 		"cogito.introspection.completed",
 		"Transform synapse completed semantic summary",
 	)
 
+	// Analyze signals.
+	AnalyzeValidationFailed = capitan.NewSignal(
+		"cogito.analyze.validation.failed",
+		"Extracted data failed validation and was kept as partial data",
+	)
+
 	// Sift signals.
 	SiftDecided = capitan.NewSignal(
 		"cogito.sift.decided",
@@ -56,6 +82,10 @@ var (
 		"cogito.amplify.completed",
 		"Refinement met completion criteria",
 	)
+	AmplifyConverged = capitan.NewSignal(
+		"cogito.amplify.converged",
+		"Refinement stopped because content stopped changing",
+	)
 
 	// Converge signals.
 	ConvergeBranchStarted = capitan.NewSignal(
@@ -70,6 +100,14 @@ var (
 		"cogito.converge.synthesis.started",
 		"Synthesis phase began",
 	)
+	ConvergeNoteMergeSkipped = capitan.NewSignal(
+		"cogito.converge.note_merge.skipped",
+		"Branch note could not be merged and was skipped",
+	)
+	ConvergeSynthesisFallback = capitan.NewSignal(
+		"cogito.converge.synthesis.fallback",
+		"Synthesis failed and the fallback reducer produced the result",
+	)
 
 	// Seek signals.
 	SeekResultsFound = capitan.NewSignal(
@@ -105,7 +143,7 @@ var (
 	// Context metrics.
 	FieldUnpublishedCount = capitan.NewIntKey("unpublished_count")
 	FieldPublishedCount   = capitan.NewIntKey("published_count")
-	FieldContextSize      = capitan.NewIntKey("context_size") // character count
+	FieldContextSize      = capitan.NewIntKey("context_size") // character (rune) count
 
 	// Timing.
 	FieldStepDuration = capitan.NewDurationKey("step_duration")
@@ -113,6 +151,9 @@ var (
 	// Error information.
 	FieldError = capitan.NewErrorKey("error")
 
+	// Skip information (for Filter, Mutate, Gate, EffectWhen).
+	FieldReason = capitan.NewStringKey("reason")
+
 	// Decision metadata (for Sift, Amplify).
 	FieldDecision   = capitan.NewBoolKey("decision")
 	FieldConfidence = capitan.NewFloat64Key("confidence")
@@ -124,6 +165,9 @@ var (
 	FieldBranchCount = capitan.NewIntKey("branch_count")
 	FieldBranchName  = capitan.NewStringKey("branch_name")
 
+	// Batch metadata (for MapAnalyze).
+	FieldItemCount = capitan.NewIntKey("item_count")
+
 	// Search metadata (for Seek, Survey).
 	FieldSearchQuery = capitan.NewStringKey("search_query")
 	FieldResultCount = capitan.NewIntKey("result_count")
@@ -2,12 +2,16 @@ package cogito
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/zoobzio/capitan"
 	capitantesting "github.com/zoobzio/capitan/testing"
+	"github.com/zoobzio/pipz"
 	"github.com/zoobzio/zyn"
 )
 
@@ -166,6 +170,54 @@ func TestNotesPublishedEvent(t *testing.T) {
 	}
 }
 
+// TestStepSkippedEvent verifies StepSkipped signal emission from conditional wrappers.
+func TestStepSkippedEvent(t *testing.T) {
+	capture := capitantesting.NewEventCapture()
+	listener := capitan.Hook(StepSkipped, capture.Handler())
+	defer listener.Close()
+
+	thought := newTestThought("test skipped")
+	ctx := context.Background()
+	never := func(context.Context, *Thought) bool { return false }
+	always := func(context.Context, *Thought) bool { return true }
+	noop := Transform(pipz.NewIdentity("noop", "No-op"), func(_ context.Context, th *Thought) *Thought { return th })
+
+	_, _ = Filter(pipz.NewIdentity("skip-filter", "Skipped filter"), never, noop).Process(ctx, thought)
+	_, _ = Mutate(pipz.NewIdentity("skip-mutate", "Skipped mutate"), func(_ context.Context, th *Thought) *Thought { return th }, never).Process(ctx, thought)
+	_, _ = Gate(pipz.NewIdentity("skip-gate", "Skipped gate"), never).Process(ctx, thought)
+	_, _ = Filter(pipz.NewIdentity("run-filter", "Executed filter"), always, noop).Process(ctx, thought)
+
+	if !capture.WaitForCount(3, time.Second) {
+		t.Fatalf("expected 3 StepSkipped events, got %d", len(capture.Events()))
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	events := capture.Events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 StepSkipped events, got %d", len(events))
+	}
+
+	expected := []struct{ name, stepType string }{
+		{"skip-filter", "filter"},
+		{"skip-mutate", "mutate"},
+		{"skip-gate", "gate"},
+	}
+	for i, want := range expected {
+		if got := getStringField(events[i], FieldStepName.Name()); got != want.name {
+			t.Errorf("event %d: expected step_name %q, got %q", i, want.name, got)
+		}
+		if got := getStringField(events[i], FieldStepType.Name()); got != want.stepType {
+			t.Errorf("event %d: expected step_type %q, got %q", i, want.stepType, got)
+		}
+		if got := getStringField(events[i], FieldTraceID.Name()); got != thought.TraceID {
+			t.Errorf("event %d: expected trace_id %q, got %q", i, thought.TraceID, got)
+		}
+		if got := getStringField(events[i], FieldReason.Name()); got == "" {
+			t.Errorf("event %d: expected reason to be set", i)
+		}
+	}
+}
+
 // mockTestProvider implements Provider interface for signal tests.
 type mockTestProvider struct {
 	callCount int
@@ -342,6 +394,68 @@ func TestIntrospectionEvents(t *testing.T) {
 	}
 }
 
+// TestContextRenderedEvent verifies the rendered context size is reported before the provider call.
+func TestContextRenderedEvent(t *testing.T) {
+	type renderData struct {
+		contextSize int
+		noteCount   int
+		name        string
+	}
+
+	var mu sync.Mutex
+	var rendered *renderData
+
+	listener := capitan.Hook(ContextRendered, func(_ context.Context, e *capitan.Event) {
+		size, _ := FieldContextSize.From(e)
+		count, _ := FieldNoteCount.From(e)
+		name, _ := FieldStepName.From(e)
+		mu.Lock()
+		rendered = &renderData{size, count, name}
+		mu.Unlock()
+	})
+	defer listener.Close()
+
+	provider := &mockTestProvider{}
+	thought := newTestThought("test context size")
+	thought.SetContent(context.Background(), "input", "test input", "test")
+	thought.SetContent(context.Background(), "extra", "Grüße, 世界", "test")
+	// Characters, not bytes
+	expected := utf8.RuneCountInString(RenderNotesToContext(thought.GetUnpublishedNotes()))
+
+	step := NewDecide("sized_decision", "Is this a test?").WithProvider(provider)
+	if _, err := step.Process(context.Background(), thought); err != nil {
+		t.Fatalf("step execution failed: %v", err)
+	}
+
+	// Wait for event.
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		got := rendered != nil
+		mu.Unlock()
+		if got || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if rendered == nil {
+		t.Fatal("expected ContextRendered event")
+	}
+	if rendered.name != "sized_decision" {
+		t.Errorf("expected step_name 'sized_decision', got %q", rendered.name)
+	}
+	if rendered.contextSize != expected {
+		t.Errorf("expected context_size %d, got %d", expected, rendered.contextSize)
+	}
+	if rendered.noteCount != 2 {
+		t.Errorf("expected note_count 2, got %d", rendered.noteCount)
+	}
+}
+
 // mockFailingProvider implements Provider interface that always fails.
 type mockFailingProvider struct{}
 
@@ -405,6 +519,72 @@ func TestStepFailedEvent(t *testing.T) {
 	}
 }
 
+// mockIntrospectionFailingProvider answers reasoning calls and fails introspection calls.
+type mockIntrospectionFailingProvider struct {
+	mockDecideProvider
+}
+
+func (m *mockIntrospectionFailingProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	if strings.Contains(messages[len(messages)-1].Content, "Transform:") {
+		return nil, errors.New("summary model unavailable")
+	}
+	return m.mockDecideProvider.Call(ctx, messages, temperature)
+}
+
+// TestStepFailedSeverityByPhase verifies introspection failures are reported at Warn.
+func TestStepFailedSeverityByPhase(t *testing.T) {
+	var mu sync.Mutex
+	var severities []capitan.Severity
+
+	listener := capitan.Hook(StepFailed, func(_ context.Context, e *capitan.Event) {
+		mu.Lock()
+		severities = append(severities, e.Severity())
+		mu.Unlock()
+	})
+	defer listener.Close()
+
+	step := NewDecide("test_decision", "Is this urgent?").
+		WithProvider(&mockIntrospectionFailingProvider{}).
+		WithIntrospection()
+	result, err := step.Process(context.Background(), newTestThought("test introspection failure"))
+	if !errors.Is(err, ErrIntrospectionFailed) {
+		t.Fatalf("expected ErrIntrospectionFailed, got %v", err)
+	}
+	if _, scanErr := step.Scan(result); scanErr != nil {
+		t.Errorf("expected decision note to survive introspection failure: %v", scanErr)
+	}
+
+	_, err = NewDecide("test_decision", "Will this fail?").
+		WithProvider(&mockFailingProvider{}).
+		Process(context.Background(), newTestThought("test reasoning failure"))
+	if err == nil || errors.Is(err, ErrIntrospectionFailed) {
+		t.Fatalf("expected reasoning failure, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(severities)
+		mu.Unlock()
+		if n >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(severities) != 2 {
+		t.Fatalf("expected 2 StepFailed events, got %d", len(severities))
+	}
+	if severities[0] != capitan.SeverityWarn {
+		t.Errorf("expected introspection failure at Warn, got %v", severities[0])
+	}
+	if severities[1] != capitan.SeverityError {
+		t.Errorf("expected reasoning failure at Error, got %v", severities[1])
+	}
+}
+
 // TestEventTraceIDCorrelation verifies all events for a thought share the same trace ID.
 func TestEventTraceIDCorrelation(t *testing.T) {
 	var mu sync.Mutex
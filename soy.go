@@ -2,7 +2,9 @@ package cogito
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -11,11 +13,36 @@ import (
 	"github.com/zoobzio/zyn"
 )
 
+// ErrMemoryClosed is returned by SoyMemory writes made after Close.
+var ErrMemoryClosed = errors.New("memory closed")
+
 // SoyMemory implements Memory using soy for persistence.
 type SoyMemory struct {
 	thoughts *soy.Soy[Thought]
+	states   *soy.Soy[thoughtState]
 	notes    *soy.Soy[Note]
 	db       *sqlx.DB
+
+	noteWriteTimeout time.Duration
+
+	pending pendingWork  // notes queued on an AsyncEmbedder
+	mu      sync.RWMutex // held for reading by in-flight writes
+	closed  bool
+}
+
+// DefaultNoteWriteTimeout bounds a SoyMemory note insert, which outlives the
+// cancellation of its caller's context.
+const DefaultNoteWriteTimeout = 30 * time.Second
+
+// thoughtState is the resumable state of a thought, stored in columns of the
+// thoughts table that the Thought struct does not map.
+type thoughtState struct {
+	ID             string    `db:"id" type:"uuid" constraints:"primarykey"`
+	ParentID       *string   `db:"parent_id" type:"uuid" references:"thoughts(id)"`
+	PublishedCount int       `db:"published_count" type:"integer" constraints:"notnull" default:"0"`
+	Session        string    `db:"session" type:"jsonb" default:"'[]'"`
+	Attrs          string    `db:"attrs" type:"jsonb" default:"'{}'"`
+	UpdatedAt      time.Time `db:"updated_at" type:"timestamp" constraints:"notnull"`
 }
 
 // NewSoyMemory creates a new soy-backed Memory implementation.
@@ -27,20 +54,43 @@ func NewSoyMemory(db *sqlx.DB) (*SoyMemory, error) {
 		return nil, fmt.Errorf("failed to initialize thoughts table: %w", err)
 	}
 
+	states, err := soy.New[thoughtState](db, "thoughts", renderer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize thought state: %w", err)
+	}
+
 	notes, err := soy.New[Note](db, "notes", renderer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize notes table: %w", err)
 	}
 
 	return &SoyMemory{
-		thoughts: thoughts,
-		notes:    notes,
-		db:       db,
+		thoughts:         thoughts,
+		states:           states,
+		notes:            notes,
+		db:               db,
+		noteWriteTimeout: DefaultNoteWriteTimeout,
 	}, nil
 }
 
+// WithNoteWriteTimeout sets how long AddNote may wait for an insert once it has
+// been detached from the caller's context. A non-positive d restores
+// DefaultNoteWriteTimeout. Call it before the memory is shared.
+func (m *SoyMemory) WithNoteWriteTimeout(d time.Duration) *SoyMemory {
+	if d <= 0 {
+		d = DefaultNoteWriteTimeout
+	}
+	m.noteWriteTimeout = d
+	return m
+}
+
 // CreateThought persists a new thought and returns it with ID populated.
 func (m *SoyMemory) CreateThought(ctx context.Context, thought *Thought) (*Thought, error) {
+	if err := m.beginWrite(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
 	inserted, err := m.thoughts.Insert().Exec(ctx, thought)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert thought: %w", err)
@@ -53,6 +103,9 @@ func (m *SoyMemory) GetThought(ctx context.Context, id string) (*Thought, error)
 	thought, err := m.thoughts.Select().
 		Where("id", "=", "id").
 		Exec(ctx, map[string]any{"id": id})
+	if errors.Is(err, soy.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get thought: %w", err)
 	}
@@ -70,6 +123,9 @@ func (m *SoyMemory) GetThoughtByTraceID(ctx context.Context, traceID string) (*T
 	thought, err := m.thoughts.Select().
 		Where("trace_id", "=", "trace_id").
 		Exec(ctx, map[string]any{"trace_id": traceID})
+	if errors.Is(err, soy.ErrNotFound) {
+		return nil, fmt.Errorf("%w for trace: %s", ErrNotFound, traceID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get thought by trace ID: %w", err)
 	}
@@ -102,6 +158,81 @@ func (m *SoyMemory) GetThoughtsByTaskID(ctx context.Context, taskID string) ([]*
 	return thoughts, nil
 }
 
+// GetThoughtsByIntent loads thoughts whose intent exactly matches intent, ordered by
+// creation time, skipping offset thoughts and returning at most limit (all when limit <= 0).
+func (m *SoyMemory) GetThoughtsByIntent(ctx context.Context, intent string, limit, offset int) ([]*Thought, error) {
+	return m.queryThoughtsByIntent(ctx, "=", intent, limit, offset)
+}
+
+// SearchThoughtsByIntent loads thoughts whose intent matches pattern case-insensitively
+// using SQL ILIKE, so "%refund%" finds "process_refund" and "Refund review". Ordering
+// and pagination follow GetThoughtsByIntent.
+func (m *SoyMemory) SearchThoughtsByIntent(ctx context.Context, pattern string, limit, offset int) ([]*Thought, error) {
+	return m.queryThoughtsByIntent(ctx, "ILIKE", pattern, limit, offset)
+}
+
+// queryThoughtsByIntent runs a paginated intent query with the given operator.
+func (m *SoyMemory) queryThoughtsByIntent(ctx context.Context, operator, intent string, limit, offset int) ([]*Thought, error) {
+	query := m.thoughts.Query().
+		Where("intent", operator, "intent").
+		OrderBy("created_at", "asc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	thoughts, err := query.Exec(ctx, map[string]any{"intent": intent})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thoughts by intent: %w", err)
+	}
+
+	// Hydrate each thought
+	for _, thought := range thoughts {
+		if err := m.hydrateThought(ctx, thought); err != nil {
+			return nil, err
+		}
+	}
+
+	return thoughts, nil
+}
+
+// GetThoughtsByDateRange loads thoughts created in [from, to), ordered by creation
+// time, skipping offset thoughts and returning at most limit (all when limit <= 0).
+// Under WithoutNoteHydration the notes query is skipped for each thought.
+func (m *SoyMemory) GetThoughtsByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*Thought, error) {
+	query := m.thoughts.Query().
+		Where("created_at", ">=", "from").
+		Where("created_at", "<", "to").
+		OrderBy("created_at", "asc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	thoughts, err := query.Exec(ctx, map[string]any{"from": from, "to": to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thoughts by date range: %w", err)
+	}
+
+	skipNotes := NoteHydrationSkipped(ctx)
+	for _, thought := range thoughts {
+		if skipNotes {
+			thought.SetMemory(m)
+			thought.Session = zyn.NewSession()
+			continue
+		}
+		if err := m.hydrateThought(ctx, thought); err != nil {
+			return nil, err
+		}
+	}
+
+	return thoughts, nil
+}
+
 // GetChildThoughts loads all thoughts that have the given thought as parent.
 func (m *SoyMemory) GetChildThoughts(ctx context.Context, parentID string) ([]*Thought, error) {
 	thoughts, err := m.thoughts.Query().
@@ -122,20 +253,126 @@ func (m *SoyMemory) GetChildThoughts(ctx context.Context, parentID string) ([]*T
 	return thoughts, nil
 }
 
-// AddNote persists a note and returns it with ID populated.
+// conversationQuery walks ParentID links from a leaf thought up to the root in one
+// round trip, oldest first. soy has no recursive queries, so it is plain SQL. A
+// thought already on the path is returned with cycle set, and the walk stops there.
+const conversationQuery = `WITH RECURSIVE chain AS (
+	SELECT id, intent, trace_id, parent_id, task_id, created_at, updated_at,
+		0 AS depth, ARRAY[id] AS path, false AS cycle
+	FROM thoughts
+	WHERE id = $1
+	UNION ALL
+	SELECT t.id, t.intent, t.trace_id, t.parent_id, t.task_id, t.created_at, t.updated_at,
+		c.depth + 1, c.path || t.id, t.id = ANY(c.path)
+	FROM thoughts t
+	JOIN chain c ON t.id = c.parent_id
+	WHERE NOT c.cycle
+)
+SELECT id, intent, trace_id, parent_id, task_id, created_at, updated_at, cycle
+FROM chain
+ORDER BY depth DESC`
+
+// conversationRow is one thought of a conversation as conversationQuery returns it.
+type conversationRow struct {
+	ID        string    `db:"id"`
+	Intent    string    `db:"intent"`
+	TraceID   string    `db:"trace_id"`
+	ParentID  *string   `db:"parent_id"`
+	TaskID    *string   `db:"task_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Cycle     bool      `db:"cycle"`
+}
+
+// GetConversation loads the chain of thoughts from the root down to leafThoughtID
+// by following ParentID, returning them oldest-first with notes hydrated.
+// The chain is read with one recursive query and its notes with one more.
+func (m *SoyMemory) GetConversation(ctx context.Context, leafThoughtID string) ([]*Thought, error) {
+	var rows []conversationRow
+	if err := m.db.SelectContext(ctx, &rows, conversationQuery, leafThoughtID); err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, leafThoughtID)
+	}
+	// The deepest row comes first: a repeated thought, or else the root
+	root := rows[0]
+	if root.Cycle {
+		return nil, fmt.Errorf("conversation cycle detected at thought %s", root.ID)
+	}
+	if root.ParentID != nil {
+		return nil, fmt.Errorf("failed to get conversation: parent %s of thought %s: %w", *root.ParentID, root.ID, ErrNotFound)
+	}
+
+	chain := make([]*Thought, 0, len(rows))
+	for _, row := range rows {
+		chain = append(chain, &Thought{
+			ID:        row.ID,
+			Intent:    row.Intent,
+			TraceID:   row.TraceID,
+			ParentID:  row.ParentID,
+			TaskID:    row.TaskID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+
+	ids := make([]string, len(chain))
+	for i, thought := range chain {
+		ids[i] = thought.ID
+	}
+
+	notes, err := m.notes.Query().
+		Where("thought_id", "IN", "ids").
+		OrderBy("created", "asc").
+		OrderBy("seq", "asc").
+		Exec(ctx, map[string]any{"ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation notes: %w", err)
+	}
+
+	byThought := make(map[string][]Note, len(chain))
+	for _, note := range notes {
+		byThought[note.ThoughtID] = append(byThought[note.ThoughtID], *note)
+	}
+
+	for _, thought := range chain {
+		thought.SetMemory(m)
+		thought.Session = zyn.NewSession()
+		for _, note := range byThought[thought.ID] {
+			thought.AddNoteWithoutPersist(note)
+		}
+	}
+
+	return chain, nil
+}
+
+// AddNote persists a note and returns it with ID populated. Cancelling ctx does
+// not abort the insert, so a note being written when a process shuts down is kept.
+// The insert is still bounded by the memory's note write timeout, so a stalled
+// database cannot hold up shutdown indefinitely.
 func (m *SoyMemory) AddNote(ctx context.Context, note *Note) (*Note, error) {
-	inserted, err := m.notes.Insert().Exec(ctx, note)
+	if err := m.beginWrite(); err != nil {
+		return nil, err
+	}
+	defer m.mu.RUnlock()
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.noteWriteTimeout)
+	defer cancel()
+
+	inserted, err := m.notes.Insert().Exec(writeCtx, note)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert note: %w", err)
 	}
 	return inserted, nil
 }
 
-// GetNotes loads all notes for a thought.
+// GetNotes loads all notes for a thought, ordered by creation time and then Seq.
 func (m *SoyMemory) GetNotes(ctx context.Context, thoughtID string) ([]Note, error) {
 	notePtrs, err := m.notes.Query().
 		Where("thought_id", "=", "thought_id").
 		OrderBy("created", "asc").
+		OrderBy("seq", "asc").
 		Exec(ctx, map[string]any{"thought_id": thoughtID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notes: %w", err)
@@ -149,14 +386,68 @@ func (m *SoyMemory) GetNotes(ctx context.Context, thoughtID string) ([]Note, err
 	return notes, nil
 }
 
-// UpdateThought updates thought metadata (timestamps, publishedCount).
+// GetNotesBySourceSince loads the notes of a thought written by source after the
+// (since, afterID) cursor, ordered by creation time and then ID, for incremental pulls.
+func (m *SoyMemory) GetNotesBySourceSince(ctx context.Context, thoughtID, source string, since time.Time, afterID string) ([]Note, error) {
+	query := m.notes.Query().
+		Where("thought_id", "=", "thought_id").
+		Where("source", "=", "source")
+	params := map[string]any{"thought_id": thoughtID, "source": source, "since": since}
+	if afterID == "" {
+		query = query.Where("created", ">", "since")
+	} else {
+		// (created, id) > (since, afterID), spelled without a nested group
+		query = query.
+			Where("created", ">=", "since").
+			WhereOr(soy.C("created", ">", "since"), soy.C("id", ">", "after_id"))
+		params["after_id"] = afterID
+	}
+	notePtrs, err := query.
+		OrderBy("created", "asc").
+		OrderBy("id", "asc").
+		Exec(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes by source: %w", err)
+	}
+
+	notes := make([]Note, len(notePtrs))
+	for i, n := range notePtrs {
+		notes[i] = *n
+	}
+	return notes, nil
+}
+
+// UpdateThought persists the thought's updated_at, parent, publish count, session and
+// persistent attributes.
 func (m *SoyMemory) UpdateThought(ctx context.Context, thought *Thought) error {
-	_, err := m.thoughts.Modify().
+	if err := m.beginWrite(); err != nil {
+		return err
+	}
+	defer m.mu.RUnlock()
+
+	session, err := encodeSession(thought.Session)
+	if err != nil {
+		return fmt.Errorf("failed to update thought: %w", err)
+	}
+	attrs, err := encodeAttrs(thought)
+	if err != nil {
+		return fmt.Errorf("failed to update thought: %w", err)
+	}
+
+	_, err = m.states.Modify().
 		Set("updated_at", "updated_at").
+		Set("parent_id", "parent_id").
+		Set("published_count", "published_count").
+		Set("session", "session").
+		Set("attrs", "attrs").
 		Where("id", "=", "id").
 		Exec(ctx, map[string]any{
-			"updated_at": time.Now(),
-			"id":         thought.ID,
+			"updated_at":      time.Now(),
+			"parent_id":       thought.ParentID,
+			"published_count": thought.PublishedCount(),
+			"session":         session,
+			"attrs":           attrs,
+			"id":              thought.ID,
 		})
 	if err != nil {
 		return fmt.Errorf("failed to update thought: %w", err)
@@ -164,8 +455,35 @@ func (m *SoyMemory) UpdateThought(ctx context.Context, thought *Thought) error {
 	return nil
 }
 
+// Resume loads a thought by trace ID with its notes, and restores the publish
+// count, session and persistent attributes saved by the last completed step or
+// UpdateThought.
+func (m *SoyMemory) Resume(ctx context.Context, traceID string) (*Thought, error) {
+	thought, err := m.GetThoughtByTraceID(ctx, traceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume thought: %w", err)
+	}
+
+	state, err := m.states.Select().
+		Where("id", "=", "id").
+		Exec(ctx, map[string]any{"id": thought.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load thought state: %w", err)
+	}
+
+	if err := restoreState(thought, state.PublishedCount, state.Session, state.Attrs); err != nil {
+		return nil, fmt.Errorf("failed to resume thought: %w", err)
+	}
+	return thought, nil
+}
+
 // DeleteThought removes a thought and all its notes.
 func (m *SoyMemory) DeleteThought(ctx context.Context, id string) error {
+	if err := m.beginWrite(); err != nil {
+		return err
+	}
+	defer m.mu.RUnlock()
+
 	// Delete notes first (foreign key constraint)
 	_, err := m.notes.Remove().
 		Where("thought_id", "=", "thought_id").
@@ -206,21 +524,47 @@ func (m *SoyMemory) hydrateThought(ctx context.Context, thought *Thought) error
 	return nil
 }
 
-// Close closes the underlying database connection.
+// beginWrite registers an in-flight write so Close waits for it. On success the
+// caller must call m.mu.RUnlock when the write is done.
+func (m *SoyMemory) beginWrite() error {
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMemoryClosed
+	}
+	return nil
+}
+
+// pendingWork implements pendingTracker.
+func (m *SoyMemory) pendingWork() *pendingWork {
+	return &m.pending
+}
+
+// Close waits for notes queued on an AsyncEmbedder to be embedded and stored, and
+// for in-flight writes to finish, then closes the underlying database connection.
+// Later writes fail with ErrMemoryClosed. It is safe to call more than once.
 func (m *SoyMemory) Close() error {
+	<-m.pending.drained()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
 	return m.db.Close()
 }
 
 // SearchNotes finds notes semantically similar to the query embedding.
 // Returns notes ordered by similarity, limited to the specified count.
-// Notes without embeddings are excluded from results.
+// Notes without embeddings, or outside the context's embedding space, are excluded.
 func (m *SoyMemory) SearchNotes(ctx context.Context, embedding Vector, limit int) ([]NoteWithThought, error) {
 	// Query notes ordered by vector distance
-	notes, err := m.notes.Query().
-		WhereNotNull("embedding").
+	query, params := m.searchQuery(ctx, embedding)
+	notes, err := query.
 		OrderByExpr("embedding", "<->", "query_embedding", "asc").
 		Limit(limit).
-		Exec(ctx, map[string]any{"query_embedding": embedding})
+		Exec(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search notes: %w", err)
 	}
@@ -271,15 +615,28 @@ func (m *SoyMemory) SearchNotes(ctx context.Context, embedding Vector, limit int
 	return results, nil
 }
 
+// searchQuery starts a similarity search over embedded notes, restricted in SQL to
+// the embedding space from EmbeddingSpaceFrom(ctx) so that LIMIT counts only
+// comparable notes.
+func (m *SoyMemory) searchQuery(ctx context.Context, embedding Vector) (*soy.Query[Note], map[string]any) {
+	query := m.notes.Query().WhereNotNull("embedding")
+	params := map[string]any{"query_embedding": embedding}
+	if model := EmbeddingSpaceFrom(ctx); model != "" {
+		query = query.Where("embedding_model", "IN", "embedding_models")
+		params["embedding_models"] = []string{model, ""}
+	}
+	return query, params
+}
+
 // SearchNotesByTask finds the most relevant note per task.
 // Returns the most recent thought for each task that has matching notes.
-// Notes without embeddings are excluded from results.
+// Notes without embeddings, or outside the context's embedding space, are excluded.
 func (m *SoyMemory) SearchNotesByTask(ctx context.Context, embedding Vector, limit int) ([]*Thought, error) {
 	// Query notes ordered by vector distance (fetch more to ensure coverage across tasks)
-	notes, err := m.notes.Query().
-		WhereNotNull("embedding").
+	query, params := m.searchQuery(ctx, embedding)
+	notes, err := query.
 		OrderByExpr("embedding", "<->", "query_embedding", "asc").
-		Exec(ctx, map[string]any{"query_embedding": embedding})
+		Exec(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search notes: %w", err)
 	}
@@ -399,4 +756,83 @@ func (m *SoyMemory) SearchNotesByTask(ctx context.Context, embedding Vector, lim
 	return results, nil
 }
 
+// BackfillEmbeddings embeds notes that were stored without an embedding,
+// processing batchSize notes at a time. Returns the number of notes updated.
+// Stops at the first embedding failure so a persistent outage cannot loop forever.
+func (m *SoyMemory) BackfillEmbeddings(ctx context.Context, embedder Embedder, batchSize int) (int, error) {
+	if embedder == nil {
+		return 0, ErrNoEmbedder
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBackfillBatchSize
+	}
+
+	updated := 0
+	done := make(map[string]struct{})
+	for {
+		notes, err := m.notes.Query().
+			WhereNull("embedding").
+			OrderBy("created", "asc").
+			OrderBy("id", "asc").
+			Limit(batchSize).
+			Exec(ctx, nil)
+		if err != nil {
+			return updated, fmt.Errorf("failed to query notes without embeddings: %w", err)
+		}
+
+		for _, note := range notes {
+			// Every pass must shrink the set of unembedded notes, or the loop never ends
+			if _, seen := done[note.ID]; seen {
+				return updated, fmt.Errorf("note %s still has no embedding after update", note.ID)
+			}
+			done[note.ID] = struct{}{}
+
+			embedding, err := embedder.Embed(ctx, note.Content)
+			if err != nil {
+				return updated, fmt.Errorf("failed to embed note %s: %w", note.ID, err)
+			}
+			if len(embedding) == 0 {
+				return updated, fmt.Errorf("failed to embed note %s: %w", note.ID, ErrEmptyEmbedding)
+			}
+
+			if err := m.UpdateNoteEmbedding(ctx, note.ID, embedding); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+
+		if len(notes) < batchSize {
+			return updated, nil
+		}
+	}
+}
+
+// UpdateNoteEmbedding sets the embedding of an already persisted note.
+func (m *SoyMemory) UpdateNoteEmbedding(ctx context.Context, noteID string, embedding Vector) error {
+	if err := m.beginWrite(); err != nil {
+		return err
+	}
+	defer m.mu.RUnlock()
+
+	_, err := m.notes.Modify().
+		Set("embedding", "embedding").
+		Where("id", "=", "id").
+		Exec(ctx, map[string]any{
+			"embedding": embedding,
+			"id":        noteID,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to update note embedding: %w", err)
+	}
+	return nil
+}
+
+// Ping verifies the database connection is alive.
+func (m *SoyMemory) Ping(ctx context.Context) error {
+	if err := m.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 var _ Memory = (*SoyMemory)(nil)
@@ -0,0 +1,162 @@
+package cogito
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/zoobzio/zyn"
+)
+
+// StreamingProvider is implemented by providers that can deliver response content
+// incrementally as it is generated. Primitives configured with a stream callback use
+// CallStream when the resolved provider supports it, and deliver the final text in a
+// single callback otherwise.
+type StreamingProvider interface {
+	Provider
+	CallStream(ctx context.Context, messages []zyn.Message, temperature float32, onChunk func(chunk string)) (*zyn.ProviderResponse, error)
+}
+
+// streamProvider adapts a StreamingProvider to Provider, forwarding the decoded
+// value of a JSON string field to onToken as response chunks arrive.
+type streamProvider struct {
+	StreamingProvider
+	field   string
+	onToken func(token string)
+}
+
+// Call streams the request, restarting field extraction for every call so synapse
+// retries do not mix partial output from earlier attempts.
+func (s *streamProvider) Call(ctx context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	streamer := newFieldStreamer(s.field, s.onToken)
+	return s.CallStream(ctx, messages, temperature, streamer.write)
+}
+
+// fieldStreamer incrementally extracts a top-level JSON string field from a
+// streamed response, emitting decoded text as soon as it is complete.
+type fieldStreamer struct {
+	opening *regexp.Regexp
+	emit    func(string)
+
+	seek    []byte // buffered input while searching for the field
+	started bool
+	done    bool
+	escape  bool
+	hex     []byte // pending \uXXXX digits
+	high    rune   // pending high surrogate
+	pending []byte // decoded bytes awaiting a complete UTF-8 sequence
+}
+
+// newFieldStreamer creates a streamer that emits the value of field to emit.
+func newFieldStreamer(field string, emit func(string)) *fieldStreamer {
+	return &fieldStreamer{
+		opening: regexp.MustCompile(`"` + regexp.QuoteMeta(field) + `"\s*:\s*"`),
+		emit:    emit,
+	}
+}
+
+// write consumes a chunk of raw response content.
+func (f *fieldStreamer) write(chunk string) {
+	if f.done {
+		return
+	}
+
+	data := []byte(chunk)
+	if !f.started {
+		f.seek = append(f.seek, data...)
+		loc := f.opening.FindIndex(f.seek)
+		if loc == nil {
+			return
+		}
+		f.started = true
+		data = f.seek[loc[1]:]
+		f.seek = nil
+	}
+
+	for _, b := range data {
+		if f.done {
+			break
+		}
+		f.consume(b)
+	}
+	f.flush()
+}
+
+// consume processes a single byte of the string value.
+func (f *fieldStreamer) consume(b byte) {
+	switch {
+	case f.hex != nil:
+		f.hex = append(f.hex, b)
+		if len(f.hex) == 4 {
+			code, err := strconv.ParseUint(string(f.hex), 16, 32)
+			f.hex = nil
+			if err == nil {
+				f.appendRune(rune(code))
+			}
+		}
+	case f.escape:
+		f.escape = false
+		switch b {
+		case 'n':
+			f.appendRune('\n')
+		case 't':
+			f.appendRune('\t')
+		case 'r':
+			f.appendRune('\r')
+		case 'b':
+			f.appendRune('\b')
+		case 'f':
+			f.appendRune('\f')
+		case 'u':
+			f.hex = make([]byte, 0, 4)
+		default: // '"', '\\', '/'
+			f.appendRune(rune(b))
+		}
+	case b == '\\':
+		f.escape = true
+	case b == '"':
+		f.done = true
+	default:
+		f.pending = append(f.pending, b)
+	}
+}
+
+// appendRune adds a decoded rune, pairing UTF-16 surrogates from \u escapes.
+func (f *fieldStreamer) appendRune(r rune) {
+	if f.high != 0 {
+		high := f.high
+		f.high = 0
+		if utf16.IsSurrogate(r) {
+			r = utf16.DecodeRune(high, r)
+		} else {
+			f.pending = utf8.AppendRune(f.pending, utf8.RuneError)
+		}
+	} else if r >= 0xD800 && r < 0xDC00 {
+		f.high = r
+		return
+	}
+	f.pending = utf8.AppendRune(f.pending, r)
+}
+
+// flush emits all complete UTF-8 sequences decoded so far.
+func (f *fieldStreamer) flush() {
+	n := len(f.pending)
+	if !f.done {
+		// Hold back a trailing partial multi-byte sequence
+		for i := len(f.pending) - 1; i >= 0 && i >= len(f.pending)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(f.pending[i]) {
+				if !utf8.FullRune(f.pending[i:]) {
+					n = i
+				}
+				break
+			}
+		}
+	}
+	if n == 0 {
+		return
+	}
+	f.emit(string(f.pending[:n]))
+	f.pending = append(f.pending[:0], f.pending[n:]...)
+}
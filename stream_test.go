@@ -0,0 +1,44 @@
+package cogito
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFieldStreamer(t *testing.T) {
+	raw := `{"confidence": 0.9, "output": "Line one\nsays \"hi\" café 😀 \u00e9\ud83d\ude00 — naïve", "changes": ["x"]}`
+	want := "Line one\nsays \"hi\" café 😀 é😀 — naïve"
+
+	for _, size := range []int{1, 2, 3, 7, len(raw)} {
+		var tokens []string
+		streamer := newFieldStreamer("output", func(token string) {
+			tokens = append(tokens, token)
+		})
+		for i := 0; i < len(raw); i += size {
+			end := i + size
+			if end > len(raw) {
+				end = len(raw)
+			}
+			streamer.write(raw[i:end])
+		}
+
+		if got := strings.Join(tokens, ""); got != want {
+			t.Errorf("chunk size %d: expected %q, got %q", size, want, got)
+		}
+		for _, token := range tokens {
+			if !utf8.ValidString(token) {
+				t.Errorf("chunk size %d: emitted invalid UTF-8 token %q", size, token)
+			}
+		}
+	}
+}
+
+func TestFieldStreamerMissingField(t *testing.T) {
+	called := false
+	streamer := newFieldStreamer("output", func(string) { called = true })
+	streamer.write(`{"decision": true, "reasoning": ["no output here"]}`)
+	if called {
+		t.Error("expected no tokens when the field is absent")
+	}
+}
@@ -0,0 +1,210 @@
+package cogito
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zoobzio/capitan"
+	"github.com/zoobzio/pipz"
+	"github.com/zoobzio/zyn"
+)
+
+// Summarize is a context-compaction primitive that implements pipz.Chainable[*Thought].
+// It condenses the notes not yet sent to the LLM into one digest note and marks them
+// published, so later steps read the digest instead of every note behind it.
+type Summarize struct {
+	identity             pipz.Identity
+	key                  string
+	summaryKey           string
+	prompt               string
+	temperature          float32
+	reasoningTemperature float32
+	provider             Provider
+}
+
+// NewSummarize creates a new summarization primitive.
+//
+// The primitive:
+//  1. Gathers the unpublished notes
+//  2. Renders them to text
+//  3. Condenses them via LLM transform synapse
+//  4. Stores the digest and marks the summarized notes published
+//
+// With no unpublished notes it writes an empty digest without calling the provider.
+//
+// Output Notes:
+//   - {key}: Concise digest of the unpublished notes, with "source_note_count" metadata
+//     (written to the WithSummaryKey key instead, if set)
+//
+// Example:
+//
+//	chain := cogito.Sequence(pipz.NewIdentity("research", "Research chain"),
+//	    gather,
+//	    cogito.NewSummarize("findings_digest"),
+//	    cogito.NewDecide("escalate", "Should this be escalated?"),
+//	)
+func NewSummarize(key string) *Summarize {
+	return &Summarize{
+		identity:    pipz.NewIdentity(key, "Summarization primitive"),
+		key:         key,
+		prompt:      "Summarize the accumulated context concisely, keeping every fact, decision and open question later steps may need",
+		temperature: DefaultReasoningTemperature,
+	}
+}
+
+// Process implements pipz.Chainable[*Thought].
+func (s *Summarize) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
+	start := time.Now()
+
+	outputKey := s.outputKey()
+	notes := t.GetUnpublishedNotes()
+	summarized := t.NoteCount()
+
+	// Emit step started
+	capitan.Emit(ctx, StepStarted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(s.key),
+		FieldStepType.Field("summarize"),
+		FieldUnpublishedCount.Field(len(notes)),
+		FieldTemperature.Field(s.temperature),
+	)
+
+	// Nothing new to condense, so there is nothing to ask the provider
+	var summary string
+	if len(notes) > 0 {
+		var err error
+		summary, err = s.summarize(ctx, t, notes)
+		if err != nil {
+			s.emitFailed(ctx, t, start, err)
+			return t, err
+		}
+	}
+
+	metadata := contextKeysMetadata(notes)
+	metadata["source_note_count"] = strconv.Itoa(len(notes))
+	if err := t.SetNote(ctx, outputKey, summary, "summarize", metadata); err != nil {
+		s.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("summarize: failed to persist summary: %w", err)
+	}
+
+	// The digest stands in for the notes it condensed; persist the boundary for Resume
+	if err := t.publishNotesUpTo(ctx, summarized); err != nil {
+		s.emitFailed(ctx, t, start, err)
+		return t, fmt.Errorf("summarize: %w", err)
+	}
+
+	// Emit step completed
+	capitan.Emit(ctx, StepCompleted,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(s.key),
+		FieldStepType.Field("summarize"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldNoteCount.Field(t.NoteCount()),
+	)
+
+	return t, nil
+}
+
+// summarize condenses notes via LLM transform synapse.
+func (s *Summarize) summarize(ctx context.Context, t *Thought, notes []Note) (string, error) {
+	provider, err := ResolveProviderForStep(ctx, "summarize", s.provider)
+	if err != nil {
+		return "", fmt.Errorf("summarize: %w", err)
+	}
+
+	transformSynapse, err := zyn.Transform(s.prompt, provider)
+	if err != nil {
+		return "", fmt.Errorf("summarize: failed to create transform synapse: %w", err)
+	}
+
+	// Determine reasoning temperature
+	temp := s.temperature
+	if s.reasoningTemperature != 0 {
+		temp = s.reasoningTemperature
+	}
+
+	noteContext := RenderNotesToContext(notes)
+	emitContextRendered(ctx, t, s.key, "summarize", noteContext, len(notes))
+
+	summary, err := transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
+		Text:        noteContext,
+		Style:       "Be concise. Drop repetition and filler, but do not drop facts, numbers, names or conclusions.",
+		Temperature: temp,
+	})
+	if err != nil {
+		return "", fmt.Errorf("summarize: transform synapse execution failed: %w", err)
+	}
+	return summary, nil
+}
+
+// outputKey returns the key the digest is written to.
+func (s *Summarize) outputKey() string {
+	if s.summaryKey != "" {
+		return s.summaryKey
+	}
+	return s.key
+}
+
+// emitFailed emits a step failed event.
+func (s *Summarize) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
+	emitStepFailed(ctx, err,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(s.key),
+		FieldStepType.Field("summarize"),
+		FieldStepDuration.Field(time.Since(start)),
+		FieldError.Field(err),
+	)
+}
+
+// Identity implements pipz.Chainable[*Thought].
+func (s *Summarize) Identity() pipz.Identity {
+	return s.identity
+}
+
+// Schema implements pipz.Chainable[*Thought].
+func (s *Summarize) Schema() pipz.Node {
+	return stepSchema(s.identity, "summarize", true, nil, []string{s.outputKey()})
+}
+
+// Close implements pipz.Chainable[*Thought].
+func (s *Summarize) Close() error {
+	return nil
+}
+
+// Builder methods
+
+// WithPrompt sets a custom summarization prompt.
+func (s *Summarize) WithPrompt(prompt string) *Summarize {
+	s.prompt = prompt
+	return s
+}
+
+// WithSummaryKey writes the digest to key instead of the step key.
+func (s *Summarize) WithSummaryKey(key string) *Summarize {
+	s.summaryKey = key
+	return s
+}
+
+// WithTemperature sets the default temperature for this step.
+func (s *Summarize) WithTemperature(temp float32) *Summarize {
+	s.temperature = temp
+	return s
+}
+
+// WithReasoningTemperature sets the temperature for the summarization call,
+// overriding WithTemperature.
+func (s *Summarize) WithReasoningTemperature(temp float32) *Summarize {
+	s.reasoningTemperature = temp
+	return s
+}
+
+// WithProvider sets the provider for the LLM call.
+func (s *Summarize) WithProvider(p Provider) *Summarize {
+	s.provider = p
+	return s
+}
+
+var _ pipz.Chainable[*Thought] = (*Summarize)(nil)
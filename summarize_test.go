@@ -0,0 +1,121 @@
+package cogito
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/zyn"
+)
+
+// summarizeProvider records its calls and answers with a fixed digest.
+type summarizeProvider struct {
+	calls       int
+	prompt      string
+	temperature float32
+}
+
+func (p *summarizeProvider) Call(_ context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	p.calls++
+	p.prompt = messages[len(messages)-1].Content
+	p.temperature = temperature
+	return &zyn.ProviderResponse{
+		Content: `{"output": "Checkout fails for EU cards since the 2.3 deploy.", "confidence": 0.9, "changes": [], "reasoning": []}`,
+	}, nil
+}
+
+func (p *summarizeProvider) Name() string {
+	return "summarize-provider"
+}
+
+func TestSummarize(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("summarize")
+	thought.SetContent(ctx, "old", "already sent", "input")
+	thought.MarkNotesPublished()
+	thought.SetContent(ctx, "report", "Checkout is failing for customers in Germany.", "input")
+	thought.SetContent(ctx, "deploys", "Release 2.3 went out this morning.", "input")
+
+	provider := &summarizeProvider{}
+	result, err := NewSummarize("digest").
+		WithProvider(provider).
+		WithReasoningTemperature(0.2).
+		Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if digest, _ := result.GetContent("digest"); digest != "Checkout fails for EU cards since the 2.3 deploy." {
+		t.Errorf("unexpected digest %q", digest)
+	}
+	if count, _ := result.GetMetadata("digest", "source_note_count"); count != "2" {
+		t.Errorf("expected source_note_count 2, got %q", count)
+	}
+	if keys, _ := result.GetMetadata("digest", "context_keys"); keys != "report,deploys" {
+		t.Errorf("expected context_keys 'report,deploys', got %q", keys)
+	}
+	if strings.Contains(provider.prompt, "already sent") {
+		t.Error("expected published notes to be left out")
+	}
+	if provider.temperature != 0.2 {
+		t.Errorf("expected reasoning temperature 0.2, got %v", provider.temperature)
+	}
+
+	// Only the digest is left for the next step
+	unpublished := result.GetUnpublishedNotes()
+	if len(unpublished) != 1 || unpublished[0].Key != "digest" {
+		t.Errorf("expected only the digest unpublished, got %v", unpublished)
+	}
+}
+
+func TestSummarizeResume(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+	thought, err := New(ctx, mem, "summarize")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thought.SetContent(ctx, "report", "Checkout is failing for customers in Germany.", "input")
+	thought.SetContent(ctx, "deploys", "Release 2.3 went out this morning.", "input")
+
+	if _, err := NewSummarize("digest").WithProvider(&summarizeProvider{}).Process(ctx, thought); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The boundary is persisted, so a resumed thought leaves only the digest unpublished
+	resumed, err := mem.Resume(ctx, thought.TraceID)
+	if err != nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	if resumed.PublishedCount() != 2 {
+		t.Errorf("expected published count 2 after resume, got %d", resumed.PublishedCount())
+	}
+	unpublished := resumed.GetUnpublishedNotes()
+	if len(unpublished) != 1 || unpublished[0].Key != "digest" {
+		t.Errorf("expected only the digest unpublished after resume, got %v", unpublished)
+	}
+}
+
+func TestSummarizeNoUnpublishedNotes(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("summarize")
+	thought.SetContent(ctx, "old", "already sent", "input")
+	thought.MarkNotesPublished()
+
+	provider := &summarizeProvider{}
+	result, err := NewSummarize("digest").WithSummaryKey("context_digest").WithProvider(provider).Process(ctx, thought)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if provider.calls != 0 {
+		t.Errorf("expected no provider call, got %d", provider.calls)
+	}
+	digest, err := result.GetContent("context_digest")
+	if err != nil {
+		t.Fatalf("expected digest note at summary key: %v", err)
+	}
+	if digest != "" {
+		t.Errorf("expected empty digest, got %q", digest)
+	}
+}
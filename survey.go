@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/pipz"
@@ -79,6 +80,7 @@ func (s *Survey) WithProvider(p Provider) *Survey {
 
 // Process executes the task-grouped semantic search and synthesis.
 func (s *Survey) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	capitan.Emit(ctx, StepStarted,
@@ -104,8 +106,8 @@ func (s *Survey) Process(ctx context.Context, t *Thought) (*Thought, error) {
 		return t, fmt.Errorf("survey: failed to embed query: %w", err)
 	}
 
-	// Search for thoughts by task
-	thoughts, err := t.memory.SearchNotesByTask(ctx, queryEmbedding, s.limit)
+	// Search for thoughts by task, within the query's embedding space
+	thoughts, err := t.memory.SearchNotesByTask(searchContext(ctx, embedder), queryEmbedding, s.limit)
 	if err != nil {
 		s.emitFailed(ctx, t, start, err)
 		return t, fmt.Errorf("survey: search failed: %w", err)
@@ -120,12 +122,14 @@ func (s *Survey) Process(ctx context.Context, t *Thought) (*Thought, error) {
 
 	// Build context from results
 	var contextBuilder strings.Builder
+	renderedNotes := 0
 	for i, thought := range thoughts {
 		contextBuilder.WriteString(fmt.Sprintf("--- Task %d ---\n", i+1))
 		contextBuilder.WriteString(fmt.Sprintf("Intent: %s\n", thought.Intent))
 		contextBuilder.WriteString(fmt.Sprintf("Created: %s\n", thought.CreatedAt.Format(time.RFC3339)))
 		contextBuilder.WriteString("Notes:\n")
 		for _, note := range thought.AllNotes() {
+			renderedNotes++
 			contextBuilder.WriteString(fmt.Sprintf("  - %s: %s\n", note.Key, truncateContent(note.Content, 200)))
 		}
 		contextBuilder.WriteString("\n")
@@ -134,7 +138,7 @@ func (s *Survey) Process(ctx context.Context, t *Thought) (*Thought, error) {
 	// Synthesize results if we have any
 	var summary string
 	if len(thoughts) > 0 {
-		provider, err := ResolveProvider(ctx, s.provider)
+		provider, err := ResolveProviderForStep(ctx, "survey", s.provider)
 		if err != nil {
 			s.emitFailed(ctx, t, start, err)
 			return t, fmt.Errorf("survey: %w", err)
@@ -149,6 +153,8 @@ func (s *Survey) Process(ctx context.Context, t *Thought) (*Thought, error) {
 			return t, fmt.Errorf("survey: failed to create transform synapse: %w", err)
 		}
 
+		emitContextRendered(ctx, t, s.key, "survey", contextBuilder.String(), renderedNotes)
+
 		summary, err = transformSynapse.FireWithInput(ctx, t.Session, zyn.TransformInput{
 			Text:        contextBuilder.String(),
 			Context:     fmt.Sprintf("Query: %s\n\nSynthesize insights from these related tasks, highlighting patterns and key learnings.", s.query),
@@ -205,7 +211,11 @@ func (s *Survey) Identity() pipz.Identity {
 
 // Schema implements pipz.Chainable[*Thought].
 func (s *Survey) Schema() pipz.Node {
-	return pipz.Node{Identity: s.identity, Type: "survey"}
+	summaryKey := s.summaryKey
+	if summaryKey == "" {
+		summaryKey = s.key
+	}
+	return stepSchema(s.identity, "survey", false, nil, []string{summaryKey})
 }
 
 // Close implements pipz.Chainable[*Thought].
@@ -214,7 +224,7 @@ func (s *Survey) Close() error {
 }
 
 func (s *Survey) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(s.key),
 		FieldStepType.Field("survey"),
@@ -223,12 +233,14 @@ func (s *Survey) emitFailed(ctx context.Context, t *Thought, start time.Time, er
 	)
 }
 
-// truncateContent truncates content to maxLen characters with ellipsis.
+// truncateContent truncates content to maxLen characters with ellipsis, cutting on
+// a rune boundary so multi-byte text stays valid UTF-8.
 func truncateContent(content string, maxLen int) string {
-	if len(content) <= maxLen {
+	if utf8.RuneCountInString(content) <= maxLen {
 		return content
 	}
-	return content[:maxLen-3] + "..."
+	runes := []rune(content)
+	return string(runes[:maxLen-3]) + "..."
 }
 
 var _ pipz.Chainable[*Thought] = (*Survey)(nil)
@@ -120,6 +120,20 @@ func TestSurveyProcess(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps to the query embedding model", func(t *testing.T) {
+		mem := newMockSearchMemory()
+		ctx := context.Background()
+		thought, _ := New(ctx, mem, "test survey")
+
+		embedder := &mockModelEmbedder{mockEmbedder{embedding: []float32{0.1}, dimensions: 1}, "prose-model"}
+		if _, err := NewSurvey("context", "query").WithEmbedder(embedder).Process(ctx, thought); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mem.space != "prose-model" {
+			t.Errorf("expected search restricted to prose-model, got %q", mem.space)
+		}
+	})
+
 	t.Run("fails without embedder", func(t *testing.T) {
 		mem := newMockSearchMemory()
 		ctx := context.Background()
@@ -281,6 +295,18 @@ func TestTruncateContent(t *testing.T) {
 			maxLen:   8,
 			expected: "hello...",
 		},
+		{
+			name:     "multi-byte content cut on rune boundary",
+			content:  "Grüße, 世界 und mehr",
+			maxLen:   10,
+			expected: "Grüße, ...",
+		},
+		{
+			name:     "multi-byte content within limit unchanged",
+			content:  "世界世界",
+			maxLen:   4,
+			expected: "世界世界",
+		},
 	}
 
 	for _, tt := range tests {
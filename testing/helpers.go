@@ -4,18 +4,20 @@ package cogitotest
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/zoobzio/cogito"
+	"github.com/zoobzio/zyn"
 )
 
 // MockMemory implements cogito.Memory for testing without a database.
 type MockMemory struct {
 	thoughts map[string]*cogito.Thought
 	notes    map[string][]cogito.Note
+	pingErr  error
 	mu       sync.RWMutex
 }
 
@@ -32,7 +34,7 @@ func (m *MockMemory) CreateThought(_ context.Context, thought *cogito.Thought) (
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	thought.ID = uuid.New().String()
+	thought.ID = cogito.NewID()
 	m.thoughts[thought.ID] = thought
 	return thought, nil
 }
@@ -44,7 +46,7 @@ func (m *MockMemory) GetThought(_ context.Context, id string) (*cogito.Thought,
 
 	thought, ok := m.thoughts[id]
 	if !ok {
-		return nil, fmt.Errorf("thought not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", cogito.ErrNotFound, id)
 	}
 	return thought, nil
 }
@@ -59,7 +61,7 @@ func (m *MockMemory) GetThoughtByTraceID(_ context.Context, traceID string) (*co
 			return thought, nil
 		}
 	}
-	return nil, fmt.Errorf("thought not found for trace: %s", traceID)
+	return nil, fmt.Errorf("%w for trace: %s", cogito.ErrNotFound, traceID)
 }
 
 // GetThoughtsByTaskID loads all thoughts for a task, ordered by creation time.
@@ -76,6 +78,71 @@ func (m *MockMemory) GetThoughtsByTaskID(_ context.Context, taskID string) ([]*c
 	return thoughts, nil
 }
 
+// GetThoughtsByIntent loads thoughts with exactly the given intent, ordered by creation time.
+func (m *MockMemory) GetThoughtsByIntent(_ context.Context, intent string, limit, offset int) ([]*cogito.Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var thoughts []*cogito.Thought
+	for _, thought := range m.thoughts {
+		if thought.Intent == intent {
+			thoughts = append(thoughts, thought)
+		}
+	}
+	return pageByCreation(thoughts, limit, offset), nil
+}
+
+// GetThoughtsByDateRange loads thoughts created in [from, to), ordered by creation time.
+// Under cogito.WithoutNoteHydration it returns note-less copies of the thoughts.
+func (m *MockMemory) GetThoughtsByDateRange(ctx context.Context, from, to time.Time, limit, offset int) ([]*cogito.Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var thoughts []*cogito.Thought
+	for _, thought := range m.thoughts {
+		if !thought.CreatedAt.Before(from) && thought.CreatedAt.Before(to) {
+			thoughts = append(thoughts, thought)
+		}
+	}
+	thoughts = pageByCreation(thoughts, limit, offset)
+
+	if cogito.NoteHydrationSkipped(ctx) {
+		for i, thought := range thoughts {
+			bare := &cogito.Thought{
+				ID:        thought.ID,
+				Intent:    thought.Intent,
+				TraceID:   thought.TraceID,
+				ParentID:  thought.ParentID,
+				TaskID:    thought.TaskID,
+				Session:   zyn.NewSession(),
+				CreatedAt: thought.CreatedAt,
+				UpdatedAt: thought.UpdatedAt,
+			}
+			bare.SetMemory(m)
+			thoughts[i] = bare
+		}
+	}
+	return thoughts, nil
+}
+
+// pageByCreation orders thoughts by creation time and applies limit and offset.
+func pageByCreation(thoughts []*cogito.Thought, limit, offset int) []*cogito.Thought {
+	sort.Slice(thoughts, func(i, j int) bool {
+		return thoughts[i].CreatedAt.Before(thoughts[j].CreatedAt)
+	})
+
+	if offset >= len(thoughts) {
+		return nil
+	}
+	if offset > 0 {
+		thoughts = thoughts[offset:]
+	}
+	if limit > 0 && limit < len(thoughts) {
+		thoughts = thoughts[:limit]
+	}
+	return thoughts
+}
+
 // GetChildThoughts loads all thoughts that have the given thought as parent.
 func (m *MockMemory) GetChildThoughts(_ context.Context, parentID string) ([]*cogito.Thought, error) {
 	m.mu.RLock()
@@ -90,12 +157,39 @@ func (m *MockMemory) GetChildThoughts(_ context.Context, parentID string) ([]*co
 	return thoughts, nil
 }
 
+// GetConversation walks ParentID from leafThoughtID to the root, returning thoughts oldest-first.
+func (m *MockMemory) GetConversation(_ context.Context, leafThoughtID string) ([]*cogito.Thought, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var chain []*cogito.Thought
+	seen := make(map[string]struct{})
+	id := leafThoughtID
+	for {
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("conversation cycle detected at thought %s", id)
+		}
+		seen[id] = struct{}{}
+
+		thought, ok := m.thoughts[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", cogito.ErrNotFound, id)
+		}
+		chain = append([]*cogito.Thought{thought}, chain...)
+
+		if thought.ParentID == nil {
+			return chain, nil
+		}
+		id = *thought.ParentID
+	}
+}
+
 // AddNote persists a note and returns it with ID populated.
 func (m *MockMemory) AddNote(_ context.Context, note *cogito.Note) (*cogito.Note, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	note.ID = uuid.New().String()
+	note.ID = cogito.NewID()
 	if note.Created.IsZero() {
 		note.Created = time.Now()
 	}
@@ -115,18 +209,46 @@ func (m *MockMemory) GetNotes(_ context.Context, thoughtID string) ([]cogito.Not
 	return notes, nil
 }
 
+// GetNotesBySourceSince loads a thought's notes from source after the (since, afterID)
+// cursor, ordered by creation time and then ID.
+func (m *MockMemory) GetNotesBySourceSince(_ context.Context, thoughtID, source string, since time.Time, afterID string) ([]cogito.Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notes := []cogito.Note{}
+	for _, note := range m.notes[thoughtID] {
+		after := note.Created.After(since) || (afterID != "" && note.Created.Equal(since) && note.ID > afterID)
+		if note.Source == source && after {
+			notes = append(notes, note)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		if !notes[i].Created.Equal(notes[j].Created) {
+			return notes[i].Created.Before(notes[j].Created)
+		}
+		return notes[i].ID < notes[j].ID
+	})
+	return notes, nil
+}
+
 // UpdateThought updates thought metadata (timestamps, publishedCount).
 func (m *MockMemory) UpdateThought(_ context.Context, thought *cogito.Thought) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if _, ok := m.thoughts[thought.ID]; !ok {
-		return fmt.Errorf("thought not found: %s", thought.ID)
+		return fmt.Errorf("%w: %s", cogito.ErrNotFound, thought.ID)
 	}
 	m.thoughts[thought.ID] = thought
 	return nil
 }
 
+// Resume loads a thought by trace ID. Thoughts are held by reference, so the
+// returned thought keeps its notes, publish count and session.
+func (m *MockMemory) Resume(ctx context.Context, traceID string) (*cogito.Thought, error) {
+	return m.GetThoughtByTraceID(ctx, traceID)
+}
+
 // DeleteThought removes a thought and all its notes.
 func (m *MockMemory) DeleteThought(_ context.Context, id string) error {
 	m.mu.Lock()
@@ -147,6 +269,66 @@ func (m *MockMemory) SearchNotesByTask(_ context.Context, _ cogito.Vector, _ int
 	return []*cogito.Thought{}, nil
 }
 
+// BackfillEmbeddings embeds stored notes that have no embedding.
+func (m *MockMemory) BackfillEmbeddings(ctx context.Context, embedder cogito.Embedder, _ int) (int, error) {
+	if embedder == nil {
+		return 0, cogito.ErrNoEmbedder
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := 0
+	for thoughtID, notes := range m.notes {
+		for i := range notes {
+			if len(notes[i].Embedding) > 0 {
+				continue
+			}
+			embedding, err := embedder.Embed(ctx, notes[i].Content)
+			if err != nil {
+				return updated, err
+			}
+			if len(embedding) == 0 {
+				return updated, cogito.ErrEmptyEmbedding
+			}
+			notes[i].Embedding = embedding
+			updated++
+		}
+		m.notes[thoughtID] = notes
+	}
+	return updated, nil
+}
+
+// UpdateNoteEmbedding sets the embedding of a stored note.
+func (m *MockMemory) UpdateNoteEmbedding(_ context.Context, noteID string, embedding cogito.Vector) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, notes := range m.notes {
+		for i := range notes {
+			if notes[i].ID == noteID {
+				notes[i].Embedding = embedding
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("note not found: %s", noteID)
+}
+
+// Ping returns the error set via SetPingError, or nil.
+func (m *MockMemory) Ping(_ context.Context) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pingErr
+}
+
+// SetPingError makes subsequent Ping calls return err, simulating an unreachable store.
+func (m *MockMemory) SetPingError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pingErr = err
+}
+
 // Verify MockMemory implements cogito.Memory.
 var _ cogito.Memory = (*MockMemory)(nil)
 
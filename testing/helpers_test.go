@@ -2,7 +2,11 @@ package cogitotest
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/zoobzio/cogito"
 )
 
 func TestMockMemory(t *testing.T) {
@@ -68,6 +72,191 @@ func TestMockMemory(t *testing.T) {
 			t.Errorf("expected empty results, got %d", len(results))
 		}
 	})
+
+	t.Run("BackfillEmbeddings", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "a", Content: "one"})
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "b", Content: "two", Embedding: cogito.Vector{1}})
+
+		updated, err := mem.BackfillEmbeddings(ctx, staticEmbedder{}, 10)
+		if err != nil {
+			t.Fatalf("BackfillEmbeddings failed: %v", err)
+		}
+		if updated != 1 {
+			t.Errorf("expected 1 note updated, got %d", updated)
+		}
+
+		notes, _ := mem.GetNotes(ctx, "t1")
+		for _, note := range notes {
+			if len(note.Embedding) == 0 {
+				t.Errorf("expected note %q to have an embedding", note.Key)
+			}
+		}
+
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "c", Content: "three"})
+		if _, err := mem.BackfillEmbeddings(ctx, emptyEmbedder{}, 10); !errors.Is(err, cogito.ErrEmptyEmbedding) {
+			t.Errorf("expected ErrEmptyEmbedding, got %v", err)
+		}
+	})
+
+	t.Run("GetConversation", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+
+		root, _ := mem.CreateThought(ctx, &cogito.Thought{Intent: "turn 1"})
+		middle, _ := mem.CreateThought(ctx, &cogito.Thought{Intent: "turn 2", ParentID: &root.ID})
+		leaf, _ := mem.CreateThought(ctx, &cogito.Thought{Intent: "turn 3", ParentID: &middle.ID})
+
+		conversation, err := mem.GetConversation(ctx, leaf.ID)
+		if err != nil {
+			t.Fatalf("GetConversation failed: %v", err)
+		}
+		if len(conversation) != 3 {
+			t.Fatalf("expected 3 thoughts, got %d", len(conversation))
+		}
+		for i, intent := range []string{"turn 1", "turn 2", "turn 3"} {
+			if conversation[i].Intent != intent {
+				t.Errorf("expected thought %d to be %q, got %q", i, intent, conversation[i].Intent)
+			}
+		}
+
+		if _, err := mem.GetConversation(ctx, "missing"); err == nil {
+			t.Error("expected error for unknown leaf")
+		}
+	})
+
+	t.Run("GetNotesBySourceSince", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		since := time.Now()
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "old", Source: "extract", Created: since.Add(-time.Minute)})
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "new", Source: "extract", Created: since.Add(time.Minute)})
+		_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: "other", Source: "decide", Created: since.Add(time.Minute)})
+
+		notes, err := mem.GetNotesBySourceSince(ctx, "t1", "extract", since, "")
+		if err != nil {
+			t.Fatalf("GetNotesBySourceSince failed: %v", err)
+		}
+		if len(notes) != 1 || notes[0].Key != "new" {
+			t.Errorf("expected only the newer extract note, got %v", notes)
+		}
+	})
+
+	t.Run("GetNotesBySourceSince pages within one instant", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		created := time.Now()
+		for _, key := range []string{"a", "b", "c"} {
+			_, _ = mem.AddNote(ctx, &cogito.Note{ThoughtID: "t1", Key: key, Source: "extract", Created: created})
+		}
+
+		first, _ := mem.GetNotesBySourceSince(ctx, "t1", "extract", created.Add(-time.Second), "")
+		if len(first) != 3 {
+			t.Fatalf("expected 3 notes, got %d", len(first))
+		}
+		// Resume from the first note: the other two share its timestamp
+		rest, err := mem.GetNotesBySourceSince(ctx, "t1", "extract", first[0].Created, first[0].ID)
+		if err != nil {
+			t.Fatalf("GetNotesBySourceSince failed: %v", err)
+		}
+		if len(rest) != 2 || rest[0].ID != first[1].ID || rest[1].ID != first[2].ID {
+			t.Errorf("expected the two notes after the cursor, got %v", rest)
+		}
+	})
+
+	t.Run("GetThoughtsByIntent", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		base := time.Now()
+		for i := 0; i < 3; i++ {
+			_, _ = mem.CreateThought(ctx, &cogito.Thought{Intent: "process_refund", CreatedAt: base.Add(time.Duration(i) * time.Second)})
+		}
+		_, _ = mem.CreateThought(ctx, &cogito.Thought{Intent: "open_ticket", CreatedAt: base})
+
+		all, err := mem.GetThoughtsByIntent(ctx, "process_refund", 0, 0)
+		if err != nil {
+			t.Fatalf("GetThoughtsByIntent failed: %v", err)
+		}
+		if len(all) != 3 {
+			t.Fatalf("expected 3 thoughts, got %d", len(all))
+		}
+
+		page, _ := mem.GetThoughtsByIntent(ctx, "process_refund", 1, 1)
+		if len(page) != 1 || page[0].ID != all[1].ID {
+			t.Errorf("expected second thought in page, got %v", page)
+		}
+		if rest, _ := mem.GetThoughtsByIntent(ctx, "process_refund", 10, 5); len(rest) != 0 {
+			t.Errorf("expected empty page past the end, got %d", len(rest))
+		}
+	})
+
+	t.Run("GetThoughtsByDateRange", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		for _, at := range []time.Time{day.Add(-time.Second), day, day.Add(time.Hour), day.Add(24 * time.Hour)} {
+			_, _ = mem.CreateThought(ctx, &cogito.Thought{Intent: "report", CreatedAt: at})
+		}
+
+		inDay, err := mem.GetThoughtsByDateRange(ctx, day, day.Add(24*time.Hour), 0, 0)
+		if err != nil {
+			t.Fatalf("GetThoughtsByDateRange failed: %v", err)
+		}
+		if len(inDay) != 2 || !inDay[0].CreatedAt.Equal(day) {
+			t.Fatalf("expected the 2 thoughts from the day in order, got %d", len(inDay))
+		}
+
+		page, _ := mem.GetThoughtsByDateRange(ctx, day, day.Add(24*time.Hour), 1, 1)
+		if len(page) != 1 || page[0].ID != inDay[1].ID {
+			t.Errorf("expected second thought in page, got %v", page)
+		}
+
+		recent, _ := cogito.New(ctx, mem, "report")
+		_ = recent.SetContent(ctx, "verdict", "approved", "test")
+		bare, err := mem.GetThoughtsByDateRange(cogito.WithoutNoteHydration(ctx), recent.CreatedAt, recent.CreatedAt.Add(time.Second), 0, 0)
+		if err != nil {
+			t.Fatalf("GetThoughtsByDateRange failed: %v", err)
+		}
+		if len(bare) != 1 || bare[0].ID != recent.ID || bare[0].NoteCount() != 0 {
+			t.Errorf("expected a note-less thought under WithoutNoteHydration, got %v", bare)
+		}
+		if recent.NoteCount() != 1 {
+			t.Error("expected the stored thought to keep its notes")
+		}
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		ctx := context.Background()
+		mem := NewMockMemory()
+		if err := mem.Ping(ctx); err != nil {
+			t.Fatalf("expected healthy ping, got %v", err)
+		}
+
+		unreachable := errors.New("connection refused")
+		mem.SetPingError(unreachable)
+		if err := mem.Ping(ctx); !errors.Is(err, unreachable) {
+			t.Errorf("expected ping error %v, got %v", unreachable, err)
+		}
+	})
+}
+
+// staticEmbedder returns a fixed embedding for any text.
+type staticEmbedder struct{}
+
+func (staticEmbedder) Embed(_ context.Context, _ string) ([]float32, error) {
+	return []float32{0.5, 0.5}, nil
+}
+
+func (staticEmbedder) Dimensions() int {
+	return 2
+}
+
+// emptyEmbedder returns no vector, as a misbehaving embedder might.
+type emptyEmbedder struct{ staticEmbedder }
+
+func (emptyEmbedder) Embed(_ context.Context, _ string) ([]float32, error) {
+	return nil, nil
 }
 
 func TestNewTestThought(t *testing.T) {
@@ -4,9 +4,12 @@ package integration_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/zoobzio/cogito"
@@ -54,6 +57,20 @@ func TestSoyMemory_CreateThought(t *testing.T) {
 	_ = memory.DeleteThought(ctx, thought.ID)
 }
 
+func TestSoyMemory_Ping(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	if err := memory.Ping(context.Background()); err != nil {
+		t.Errorf("expected ping to succeed: %v", err)
+	}
+}
+
 func TestSoyMemory_AddNote(t *testing.T) {
 	db := getTestDB(t)
 	defer db.Close()
@@ -84,6 +101,84 @@ func TestSoyMemory_AddNote(t *testing.T) {
 	}
 }
 
+func TestSoyMemory_AddNoteWriteTimeout(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "test intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	// A cancelled caller does not abort the insert
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := memory.AddNote(cancelled, &cogito.Note{ThoughtID: thought.ID, Key: "kept", Content: "v", Source: "test", Created: time.Now()}); err != nil {
+		t.Fatalf("expected insert to survive cancellation, got %v", err)
+	}
+
+	// but the write timeout still bounds it
+	memory.WithNoteWriteTimeout(time.Nanosecond)
+	if _, err := memory.AddNote(ctx, &cogito.Note{ThoughtID: thought.ID, Key: "late", Content: "v", Source: "test", Created: time.Now()}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestSoyMemory_GetNotesBySourceSince(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "test intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	_ = thought.SetContent(ctx, "old", "before", "extract")
+	since := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_ = thought.SetContent(ctx, "new", "after", "extract")
+	_ = thought.SetContent(ctx, "other", "after", "decide")
+
+	notes, err := memory.GetNotesBySourceSince(ctx, thought.ID, "extract", since, "")
+	if err != nil {
+		t.Fatalf("failed to get notes by source: %v", err)
+	}
+	if len(notes) != 1 || notes[0].Key != "new" {
+		t.Errorf("expected only the newer extract note, got %v", notes)
+	}
+
+	// Notes sharing a timestamp are paged by ID
+	created := time.Now().Truncate(time.Microsecond)
+	for _, key := range []string{"a", "b", "c"} {
+		_ = thought.AddNote(ctx, cogito.Note{Key: key, Content: "same instant", Source: "batch", Created: created})
+	}
+	first, err := memory.GetNotesBySourceSince(ctx, thought.ID, "batch", created.Add(-time.Second), "")
+	if err != nil || len(first) != 3 {
+		t.Fatalf("expected 3 batch notes, got %d (%v)", len(first), err)
+	}
+	rest, err := memory.GetNotesBySourceSince(ctx, thought.ID, "batch", first[0].Created, first[0].ID)
+	if err != nil {
+		t.Fatalf("failed to page notes: %v", err)
+	}
+	if len(rest) != 2 || rest[0].ID != first[1].ID || rest[1].ID != first[2].ID {
+		t.Errorf("expected the two notes after the cursor, got %v", rest)
+	}
+}
+
 func TestSoyMemory_GetThought(t *testing.T) {
 	db := getTestDB(t)
 	defer db.Close()
@@ -125,6 +220,47 @@ func TestSoyMemory_GetThought(t *testing.T) {
 	}
 }
 
+func TestSoyMemory_GetNotesSameTimestamp(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "same timestamp intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	// Notes written in the same instant must reload in insertion order.
+	created := time.Now()
+	keys := []string{"zulu", "alpha", "mike", "bravo"}
+	for _, key := range keys {
+		if err := thought.AddNote(ctx, cogito.Note{Key: key, Content: key, Source: "test", Created: created}); err != nil {
+			t.Fatalf("failed to add note: %v", err)
+		}
+	}
+
+	retrieved, err := memory.GetThought(ctx, thought.ID)
+	if err != nil {
+		t.Fatalf("failed to get thought: %v", err)
+	}
+
+	notes := retrieved.AllNotes()
+	if len(notes) != len(keys) {
+		t.Fatalf("expected %d notes, got %d", len(keys), len(notes))
+	}
+	for i, key := range keys {
+		if notes[i].Key != key {
+			t.Errorf("note %d: expected %q, got %q", i, key, notes[i].Key)
+		}
+	}
+}
+
 func TestSoyMemory_GetThoughtByTraceID(t *testing.T) {
 	db := getTestDB(t)
 	defer db.Close()
@@ -151,6 +287,286 @@ func TestSoyMemory_GetThoughtByTraceID(t *testing.T) {
 	}
 }
 
+func TestSoyMemory_GetThoughtsByIntent(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	intent := "process_refund_" + uuid.New().String()
+	for i := 0; i < 3; i++ {
+		thought, err := cogito.New(ctx, memory, intent)
+		if err != nil {
+			t.Fatalf("failed to create thought: %v", err)
+		}
+		defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+	}
+
+	all, err := memory.GetThoughtsByIntent(ctx, intent, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get thoughts by intent: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 thoughts, got %d", len(all))
+	}
+
+	page, err := memory.GetThoughtsByIntent(ctx, intent, 2, 1)
+	if err != nil {
+		t.Fatalf("failed to get page: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != all[1].ID {
+		t.Errorf("expected page to start at the second thought")
+	}
+
+	matches, err := memory.SearchThoughtsByIntent(ctx, "%"+intent[len("process_"):], 0, 0)
+	if err != nil {
+		t.Fatalf("failed to search thoughts by intent: %v", err)
+	}
+	if len(matches) != 3 {
+		t.Errorf("expected 3 fuzzy matches, got %d", len(matches))
+	}
+}
+
+func TestSoyMemory_GetThoughtsByDateRange(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	from := time.Now().Add(-time.Second)
+	var ids []string
+	for i := 0; i < 3; i++ {
+		thought, err := cogito.New(ctx, memory, "date_range_"+uuid.New().String())
+		if err != nil {
+			t.Fatalf("failed to create thought: %v", err)
+		}
+		if err := thought.SetContent(ctx, "input", "note", "test"); err != nil {
+			t.Fatalf("failed to add note: %v", err)
+		}
+		ids = append(ids, thought.ID)
+		defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+	}
+	to := time.Now().Add(time.Second)
+
+	found, err := memory.GetThoughtsByDateRange(ctx, from, to, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get thoughts by date range: %v", err)
+	}
+	seen := make(map[string]*cogito.Thought)
+	for _, thought := range found {
+		seen[thought.ID] = thought
+	}
+	for _, id := range ids {
+		thought, ok := seen[id]
+		if !ok {
+			t.Fatalf("expected thought %s in range", id)
+		}
+		if thought.NoteCount() != 1 {
+			t.Errorf("expected hydrated notes, got %d", thought.NoteCount())
+		}
+	}
+
+	bare, err := memory.GetThoughtsByDateRange(cogito.WithoutNoteHydration(ctx), from, to, 0, 0)
+	if err != nil {
+		t.Fatalf("failed to get thoughts without notes: %v", err)
+	}
+	for _, thought := range bare {
+		if thought.NoteCount() != 0 {
+			t.Errorf("expected no notes under WithoutNoteHydration, got %d", thought.NoteCount())
+		}
+	}
+
+	if none, _ := memory.GetThoughtsByDateRange(ctx, to.Add(time.Hour), to.Add(2*time.Hour), 0, 0); len(none) != 0 {
+		t.Errorf("expected no thoughts in a future range, got %d", len(none))
+	}
+}
+
+func TestSoyMemory_Resume(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	traceID := uuid.New().String()
+	thought, err := cogito.NewWithTrace(ctx, memory, "support chat", traceID)
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	_ = thought.SetContent(ctx, "message", "hello", "input")
+	_ = thought.SetContent(ctx, "reply", "hi", "respond")
+	thought.MarkNotesPublishedUpTo(1)
+	thought.Session.Append("user", "hello")
+	thought.Session.Append("assistant", "hi")
+	thought.SetPersistentAttr("tenant", "acme")
+	thought.SetAttr("request_id", "req-1")
+	if err := memory.UpdateThought(ctx, thought); err != nil {
+		t.Fatalf("failed to update thought: %v", err)
+	}
+
+	resumed, err := memory.Resume(ctx, traceID)
+	if err != nil {
+		t.Fatalf("failed to resume thought: %v", err)
+	}
+	if resumed.NoteCount() != 2 || resumed.PublishedCount() != 1 {
+		t.Errorf("expected 2 notes with 1 published, got %d and %d", resumed.NoteCount(), resumed.PublishedCount())
+	}
+	if resumed.Session.Len() != 2 {
+		t.Errorf("expected session with 2 messages, got %d", resumed.Session.Len())
+	}
+	if v, ok := resumed.GetAttr("tenant"); !ok || v != "acme" {
+		t.Errorf("expected persistent attribute restored, got %v (%v)", v, ok)
+	}
+	if _, ok := resumed.GetAttr("request_id"); ok {
+		t.Error("expected plain attribute not to be persisted")
+	}
+}
+
+func TestSoyMemory_GetConversation(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	root, err := cogito.New(ctx, memory, "turn 1")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	_ = root.SetContent(ctx, "message", "hello", "test")
+
+	leaf, err := memory.CreateThought(ctx, &cogito.Thought{
+		Intent:    "turn 2",
+		TraceID:   uuid.New().String(),
+		ParentID:  &root.ID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create child thought: %v", err)
+	}
+	defer func() {
+		_ = memory.DeleteThought(ctx, leaf.ID)
+		_ = memory.DeleteThought(ctx, root.ID)
+	}()
+
+	conversation, err := memory.GetConversation(ctx, leaf.ID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	if len(conversation) != 2 {
+		t.Fatalf("expected 2 thoughts, got %d", len(conversation))
+	}
+	if conversation[0].ID != root.ID || conversation[1].ID != leaf.ID {
+		t.Error("expected conversation ordered root to leaf")
+	}
+	if content, err := conversation[0].GetContent("message"); err != nil || content != "hello" {
+		t.Errorf("expected root notes to be hydrated, got %q (err: %v)", content, err)
+	}
+}
+
+func TestSoyMemory_GetConversationCycle(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	var turns []*cogito.Thought
+	for i := 0; i < 3; i++ {
+		turn, err := cogito.New(ctx, memory, "cycle turn")
+		if err != nil {
+			t.Fatalf("failed to create thought: %v", err)
+		}
+		if i > 0 {
+			if err := turn.SetParent(ctx, turns[i-1].ID); err != nil {
+				t.Fatalf("failed to set parent: %v", err)
+			}
+		}
+		turns = append(turns, turn)
+	}
+	defer func() {
+		for _, turn := range turns {
+			_ = turn.SetParent(ctx, "")
+		}
+		for i := len(turns) - 1; i >= 0; i-- {
+			_ = memory.DeleteThought(ctx, turns[i].ID)
+		}
+	}()
+
+	conversation, err := memory.GetConversation(ctx, turns[2].ID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	if len(conversation) != 3 || conversation[0].ID != turns[0].ID || conversation[2].ID != turns[2].ID {
+		t.Fatalf("expected the three turns root to leaf, got %d thoughts", len(conversation))
+	}
+
+	// Closing the loop must be reported, not walked forever
+	if err := turns[0].SetParent(ctx, turns[2].ID); err != nil {
+		t.Fatalf("failed to set parent: %v", err)
+	}
+	if _, err := memory.GetConversation(ctx, turns[2].ID); err == nil {
+		t.Error("expected a cycle error")
+	}
+}
+
+func TestSoyMemory_SetParent(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	parent, err := cogito.New(ctx, memory, "parent intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	child, err := cogito.New(ctx, memory, "child intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() {
+		_ = memory.DeleteThought(ctx, child.ID)
+		_ = memory.DeleteThought(ctx, parent.ID)
+	}()
+
+	// The link is only known after both thoughts exist.
+	if err := child.SetParent(ctx, parent.ID); err != nil {
+		t.Fatalf("failed to set parent: %v", err)
+	}
+
+	conversation, err := memory.GetConversation(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("failed to get conversation: %v", err)
+	}
+	if len(conversation) != 2 || conversation[0].ID != parent.ID {
+		t.Errorf("expected conversation to start at the parent, got %d thoughts", len(conversation))
+	}
+}
+
 func TestSoyMemory_DeleteThought(t *testing.T) {
 	db := getTestDB(t)
 	defer db.Close()
@@ -181,3 +597,216 @@ func TestSoyMemory_DeleteThought(t *testing.T) {
 		t.Error("expected error when getting deleted thought")
 	}
 }
+
+// fixedEmbedder returns a constant embedding matching the notes table dimensions.
+type fixedEmbedder struct{}
+
+func (fixedEmbedder) Embed(_ context.Context, _ string) ([]float32, error) {
+	return make([]float32, 1536), nil
+}
+
+func (fixedEmbedder) Dimensions() int {
+	return 1536
+}
+
+func TestSoyMemory_BackfillEmbeddings(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "test intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	// No embedder configured, so the note is stored without an embedding
+	if err := thought.SetContent(ctx, "key1", "value1", "test"); err != nil {
+		t.Fatalf("failed to set content: %v", err)
+	}
+
+	updated, err := memory.BackfillEmbeddings(ctx, fixedEmbedder{}, 10)
+	if err != nil {
+		t.Fatalf("failed to backfill embeddings: %v", err)
+	}
+	if updated < 1 {
+		t.Errorf("expected at least 1 note updated, got %d", updated)
+	}
+
+	notes, err := memory.GetNotes(ctx, thought.ID)
+	if err != nil {
+		t.Fatalf("failed to get notes: %v", err)
+	}
+	for _, note := range notes {
+		if len(note.Embedding) == 0 {
+			t.Errorf("expected note %q to have an embedding", note.Key)
+		}
+	}
+}
+
+// emptyEmbedder returns no vector, which would store NULL and select the note forever.
+type emptyEmbedder struct {
+	fixedEmbedder
+}
+
+func (emptyEmbedder) Embed(_ context.Context, _ string) ([]float32, error) {
+	return nil, nil
+}
+
+func TestSoyMemory_BackfillEmbeddingsEmptyVector(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "test intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	if err := thought.SetContent(ctx, "key1", "value1", "test"); err != nil {
+		t.Fatalf("failed to set content: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := memory.BackfillEmbeddings(ctx, emptyEmbedder{}, 10)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, cogito.ErrEmptyEmbedding) {
+			t.Errorf("expected ErrEmptyEmbedding, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("BackfillEmbeddings did not return")
+	}
+}
+
+// slowEmbedder is a fixedEmbedder that takes a while to answer.
+type slowEmbedder struct {
+	fixedEmbedder
+}
+
+func (s slowEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	time.Sleep(100 * time.Millisecond)
+	return s.fixedEmbedder.Embed(ctx, text)
+}
+
+func TestSoyMemory_CloseWaitsForEmbeddings(t *testing.T) {
+	memory, err := cogito.NewSoyMemory(getTestDB(t))
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	async := cogito.NewAsyncEmbedder(slowEmbedder{}, 4)
+	defer async.Close()
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "close intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	thought.SetEmbedder(async)
+	if err := thought.SetContent(ctx, "key1", "value1", "test"); err != nil {
+		t.Fatalf("failed to set content: %v", err)
+	}
+
+	// Close must not return until the queued embedding is stored.
+	if err := memory.Close(); err != nil {
+		t.Fatalf("failed to close memory: %v", err)
+	}
+	if err := thought.SetContent(ctx, "key2", "value2", "test"); !errors.Is(err, cogito.ErrMemoryClosed) {
+		t.Errorf("expected ErrMemoryClosed after Close, got %v", err)
+	}
+
+	db := getTestDB(t)
+	defer db.Close()
+	reopened, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+	defer func() { _ = reopened.DeleteThought(ctx, thought.ID) }()
+
+	notes, err := reopened.GetNotes(ctx, thought.ID)
+	if err != nil {
+		t.Fatalf("failed to get notes: %v", err)
+	}
+	if len(notes) != 1 || len(notes[0].Embedding) == 0 {
+		t.Errorf("expected the queued note to be embedded before Close returned, got %+v", notes)
+	}
+}
+
+// modelEmbedder embeds every text as the same unit vector and names its model.
+type modelEmbedder struct {
+	fixedEmbedder
+	model string
+}
+
+func (modelEmbedder) Embed(_ context.Context, _ string) ([]float32, error) {
+	v := make([]float32, 1536)
+	v[0] = 1
+	return v, nil
+}
+
+func (e modelEmbedder) Model() string {
+	return e.model
+}
+
+func TestSoyMemory_SearchNotesEmbeddingSpace(t *testing.T) {
+	db := getTestDB(t)
+	defer db.Close()
+
+	memory, err := cogito.NewSoyMemory(db)
+	if err != nil {
+		t.Fatalf("failed to create memory: %v", err)
+	}
+
+	ctx := context.Background()
+	thought, err := cogito.New(ctx, memory, "search intent")
+	if err != nil {
+		t.Fatalf("failed to create thought: %v", err)
+	}
+	defer func() { _ = memory.DeleteThought(ctx, thought.ID) }()
+
+	prose := modelEmbedder{model: "prose-" + uuid.NewString()}
+	other := modelEmbedder{model: "other-" + uuid.NewString()}
+
+	// Other-model notes are just as close, so a filter applied after LIMIT would lose prose results
+	thought.SetEmbedder(other)
+	for _, key := range []string{"o1", "o2", "o3"} {
+		if err := thought.SetContent(ctx, key, "other", "test"); err != nil {
+			t.Fatalf("failed to set content: %v", err)
+		}
+	}
+	thought.SetEmbedder(prose)
+	for _, key := range []string{"p1", "p2"} {
+		if err := thought.SetContent(ctx, key, "prose", "test"); err != nil {
+			t.Fatalf("failed to set content: %v", err)
+		}
+	}
+
+	query, _ := prose.Embed(ctx, "query")
+	results, err := memory.SearchNotes(cogito.WithEmbeddingSpace(ctx, prose.model), query, 2)
+	if err != nil {
+		t.Fatalf("failed to search notes: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Note.EmbeddingModel != prose.model {
+			t.Errorf("expected only %s notes, got %q from %q", prose.model, r.Note.Key, r.Note.EmbeddingModel)
+		}
+	}
+}
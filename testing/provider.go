@@ -0,0 +1,183 @@
+package cogitotest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zoobzio/cogito"
+	"github.com/zoobzio/zyn"
+)
+
+// FakeProvider implements cogito.Provider for testing. It returns canned responses
+// chosen by substring match on the prompt and records every call.
+//
+// Rules are checked in registration order against the last message of each call;
+// the first match wins. zyn marks introspection prompts with "Transform:", so a
+// typical setup for a step with introspection is:
+//
+//	provider := cogitotest.NewFakeProvider().
+//	    On("Transform:", `{"output": "summary", "confidence": 0.9, "changes": [], "reasoning": []}`).
+//	    Default(`{"decision": true, "confidence": 0.95, "reasoning": ["ok"]}`)
+type FakeProvider struct {
+	mu       sync.Mutex
+	rules    []fakeRule
+	fallback *fakeRule
+	calls    []FakeCall
+}
+
+// FakeCall records a single provider invocation.
+type FakeCall struct {
+	Messages    []zyn.Message
+	Temperature float32
+}
+
+// Prompt returns the content of the call's last message.
+func (c FakeCall) Prompt() string {
+	if len(c.Messages) == 0 {
+		return ""
+	}
+	return c.Messages[len(c.Messages)-1].Content
+}
+
+// fakeRule maps a prompt pattern to a response or error.
+type fakeRule struct {
+	pattern  string
+	response string
+	err      error
+}
+
+// NewFakeProvider creates a FakeProvider with no responses configured.
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+// On returns response for calls whose prompt contains pattern.
+func (f *FakeProvider) On(pattern, response string) *FakeProvider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, fakeRule{pattern: pattern, response: response})
+	return f
+}
+
+// OnError fails calls whose prompt contains pattern with err.
+func (f *FakeProvider) OnError(pattern string, err error) *FakeProvider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, fakeRule{pattern: pattern, err: err})
+	return f
+}
+
+// Default returns response for calls that match no pattern.
+func (f *FakeProvider) Default(response string) *FakeProvider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fallback = &fakeRule{response: response}
+	return f
+}
+
+// Call implements cogito.Provider.
+func (f *FakeProvider) Call(_ context.Context, messages []zyn.Message, temperature float32) (*zyn.ProviderResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	call := FakeCall{
+		Messages:    append([]zyn.Message(nil), messages...),
+		Temperature: temperature,
+	}
+	f.calls = append(f.calls, call)
+
+	rule := f.fallback
+	prompt := call.Prompt()
+	for i := range f.rules {
+		if strings.Contains(prompt, f.rules[i].pattern) {
+			rule = &f.rules[i]
+			break
+		}
+	}
+
+	if rule == nil {
+		return nil, fmt.Errorf("fake provider: no response configured for prompt: %q", prompt)
+	}
+	if rule.err != nil {
+		return nil, rule.err
+	}
+	return &zyn.ProviderResponse{
+		Content: rule.response,
+		Usage: zyn.TokenUsage{
+			Prompt:     len(prompt),
+			Completion: len(rule.response),
+			Total:      len(prompt) + len(rule.response),
+		},
+	}, nil
+}
+
+// Name implements cogito.Provider.
+func (f *FakeProvider) Name() string {
+	return "fake"
+}
+
+// CallCount returns the number of calls received.
+func (f *FakeProvider) CallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+// Calls returns a copy of all recorded calls in order.
+func (f *FakeProvider) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCall(nil), f.calls...)
+}
+
+// LastMessages returns the messages sent in the most recent call, or nil if none.
+func (f *FakeProvider) LastMessages() []zyn.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.calls) == 0 {
+		return nil
+	}
+	return f.calls[len(f.calls)-1].Messages
+}
+
+// Reset clears recorded calls, keeping configured responses.
+func (f *FakeProvider) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// Verify FakeProvider implements cogito.Provider.
+var _ cogito.Provider = (*FakeProvider)(nil)
+
+// RequireCallCount asserts that the provider received exactly n calls.
+func RequireCallCount(t *testing.T, f *FakeProvider, n int) {
+	t.Helper()
+	if got := f.CallCount(); got != n {
+		t.Fatalf("expected %d provider calls, got %d", n, got)
+	}
+}
+
+// RequirePromptContains asserts that at least one call's prompt contains substr.
+func RequirePromptContains(t *testing.T, f *FakeProvider, substr string) {
+	t.Helper()
+	for _, call := range f.Calls() {
+		if strings.Contains(call.Prompt(), substr) {
+			return
+		}
+	}
+	t.Fatalf("expected a prompt containing %q, none of %d calls matched", substr, f.CallCount())
+}
+
+// RequireNoPromptContains asserts that no call's prompt contains substr.
+func RequireNoPromptContains(t *testing.T, f *FakeProvider, substr string) {
+	t.Helper()
+	for i, call := range f.Calls() {
+		if strings.Contains(call.Prompt(), substr) {
+			t.Fatalf("expected no prompt containing %q, call %d matched", substr, i)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cogitotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zoobzio/cogito"
+	"github.com/zoobzio/zyn"
+)
+
+func TestFakeProvider(t *testing.T) {
+	t.Run("drives a primitive", func(t *testing.T) {
+		provider := NewFakeProvider().
+			On("Transform:", `{"output": "Urgent outage", "confidence": 0.9, "changes": [], "reasoning": []}`).
+			Default(`{"decision": true, "confidence": 0.95, "reasoning": ["Production is down"]}`)
+
+		step := cogito.NewDecide("is_urgent", "Is this urgent?").
+			WithProvider(provider).
+			WithIntrospection()
+
+		thought := NewTestThought(t, "fake provider")
+		result, err := step.Process(context.Background(), thought)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		RequireCallCount(t, provider, 2)
+		RequirePromptContains(t, provider, "Is this urgent?")
+		RequireNoPromptContains(t, provider, "Categories:")
+		RequireContent(t, result, "is_urgent_summary", "Urgent outage")
+
+		if msgs := provider.LastMessages(); len(msgs) == 0 {
+			t.Error("expected last messages to be recorded")
+		}
+	})
+
+	t.Run("errors and unmatched prompts", func(t *testing.T) {
+		boom := errors.New("boom")
+		provider := NewFakeProvider().OnError("explode", boom)
+		ctx := context.Background()
+
+		_, err := provider.Call(ctx, []zyn.Message{{Role: zyn.RoleUser, Content: "please explode"}}, 0.1)
+		if !errors.Is(err, boom) {
+			t.Errorf("expected configured error, got %v", err)
+		}
+
+		if _, err := provider.Call(ctx, []zyn.Message{{Role: zyn.RoleUser, Content: "anything else"}}, 0.1); err == nil {
+			t.Error("expected error for unmatched prompt without default")
+		}
+
+		calls := provider.Calls()
+		if len(calls) != 2 || calls[0].Temperature != 0.1 {
+			t.Errorf("unexpected recorded calls: %+v", calls)
+		}
+
+		provider.Reset()
+		RequireCallCount(t, provider, 0)
+	})
+}
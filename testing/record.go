@@ -0,0 +1,184 @@
+package cogitotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zoobzio/cogito"
+	"github.com/zoobzio/pipz"
+)
+
+// UpdateGoldenEnv names the environment variable that makes RequireGolden rewrite
+// golden files instead of comparing against them.
+const UpdateGoldenEnv = "COGITO_UPDATE_GOLDEN"
+
+// StepSnapshot captures a single step execution for golden-file comparison.
+// Volatile note fields (IDs, timestamps, embeddings) are omitted so snapshots
+// are stable across runs.
+type StepSnapshot struct {
+	Step    string         `json:"step"`
+	Context string         `json:"context"`
+	Input   []SnapshotNote `json:"input"`
+	Output  []SnapshotNote `json:"output"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// SnapshotNote is the stable subset of a cogito.Note.
+type SnapshotNote struct {
+	Key      string            `json:"key"`
+	Content  string            `json:"content"`
+	Source   string            `json:"source"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// StepRecorder wraps a step and writes a StepSnapshot for every Process call.
+// It implements pipz.Chainable[*cogito.Thought], so it can stand in for the step
+// anywhere in a pipeline.
+type StepRecorder struct {
+	step pipz.Chainable[*cogito.Thought]
+	w    io.Writer
+	mu   sync.Mutex
+}
+
+// RecordStep wraps step so that each execution writes an indented JSON StepSnapshot
+// to w: the unpublished context the step received, the notes it started with, and
+// the notes it added. Pair it with FakeProvider and RequireGolden to pin a
+// primitive's behavior as prompts evolve:
+//
+//	var buf bytes.Buffer
+//	step := cogitotest.RecordStep(cogito.NewDecide("urgent", "Is this urgent?").WithProvider(fake), &buf)
+//	step.Process(ctx, thought)
+//	cogitotest.RequireGolden(t, "testdata/urgent.golden.json", buf.Bytes())
+func RecordStep(step pipz.Chainable[*cogito.Thought], w io.Writer) *StepRecorder {
+	return &StepRecorder{step: step, w: w}
+}
+
+// Process runs the wrapped step and records its input and output.
+// A failure to write the snapshot is returned as the step's error.
+func (r *StepRecorder) Process(ctx context.Context, t *cogito.Thought) (*cogito.Thought, error) {
+	before := t.AllNotes()
+	snapshot := StepSnapshot{
+		Step:    r.step.Identity().Name(),
+		Context: cogito.RenderNotesToContext(t.GetUnpublishedNotes()),
+		Input:   snapshotNotes(before),
+	}
+
+	result, err := r.step.Process(ctx, t)
+
+	out := result
+	if out == nil {
+		out = t
+	}
+	if after := out.AllNotes(); len(after) > len(before) {
+		snapshot.Output = snapshotNotes(after[len(before):])
+	} else {
+		snapshot.Output = []SnapshotNote{}
+	}
+	if err != nil {
+		snapshot.Error = err.Error()
+	}
+
+	if writeErr := r.write(snapshot); writeErr != nil {
+		return result, fmt.Errorf("record step: %w", writeErr)
+	}
+	return result, err
+}
+
+// write encodes a snapshot to the writer.
+func (r *StepRecorder) write(snapshot StepSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(append(data, '\n'))
+	return err
+}
+
+// Identity implements pipz.Chainable[*cogito.Thought].
+func (r *StepRecorder) Identity() pipz.Identity {
+	return r.step.Identity()
+}
+
+// Schema implements pipz.Chainable[*cogito.Thought].
+func (r *StepRecorder) Schema() pipz.Node {
+	return r.step.Schema()
+}
+
+// Close implements pipz.Chainable[*cogito.Thought].
+func (r *StepRecorder) Close() error {
+	return r.step.Close()
+}
+
+// snapshotNotes strips volatile fields from notes.
+func snapshotNotes(notes []cogito.Note) []SnapshotNote {
+	out := make([]SnapshotNote, len(notes))
+	for i, note := range notes {
+		out[i] = SnapshotNote{
+			Key:      note.Key,
+			Content:  note.Content,
+			Source:   note.Source,
+			Metadata: note.Metadata,
+		}
+	}
+	return out
+}
+
+// RequireGolden asserts that got matches the golden file at path.
+// When the COGITO_UPDATE_GOLDEN environment variable is set, the file is
+// (re)written with got instead.
+func RequireGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+	if diff := firstDiff(string(want), string(got)); diff != "" {
+		t.Fatalf("output does not match golden file %s (set %s=1 to update):\n%s", path, UpdateGoldenEnv, diff)
+	}
+}
+
+// firstDiff describes the first differing line between want and got, or returns "" if equal.
+func firstDiff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return ""
+}
+
+// Verify StepRecorder implements pipz.Chainable.
+var _ pipz.Chainable[*cogito.Thought] = (*StepRecorder)(nil)
@@ -0,0 +1,75 @@
+package cogitotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/zoobzio/cogito"
+)
+
+func TestRecordStep(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider().
+		Default(`{"decision": true, "confidence": 0.9, "reasoning": ["outage reported"]}`)
+
+	thought := NewTestThought(t, "triage ticket")
+	if err := thought.SetContent(ctx, "ticket", "Checkout is down", "input"); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	step := RecordStep(cogito.NewDecide("urgent", "Is this urgent?").WithProvider(provider), &buf)
+	if _, err := step.Process(ctx, thought); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	var snapshot StepSnapshot
+	if err := json.Unmarshal(buf.Bytes(), &snapshot); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if snapshot.Step != "urgent" {
+		t.Errorf("expected step 'urgent', got %q", snapshot.Step)
+	}
+	if !strings.Contains(snapshot.Context, "Checkout is down") {
+		t.Errorf("expected input context to be recorded, got %q", snapshot.Context)
+	}
+	if len(snapshot.Input) != 1 || snapshot.Input[0].Key != "ticket" {
+		t.Errorf("expected ticket as the only input note, got %+v", snapshot.Input)
+	}
+	if len(snapshot.Output) != 1 || snapshot.Output[0].Key != "urgent" {
+		t.Errorf("expected urgent as the only output note, got %+v", snapshot.Output)
+	}
+
+	RequireGolden(t, "testdata/decide.golden.json", buf.Bytes())
+}
+
+func TestRecordStepError(t *testing.T) {
+	provider := NewFakeProvider() // no responses configured
+
+	var buf bytes.Buffer
+	step := RecordStep(cogito.NewDecide("urgent", "Is this urgent?").WithProvider(provider), &buf)
+	if _, err := step.Process(context.Background(), NewTestThought(t, "triage ticket")); err == nil {
+		t.Fatal("expected step to fail")
+	}
+
+	var snapshot StepSnapshot
+	if err := json.Unmarshal(buf.Bytes(), &snapshot); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if snapshot.Error == "" {
+		t.Error("expected error to be recorded")
+	}
+}
+
+func TestFirstDiff(t *testing.T) {
+	if diff := firstDiff("a\nb\n", "a\nb\n"); diff != "" {
+		t.Errorf("expected no diff, got %q", diff)
+	}
+	diff := firstDiff("a\nb\n", "a\nc\n")
+	if !strings.Contains(diff, "line 2") || !strings.Contains(diff, "want: b") || !strings.Contains(diff, "got:  c") {
+		t.Errorf("unexpected diff: %q", diff)
+	}
+}
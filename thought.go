@@ -2,13 +2,17 @@ package cogito
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
-	"github.com/google/uuid"
 	"github.com/zoobzio/capitan"
 	"github.com/zoobzio/zyn"
 )
@@ -16,15 +20,21 @@ import (
 // Note represents a semantic piece of information in the reasoning chain.
 // Everything in LLM space is fundamentally text-based, so Content is always a string.
 // Metadata provides structured extension without breaking type safety.
+// Seq is assigned by AddNote and increases with every note added to a thought or
+// any of its clones, so it is unique within the thought; it breaks ties between
+// notes with the same Created time. EmbeddingModel names
+// the ModelEmbedder behind Embedding, if any, so searches keep to one embedding space.
 type Note struct {
-	ID        string            `db:"id" type:"uuid" constraints:"primarykey" default:"gen_random_uuid()"`
-	ThoughtID string            `db:"thought_id" type:"uuid" constraints:"notnull" references:"thoughts(id)"`
-	Key       string            `db:"key" type:"text" constraints:"notnull"`
-	Content   string            `db:"content" type:"text" constraints:"notnull"`
-	Metadata  map[string]string `db:"metadata" type:"jsonb" default:"'{}'"`
-	Source    string            `db:"source" type:"text" constraints:"notnull"`
-	Created   time.Time         `db:"created" type:"timestamp" constraints:"notnull"`
-	Embedding Vector            `db:"embedding" type:"vector(1536)"`
+	ID             string            `db:"id" type:"uuid" constraints:"primarykey" default:"gen_random_uuid()"`
+	ThoughtID      string            `db:"thought_id" type:"uuid" constraints:"notnull" references:"thoughts(id)"`
+	Key            string            `db:"key" type:"text" constraints:"notnull"`
+	Content        string            `db:"content" type:"text" constraints:"notnull"`
+	Metadata       map[string]string `db:"metadata" type:"jsonb" default:"'{}'"`
+	Source         string            `db:"source" type:"text" constraints:"notnull"`
+	Created        time.Time         `db:"created" type:"timestamp" constraints:"notnull"`
+	Seq            int64             `db:"seq" type:"bigint" constraints:"notnull" default:"0"`
+	EmbeddingModel string            `db:"embedding_model" type:"text" constraints:"notnull" default:"''"`
+	Embedding      Vector            `db:"embedding" type:"vector"`
 }
 
 // Thought represents the rolling context of a chain of thought.
@@ -60,26 +70,35 @@ type Thought struct {
 	Session *zyn.Session // Shared session for LLM continuity (not persisted)
 
 	// Persistence
-	memory   Memory   // Reference to memory for note persistence
-	embedder Embedder // Reference to embedder for note embeddings (optional)
+	memory    Memory              // Reference to memory for note persistence
+	embedder  Embedder            // Reference to embedder for note embeddings (optional)
+	embedders map[string]Embedder // Embedders by note content_type metadata (optional)
+	pending   pendingWork         // Notes queued on an AsyncEmbedder
 
 	// Append-only note history
 	notes          []Note
-	publishedCount int      // Number of notes that have been sent to LLM
+	publishedCount int                 // Number of notes that have been sent to LLM
+	superseded     map[string]struct{} // IDs of notes withheld from unpublished context
+	seq            *atomic.Int64       // Seq of the last note added, shared with clones; never decreases
+	seqOnce        sync.Once
 	index          sync.Map // map[string]int for quick lookup by key (most recent)
 	mu             sync.RWMutex
 
+	// Operational attributes (never rendered to LLM context; persisted only when opted in)
+	attrs          sync.Map
+	persistedAttrs sync.Map // keys set with SetPersistentAttr
+
 	// Timestamps
 	CreatedAt time.Time `db:"created_at" type:"timestamp" constraints:"notnull"`
 	UpdatedAt time.Time `db:"updated_at" type:"timestamp" constraints:"notnull"`
 }
 
 // New creates a new Thought with the given intent and persists it.
-// TraceID is auto-generated using UUID. ID is assigned by the database.
+// TraceID is generated by NewID (a UUID unless SetIDGenerator is used). ID is assigned by the database.
 func New(ctx context.Context, memory Memory, intent string) (*Thought, error) {
 	t := &Thought{
 		Intent:         intent,
-		TraceID:        uuid.New().String(),
+		TraceID:        NewID(),
 		Session:        zyn.NewSession(),
 		memory:         memory,
 		notes:          make([]Note, 0),
@@ -135,11 +154,36 @@ func NewWithTrace(ctx context.Context, memory Memory, intent, traceID string) (*
 	return t, nil
 }
 
+// GetOrCreateByTrace returns the thought for traceID, hydrated with its notes, or
+// creates and persists a new one if the lookup reports ErrNotFound. Any other lookup
+// error is returned, so a database outage never produces a duplicate thought. It
+// makes redelivered messages safe under at-least-once delivery. If the create fails
+// because a concurrent caller inserted the trace first, that thought is loaded and
+// returned instead.
+func GetOrCreateByTrace(ctx context.Context, memory Memory, intent, traceID string) (*Thought, error) {
+	existing, err := memory.GetThoughtByTraceID(ctx, traceID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to look up trace %s: %w", traceID, err)
+	}
+
+	t, err := NewWithTrace(ctx, memory, intent, traceID)
+	if err != nil {
+		if existing, getErr := memory.GetThoughtByTraceID(ctx, traceID); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
 // NewForTask creates a new Thought associated with a task and persists it.
 func NewForTask(ctx context.Context, memory Memory, intent, taskID string) (*Thought, error) {
 	t := &Thought{
 		Intent:         intent,
-		TraceID:        uuid.New().String(),
+		TraceID:        NewID(),
 		TaskID:         &taskID,
 		Session:        zyn.NewSession(),
 		memory:         memory,
@@ -164,9 +208,43 @@ func NewForTask(ctx context.Context, memory Memory, intent, taskID string) (*Tho
 	return t, nil
 }
 
+// NewFromNotes creates a new Thought pre-loaded with notes and persists both.
+// It is intended for rehydrating state from non-database sources such as a message queue payload.
+// Notes keep their Key, Content, Source, Metadata and Created time; IDs and ThoughtID are reassigned.
+// publishedCount marks how many leading notes have already been sent to the LLM and is
+// clamped to the range [0, len(notes)].
+func NewFromNotes(ctx context.Context, memory Memory, intent string, notes []Note, publishedCount int) (*Thought, error) {
+	t, err := New(ctx, memory, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, note := range notes {
+		note.ID = ""
+		if err := t.AddNote(ctx, note); err != nil {
+			return nil, fmt.Errorf("failed to add note %q: %w", note.Key, err)
+		}
+	}
+
+	if publishedCount < 0 {
+		publishedCount = 0
+	}
+	if publishedCount > len(notes) {
+		publishedCount = len(notes)
+	}
+	t.SetPublishedCount(publishedCount)
+
+	return t, nil
+}
+
 // AddNote adds a new note to the thought and persists it.
 // If a note with the same key exists, the new note becomes the current value.
 // If an embedder is configured, the note content will be embedded for semantic search.
+// A note whose "content_type" metadata has an embedder registered with SetEmbedderFor
+// is embedded by that embedder instead.
+// With an AsyncEmbedder the note is persisted first and embedded in the background.
+// The note is given the next Seq, so notes created in the same instant keep their
+// order when the thought is reloaded.
 func (t *Thought) AddNote(ctx context.Context, note Note) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -175,10 +253,16 @@ func (t *Thought) AddNote(ctx context.Context, note Note) error {
 		note.Created = time.Now()
 	}
 	note.ThoughtID = t.ID
-
-	// Generate embedding if embedder is available
-	embedder, err := ResolveEmbedder(ctx, t.embedder)
-	if err == nil && embedder != nil {
+	note.Seq = t.seqCounter().Add(1)
+
+	// Generate embedding if embedder is available, deferring it for async embedders
+	var async *AsyncEmbedder
+	embedder, err := t.noteEmbedder(ctx, note)
+	if a, ok := embedder.(*AsyncEmbedder); ok && err == nil && a.accepting() {
+		async = a
+		note.Metadata = withEmbeddingModel(note.Metadata, embedder)
+		note.EmbeddingModel = EmbeddingModel(embedder)
+	} else if err == nil && embedder != nil {
 		embedding, embedErr := embedder.Embed(ctx, note.Content)
 		if embedErr != nil {
 			// Log but don't fail - embedding is optional
@@ -189,6 +273,8 @@ func (t *Thought) AddNote(ctx context.Context, note Note) error {
 			)
 		} else {
 			note.Embedding = embedding
+			note.Metadata = withEmbeddingModel(note.Metadata, embedder)
+			note.EmbeddingModel = EmbeddingModel(embedder)
 		}
 	}
 
@@ -201,8 +287,13 @@ func (t *Thought) AddNote(ctx context.Context, note Note) error {
 
 	t.notes = append(t.notes, note)
 	t.index.Store(note.Key, len(t.notes)-1)
+	t.recordSupersedes(note)
 	t.UpdatedAt = time.Now()
 
+	if async != nil {
+		async.enqueue(ctx, t, note)
+	}
+
 	// Emit note added event
 	capitan.Emit(ctx, NoteAdded,
 		FieldTraceID.Field(t.TraceID),
@@ -215,6 +306,56 @@ func (t *Thought) AddNote(ctx context.Context, note Note) error {
 	return nil
 }
 
+// Flush waits until every note this thought queued on an AsyncEmbedder has been
+// embedded and stored, or has failed. It returns ctx.Err() if ctx ends first; the
+// queued work carries on regardless. Call it before handing the thought to code that
+// searches its notes, or before shutdown.
+func (t *Thought) Flush(ctx context.Context) error {
+	select {
+	case <-t.pending.drained():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// noteEmbedder picks the embedder for note: the one registered for its
+// content_type metadata, otherwise the thought's embedder resolution.
+// Callers must hold t.mu.
+func (t *Thought) noteEmbedder(ctx context.Context, note Note) (Embedder, error) {
+	if contentType := note.Metadata["content_type"]; contentType != "" {
+		if e, ok := t.embedders[contentType]; ok {
+			return e, nil
+		}
+	}
+	return ResolveEmbedder(ctx, t.embedder)
+}
+
+// withEmbeddingModel records the model behind e as "embedding_model" metadata,
+// so searches can keep to one embedding space. The map is copied, never modified.
+func withEmbeddingModel(metadata map[string]string, e Embedder) map[string]string {
+	model := EmbeddingModel(e)
+	if model == "" {
+		return metadata
+	}
+	metadata = copyMetadata(metadata)
+	metadata["embedding_model"] = model
+	return metadata
+}
+
+// setNoteEmbedding updates the in-memory embedding of a persisted note by ID.
+func (t *Thought) setNoteEmbedding(noteID string, embedding Vector) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := len(t.notes) - 1; i >= 0; i-- {
+		if t.notes[i].ID == noteID {
+			t.notes[i].Embedding = embedding
+			return
+		}
+	}
+}
+
 // SetContent adds a simple note with just key and content.
 func (t *Thought) SetContent(ctx context.Context, key, content, source string) error {
 	return t.AddNote(ctx, Note{
@@ -267,6 +408,28 @@ func (t *Thought) GetContent(key string) (string, error) {
 	return note.Content, nil
 }
 
+// GetContentMulti retrieves the content of the most recent note for each key.
+// It returns the contents found and the keys that had no note, in argument order,
+// so callers can validate required inputs up front:
+//
+//	contents, missing := t.GetContentMulti("ticket", "customer", "history")
+//	if len(missing) > 0 {
+//	    return t, fmt.Errorf("missing inputs: %v", missing)
+//	}
+func (t *Thought) GetContentMulti(keys ...string) (map[string]string, []string) {
+	contents := make(map[string]string, len(keys))
+	var missing []string
+	for _, key := range keys {
+		note, ok := t.GetNote(key)
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		contents[key] = note.Content
+	}
+	return contents, missing
+}
+
 // GetMetadata retrieves a specific metadata field from the most recent note.
 func (t *Thought) GetMetadata(key, field string) (string, error) {
 	note, ok := t.GetNote(key)
@@ -282,6 +445,65 @@ func (t *Thought) GetMetadata(key, field string) (string, error) {
 	return value, nil
 }
 
+// SetAttr attaches an operational attribute to the thought, such as a tenant ID or feature flag.
+// Attributes travel with the thought (including through Clone) and are available to processors,
+// but are never rendered into LLM context or persisted. Use SetPersistentAttr when the value
+// should survive a Resume, or store a note when it should inform reasoning.
+func (t *Thought) SetAttr(key string, v any) {
+	t.attrs.Store(key, v)
+	t.persistedAttrs.Delete(key)
+}
+
+// SetPersistentAttr attaches an attribute like SetAttr and opts it into persistence:
+// UpdateThought saves it as JSON and Resume restores it. v must marshal to JSON, and a
+// resumed thought holds its decoded JSON form (numbers as float64, objects as map[string]any).
+// It is still never rendered into LLM context.
+func (t *Thought) SetPersistentAttr(key string, v any) {
+	t.attrs.Store(key, v)
+	t.persistedAttrs.Store(key, struct{}{})
+}
+
+// GetAttr retrieves an operational attribute set with SetAttr.
+func (t *Thought) GetAttr(key string) (any, bool) {
+	return t.attrs.Load(key)
+}
+
+// GetReasoning returns the reasoning steps recorded for the most recent note with the given key.
+// Sequential reasoning_0, reasoning_1, ... metadata fields are collected in order when present;
+// otherwise the "reasoning" array of the JSON note content is used, which covers the
+// responses stored by Decide, Categorize, Assess, Prioritize, Discern and similar steps.
+// Returns nil if the note is missing or carries no reasoning.
+func (t *Thought) GetReasoning(key string) []string {
+	note, ok := t.GetNote(key)
+	if !ok {
+		return nil
+	}
+	return noteReasoning(note)
+}
+
+// noteReasoning extracts the reasoning steps recorded on note, as GetReasoning describes.
+func noteReasoning(note Note) []string {
+	var reasoning []string
+	for i := 0; ; i++ {
+		step, ok := note.Metadata["reasoning_"+strconv.Itoa(i)]
+		if !ok {
+			break
+		}
+		reasoning = append(reasoning, step)
+	}
+	if len(reasoning) > 0 {
+		return reasoning
+	}
+
+	var content struct {
+		Reasoning []string `json:"reasoning"`
+	}
+	if err := json.Unmarshal([]byte(note.Content), &content); err != nil {
+		return nil
+	}
+	return content.Reasoning
+}
+
 // GetLatestNote returns the most recently added note.
 func (t *Thought) GetLatestNote() (Note, bool) {
 	t.mu.RLock()
@@ -304,6 +526,32 @@ func (t *Thought) AllNotes() []Note {
 	return notes
 }
 
+// Walk calls fn for each note in chronological order without copying the note
+// slice, stopping early when fn returns false. The thought's read lock is held
+// for the whole traversal, so fn must not modify the thought.
+func (t *Thought) Walk(fn func(Note) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, note := range t.notes {
+		if !fn(note) {
+			return
+		}
+	}
+}
+
+// NoteCount returns the number of notes without copying the note slice.
+func (t *Thought) NoteCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.notes)
+}
+
+// IsEmpty reports whether the thought has no notes yet.
+func (t *Thought) IsEmpty() bool {
+	return t.NoteCount() == 0
+}
+
 // GetBool parses the content as a boolean ("true"/"false").
 func (t *Thought) GetBool(key string) (bool, error) {
 	content, err := t.GetContent(key)
@@ -351,6 +599,29 @@ func (t *Thought) GetInt(key string) (int, error) {
 	return i, nil
 }
 
+// SetContentBytes stores data as a base64-encoded (standard encoding) note,
+// tagged with "encoding" metadata of "base64". Read it back with GetContentBytes.
+func (t *Thought) SetContentBytes(ctx context.Context, key string, data []byte, source string) error {
+	return t.SetNote(ctx, key, base64.StdEncoding.EncodeToString(data), source, map[string]string{
+		"encoding": "base64",
+	})
+}
+
+// GetContentBytes decodes the base64 content of the most recent note with the given key.
+func (t *Thought) GetContentBytes(key string) ([]byte, error) {
+	content, err := t.GetContent(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode %q as base64: %w", key, err)
+	}
+
+	return data, nil
+}
+
 // Clone creates a deep copy of the thought for concurrent processing.
 // Required for pipz.Concurrent and other parallel operations.
 //
@@ -374,8 +645,11 @@ func (t *Thought) Clone() *Thought {
 		Session:        zyn.NewSession(),
 		memory:         t.memory,
 		embedder:       t.embedder,
+		embedders:      make(map[string]Embedder, len(t.embedders)),
 		notes:          make([]Note, len(t.notes)),
 		publishedCount: t.publishedCount,
+		seq:            t.seqCounter(),
+		superseded:     make(map[string]struct{}, len(t.superseded)),
 		CreatedAt:      t.CreatedAt,
 		UpdatedAt:      time.Now(),
 	}
@@ -383,6 +657,13 @@ func (t *Thought) Clone() *Thought {
 	// Copy session messages (Session.Messages() returns a copy and is internally synchronized)
 	clone.Session.SetMessages(t.Session.Messages())
 
+	for contentType, e := range t.embedders {
+		clone.embedders[contentType] = e
+	}
+	for id := range t.superseded {
+		clone.superseded[id] = struct{}{}
+	}
+
 	// Deep copy notes (Note is value type, but Metadata is map and Embedding is slice)
 	for i, note := range t.notes {
 		clonedMeta := make(map[string]string, len(note.Metadata))
@@ -395,14 +676,16 @@ func (t *Thought) Clone() *Thought {
 			copy(clonedEmbedding, note.Embedding)
 		}
 		clone.notes[i] = Note{
-			ID:        note.ID,
-			ThoughtID: note.ThoughtID,
-			Key:       note.Key,
-			Content:   note.Content,
-			Metadata:  clonedMeta,
-			Source:    note.Source,
-			Created:   note.Created,
-			Embedding: clonedEmbedding,
+			ID:             note.ID,
+			ThoughtID:      note.ThoughtID,
+			Key:            note.Key,
+			Content:        note.Content,
+			Metadata:       clonedMeta,
+			Source:         note.Source,
+			Created:        note.Created,
+			Seq:            note.Seq,
+			EmbeddingModel: note.EmbeddingModel,
+			Embedding:      clonedEmbedding,
 		}
 	}
 
@@ -411,9 +694,87 @@ func (t *Thought) Clone() *Thought {
 		clone.index.Store(note.Key, i)
 	}
 
+	// Copy attributes (values are shared, not deep copied)
+	t.attrs.Range(func(k, v any) bool {
+		clone.attrs.Store(k, v)
+		return true
+	})
+	t.persistedAttrs.Range(func(k, v any) bool {
+		clone.persistedAttrs.Store(k, v)
+		return true
+	})
+
 	return clone
 }
 
+// NoteCheckpoint marks a position in a thought's note log for in-memory backtracking.
+// Unlike the Checkpoint primitive, it creates no persisted thought.
+type NoteCheckpoint struct {
+	noteCount      int
+	publishedCount int
+}
+
+// Checkpoint captures the current note count and publish state.
+// Pass the result to Restore to discard notes added afterwards.
+//
+// Example:
+//
+//	cp := thought.Checkpoint()
+//	result, err := speculativeChain.Process(ctx, thought)
+//	if err != nil {
+//	    thought.Restore(cp)
+//	}
+func (t *Thought) Checkpoint() NoteCheckpoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return NoteCheckpoint{
+		noteCount:      len(t.notes),
+		publishedCount: t.publishedCount,
+	}
+}
+
+// Restore truncates the note log back to a checkpoint and rebuilds the key index
+// and publish count. Only in-memory state is affected: notes already written to
+// memory and session messages are left untouched.
+func (t *Thought) Restore(cp NoteCheckpoint) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if cp.noteCount < len(t.notes) {
+		t.notes = t.notes[:cp.noteCount:cp.noteCount]
+	}
+	t.publishedCount = min(cp.publishedCount, len(t.notes))
+
+	// Rebuild index
+	t.index.Range(func(key, _ any) bool {
+		t.index.Delete(key)
+		return true
+	})
+	t.superseded = nil
+	for i, note := range t.notes {
+		t.index.Store(note.Key, i)
+		t.recordSupersedes(note)
+	}
+}
+
+// ClearNotes discards every note and resets the key index and publish count,
+// giving a fresh reasoning slate while keeping the thought's identity, memory,
+// embedder, session and attributes. Only in-memory state is affected: notes
+// already written to memory are left untouched.
+func (t *Thought) ClearNotes() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.notes = nil
+	t.publishedCount = 0
+	t.superseded = nil
+	t.index.Range(func(key, _ any) bool {
+		t.index.Delete(key)
+		return true
+	})
+	t.UpdatedAt = time.Now()
+}
+
 // PublishedCount returns the number of notes that have been published to the LLM.
 func (t *Thought) PublishedCount() int {
 	t.mu.RLock()
@@ -439,11 +800,191 @@ func (t *Thought) GetUnpublishedNotes() []Note {
 		return []Note{}
 	}
 
-	unpublished := make([]Note, len(t.notes)-t.publishedCount)
-	copy(unpublished, t.notes[t.publishedCount:])
+	unpublished := make([]Note, 0, len(t.notes)-t.publishedCount)
+	for _, note := range t.notes[t.publishedCount:] {
+		if _, ok := t.superseded[note.ID]; !ok {
+			unpublished = append(unpublished, note)
+		}
+	}
 	return unpublished
 }
 
+// SupersedesMetadataKey is the metadata key under which a note lists, as
+// comma-separated note IDs, the notes it replaces in unpublished context. Redact
+// and Normalize set it so the raw note never reaches the next prompt.
+const SupersedesMetadataKey = "supersedes"
+
+// unpublishedIDs returns the IDs of the unpublished notes at key, formatted
+// for SupersedesMetadataKey.
+func (t *Thought) unpublishedIDs(key string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var ids []string
+	for _, note := range t.notes[min(t.publishedCount, len(t.notes)):] {
+		if note.Key == key && note.ID != "" {
+			ids = append(ids, note.ID)
+		}
+	}
+	return strings.Join(ids, ",")
+}
+
+// isSuperseded reports whether the note with the given ID is withheld from
+// unpublished context by a later note.
+func (t *Thought) isSuperseded(id string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.superseded[id]
+	return ok
+}
+
+// recordSupersedes withholds the notes listed in note's SupersedesMetadataKey
+// from unpublished context. Callers must hold t.mu.
+func (t *Thought) recordSupersedes(note Note) {
+	list := note.Metadata[SupersedesMetadataKey]
+	if list == "" {
+		return
+	}
+	if t.superseded == nil {
+		t.superseded = make(map[string]struct{})
+	}
+	for _, id := range strings.Split(list, ",") {
+		if id != "" {
+			t.superseded[id] = struct{}{}
+		}
+	}
+}
+
+// mergeNote adds a note produced on a clone of t, such as a Converge branch, with
+// the given source. The note's SupersedesMetadataKey list may name notes the clone
+// added, whose copies in t have new IDs; merged maps those clone note IDs to their
+// copies and is extended with this note, so merge a clone's notes in order. IDs of
+// notes t already held before the clone are left as they are.
+func (t *Thought) mergeNote(ctx context.Context, note Note, source string, merged map[string]string) error {
+	metadata := note.Metadata
+	if list := metadata[SupersedesMetadataKey]; list != "" {
+		metadata = copyMetadata(metadata)
+		ids := strings.Split(list, ",")
+		for i, id := range ids {
+			if copied, ok := merged[id]; ok {
+				ids[i] = copied
+			}
+		}
+		metadata[SupersedesMetadataKey] = strings.Join(ids, ",")
+	}
+	if err := t.SetNote(ctx, note.Key, note.Content, source, metadata); err != nil {
+		return err
+	}
+	if copied, ok := t.GetLatestNote(); ok {
+		merged[note.ID] = copied.ID
+	}
+	return nil
+}
+
+// ContextSize returns the character (rune) length of the context the next step would
+// receive, i.e. the unpublished notes as rendered by RenderNotesToContext.
+func (t *Thought) ContextSize() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.publishedCount >= len(t.notes) {
+		return 0
+	}
+	if len(t.superseded) == 0 {
+		return renderedSize(t.notes[t.publishedCount:])
+	}
+	var visible []Note
+	for _, note := range t.notes[t.publishedCount:] {
+		if _, ok := t.superseded[note.ID]; !ok {
+			visible = append(visible, note)
+		}
+	}
+	return renderedSize(visible)
+}
+
+// ContextSizeAll returns the character (rune) length of the full note log as rendered by RenderNotesToContext.
+func (t *Thought) ContextSizeAll() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return renderedSize(t.notes)
+}
+
+// renderedSize counts the runes of RenderNotesToContext(notes) without building the string.
+func renderedSize(notes []Note) int {
+	if len(notes) == 0 {
+		return 0
+	}
+	size := len(notes) - 1 // newline separators
+	for _, note := range notes {
+		size += utf8.RuneCountInString(note.Key) + len(": ") + utf8.RuneCountInString(note.Content)
+	}
+	return size
+}
+
+// TrimSession drops the oldest session turns so at most maxMessages remain, and
+// returns how many messages were removed. A turn is a user message with the replies
+// that follow it, and is dropped whole so the model never sees an answer without
+// its question; an odd maxMessages can therefore leave one message fewer. System
+// messages are always kept, so the session can exceed maxMessages only when it
+// holds more system messages than that.
+// Use it to stop a long chain's accumulated session from overflowing the model.
+func (t *Thought) TrimSession(maxMessages int) int {
+	messages := t.Session.Messages()
+	excess := len(messages) - maxMessages
+	if excess <= 0 {
+		return 0
+	}
+
+	dropped := make([]bool, len(messages))
+	removed := 0
+	for i := 0; i < len(messages) && removed < excess; {
+		if messages[i].Role == zyn.RoleSystem {
+			i++
+			continue
+		}
+		// The turn runs up to the next user message
+		j := i
+		for j < len(messages) && (j == i || messages[j].Role != zyn.RoleUser) {
+			if messages[j].Role != zyn.RoleSystem {
+				dropped[j] = true
+				removed++
+			}
+			j++
+		}
+		i = j
+	}
+
+	kept := make([]zyn.Message, 0, len(messages)-removed)
+	for i, msg := range messages {
+		if !dropped[i] {
+			kept = append(kept, msg)
+		}
+	}
+	t.Session.SetMessages(kept)
+	return removed
+}
+
+// SetParent links the thought to parentID and persists the link with
+// Memory.UpdateThought, for workflows that only learn the parent once the child
+// exists. An empty parentID removes the link. ParentID is left unchanged if the
+// update fails. Memory.GetConversation reports any cycle this creates.
+func (t *Thought) SetParent(ctx context.Context, parentID string) error {
+	if parentID != "" && parentID == t.ID {
+		return fmt.Errorf("thought %s cannot be its own parent", t.ID)
+	}
+
+	previous := t.ParentID
+	t.ParentID = nil
+	if parentID != "" {
+		t.ParentID = &parentID
+	}
+	if err := t.memory.UpdateThought(ctx, t); err != nil {
+		t.ParentID = previous
+		return fmt.Errorf("failed to set parent: %w", err)
+	}
+	return nil
+}
+
 // SetMemory sets the memory reference for persistence operations.
 // This is used when hydrating a Thought from the database.
 func (t *Thought) SetMemory(m Memory) {
@@ -466,6 +1007,41 @@ func (t *Thought) Embedder() Embedder {
 	return t.embedder
 }
 
+// SetEmbedderFor routes notes whose "content_type" metadata equals contentType
+// to e, for content that needs its own embedding model (code versus prose, say).
+// Notes with any other content type use the default embedder. Passing a nil
+// embedder removes the route.
+//
+// Example:
+//
+//	thought.SetEmbedderFor("code", codeEmbedder)
+//	thought.SetNote(ctx, "patch", diff, "review", map[string]string{"content_type": "code"})
+func (t *Thought) SetEmbedderFor(contentType string, e Embedder) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e == nil {
+		delete(t.embedders, contentType)
+		return
+	}
+	if t.embedders == nil {
+		t.embedders = make(map[string]Embedder)
+	}
+	t.embedders[contentType] = e
+}
+
+// seqCounter returns the counter that hands out note Seqs. Clones share it with
+// the thought they were cloned from, since they write notes under the same thought
+// ID, so a Seq stays unique within a thought even across Converge branches.
+func (t *Thought) seqCounter() *atomic.Int64 {
+	t.seqOnce.Do(func() {
+		if t.seq == nil {
+			t.seq = new(atomic.Int64)
+		}
+	})
+	return t.seq
+}
+
 // AddNoteWithoutPersist adds a note to the in-memory state without persisting.
 // This is used when hydrating a Thought from the database.
 func (t *Thought) AddNoteWithoutPersist(note Note) {
@@ -474,6 +1050,15 @@ func (t *Thought) AddNoteWithoutPersist(note Note) {
 
 	t.notes = append(t.notes, note)
 	t.index.Store(note.Key, len(t.notes)-1)
+	t.recordSupersedes(note)
+	// Raise the shared counter past the reloaded Seq
+	seq := t.seqCounter()
+	for {
+		current := seq.Load()
+		if note.Seq <= current || seq.CompareAndSwap(current, note.Seq) {
+			break
+		}
+	}
 	t.UpdatedAt = time.Now()
 }
 
@@ -483,19 +1068,96 @@ func (t *Thought) MarkNotesPublished() {
 	t.mu.Lock()
 	previousPublished := t.publishedCount
 	t.publishedCount = len(t.notes)
+	publishedCount := t.publishedCount
 	t.mu.Unlock()
 
-	// Emit notes published event
+	t.emitNotesPublished(previousPublished, publishedCount)
+}
+
+// publishNotes marks all notes as published and persists the new boundary and the
+// session with Memory.UpdateThought, so Memory.Resume continues from the last
+// completed step without the caller saving state by hand.
+func (t *Thought) publishNotes(ctx context.Context) error {
+	t.MarkNotesPublished()
+	if t.memory == nil {
+		return nil
+	}
+	if err := t.memory.UpdateThought(ctx, t); err != nil {
+		return fmt.Errorf("failed to persist thought state: %w", err)
+	}
+	return nil
+}
+
+// publishNotesUpTo marks the first count notes as published and persists the
+// boundary like publishNotes, for steps that publish only part of the log.
+func (t *Thought) publishNotesUpTo(ctx context.Context, count int) error {
+	t.MarkNotesPublishedUpTo(count)
+	if t.memory == nil {
+		return nil
+	}
+	if err := t.memory.UpdateThought(ctx, t); err != nil {
+		return fmt.Errorf("failed to persist thought state: %w", err)
+	}
+	return nil
+}
+
+// MarkNotesPublishedUpTo marks the first count notes as published to the LLM.
+// The count is clamped to the range [0, number of notes]. Unlike SetPublishedCount,
+// this emits NotesPublished so event consumers observe the new boundary.
+func (t *Thought) MarkNotesPublishedUpTo(count int) {
+	t.mu.Lock()
+	if count < 0 {
+		count = 0
+	}
+	if count > len(t.notes) {
+		count = len(t.notes)
+	}
+	previousPublished := t.publishedCount
+	t.publishedCount = count
+	t.mu.Unlock()
+
+	t.emitNotesPublished(previousPublished, count)
+}
+
+// emitNotesPublished emits a notes published event for a boundary change.
+func (t *Thought) emitNotesPublished(previousPublished, publishedCount int) {
 	capitan.Emit(context.Background(), NotesPublished,
 		FieldTraceID.Field(t.TraceID),
-		FieldPublishedCount.Field(t.publishedCount),
-		FieldUnpublishedCount.Field(t.publishedCount-previousPublished),
+		FieldPublishedCount.Field(publishedCount),
+		FieldUnpublishedCount.Field(publishedCount-previousPublished),
 	)
 }
 
 // Compile-time check: *Thought must implement pipz.Cloner[*Thought].
 var _ interface{ Clone() *Thought } = (*Thought)(nil)
 
+// contextKeysMetadata builds output note metadata recording which note keys
+// were rendered into the LLM context, in first-seen order.
+func contextKeysMetadata(notes []Note) map[string]string {
+	seen := make(map[string]bool, len(notes))
+	keys := make([]string, 0, len(notes))
+	for _, note := range notes {
+		if seen[note.Key] {
+			continue
+		}
+		seen[note.Key] = true
+		keys = append(keys, note.Key)
+	}
+	return map[string]string{"context_keys": strings.Join(keys, ",")}
+}
+
+// emitContextRendered reports the size of the rendered context a step is about to
+// send, so context growth can be charted across a chain.
+func emitContextRendered(ctx context.Context, t *Thought, stepName, stepType, rendered string, noteCount int) {
+	capitan.Emit(ctx, ContextRendered,
+		FieldTraceID.Field(t.TraceID),
+		FieldStepName.Field(stepName),
+		FieldStepType.Field(stepType),
+		FieldContextSize.Field(utf8.RuneCountInString(rendered)),
+		FieldNoteCount.Field(noteCount),
+	)
+}
+
 // RenderNotesToContext converts a slice of notes to a formatted context string
 // suitable for LLM consumption. Each note is rendered as "key: content".
 func RenderNotesToContext(notes []Note) string {
@@ -0,0 +1,127 @@
+package cogito
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/zoobzio/pipz"
+)
+
+// ErrPoolShutdown is returned when submitting to a ThoughtWorkerPool that has been shut down.
+var ErrPoolShutdown = errors.New("thought worker pool is shut down")
+
+// ThoughtResult is the outcome of processing a single submitted thought.
+type ThoughtResult struct {
+	Thought *Thought
+	Err     error
+}
+
+// ThoughtWorkerPool is a long-lived service that continuously processes submitted
+// thoughts through a pipeline using a fixed number of workers.
+//
+// Unlike the WorkerPool connector, which fans a single thought out to several
+// processors, ThoughtWorkerPool accepts a stream of thoughts over its lifetime.
+// Each result is delivered on Results; callers must drain the channel or workers
+// will block once its buffer is full.
+//
+// Example:
+//
+//	pool := cogito.NewThoughtWorkerPool(ctx, 4, pipeline)
+//	go func() {
+//	    for res := range pool.Results() {
+//	        handle(res.Thought, res.Err)
+//	    }
+//	}()
+//	pool.Submit(thought)
+//	pool.Shutdown()
+type ThoughtWorkerPool struct {
+	pipeline pipz.Chainable[*Thought]
+	queue    chan *Thought
+	results  chan ThoughtResult
+	wg       sync.WaitGroup
+
+	done       chan struct{}  // closed by Shutdown to release blocked submitters
+	submitters sync.WaitGroup // Submit calls that may still send on queue
+	mu         sync.Mutex
+	closed     bool
+}
+
+// NewThoughtWorkerPool starts a pool of workers that apply pipeline to each submitted thought.
+// Workers use ctx for processing; cancelling it causes in-flight and queued thoughts to fail.
+// A workers value below 1 is treated as 1.
+func NewThoughtWorkerPool(ctx context.Context, workers int, pipeline pipz.Chainable[*Thought]) *ThoughtWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &ThoughtWorkerPool{
+		pipeline: pipeline,
+		queue:    make(chan *Thought, workers),
+		results:  make(chan ThoughtResult, workers),
+		done:     make(chan struct{}),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work(ctx)
+	}
+
+	return p
+}
+
+// work processes queued thoughts until the queue is closed.
+func (p *ThoughtWorkerPool) work(ctx context.Context) {
+	defer p.wg.Done()
+	for t := range p.queue {
+		result, err := p.pipeline.Process(ctx, t)
+		p.results <- ThoughtResult{Thought: result, Err: err}
+	}
+}
+
+// Submit queues a thought for processing, blocking while the queue is full.
+// Returns ErrPoolShutdown if the pool has been shut down, including when Shutdown
+// is called while Submit is waiting for room.
+func (p *ThoughtWorkerPool) Submit(t *Thought) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolShutdown
+	}
+	p.submitters.Add(1)
+	p.mu.Unlock()
+	defer p.submitters.Done()
+
+	select {
+	case p.queue <- t:
+		return nil
+	case <-p.done:
+		return ErrPoolShutdown
+	}
+}
+
+// Results returns the channel on which processed thoughts are delivered.
+// The channel is closed once Shutdown has drained all submitted work.
+func (p *ThoughtWorkerPool) Results() <-chan ThoughtResult {
+	return p.results
+}
+
+// Shutdown stops accepting new thoughts, waits for queued work to finish,
+// and closes the results channel. It is safe to call more than once.
+func (p *ThoughtWorkerPool) Shutdown() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	// Blocked submitters now return; the queue is closed once none can send on it
+	p.submitters.Wait()
+	close(p.queue)
+
+	p.wg.Wait()
+	close(p.results)
+}
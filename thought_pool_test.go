@@ -0,0 +1,114 @@
+package cogito
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zoobzio/pipz"
+)
+
+func TestThoughtWorkerPool(t *testing.T) {
+	pipeline := Effect(pipz.NewIdentity("mark", "Marks processed thoughts"), func(ctx context.Context, th *Thought) error {
+		if th.Intent == "fail" {
+			return errors.New("boom")
+		}
+		return th.SetContent(ctx, "processed", "yes", "mark")
+	})
+
+	pool := NewThoughtWorkerPool(context.Background(), 3, pipeline)
+
+	collected := make(chan []ThoughtResult)
+	go func() {
+		var results []ThoughtResult
+		for res := range pool.Results() {
+			results = append(results, res)
+		}
+		collected <- results
+	}()
+
+	for i := 0; i < 9; i++ {
+		if err := pool.Submit(newTestThought("work")); err != nil {
+			t.Fatalf("unexpected submit error: %v", err)
+		}
+	}
+	if err := pool.Submit(newTestThought("fail")); err != nil {
+		t.Fatalf("unexpected submit error: %v", err)
+	}
+
+	pool.Shutdown()
+	results := <-collected
+
+	if len(results) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(results))
+	}
+
+	failures := 0
+	for _, res := range results {
+		if res.Err != nil {
+			failures++
+			continue
+		}
+		if v, err := res.Thought.GetContent("processed"); err != nil || v != "yes" {
+			t.Errorf("expected processed note, got %q (%v)", v, err)
+		}
+	}
+	if failures != 1 {
+		t.Errorf("expected 1 failure, got %d", failures)
+	}
+
+	if err := pool.Submit(newTestThought("late")); !errors.Is(err, ErrPoolShutdown) {
+		t.Errorf("expected ErrPoolShutdown after shutdown, got %v", err)
+	}
+
+	// Shutdown is idempotent
+	pool.Shutdown()
+}
+
+func TestThoughtWorkerPoolShutdownReleasesBlockedSubmit(t *testing.T) {
+	gate := make(chan struct{})
+	pipeline := Effect(pipz.NewIdentity("wait", "Waits for the gate"), func(_ context.Context, _ *Thought) error {
+		<-gate
+		return nil
+	})
+
+	pool := NewThoughtWorkerPool(context.Background(), 1, pipeline)
+	go func() {
+		for range pool.Results() {
+		}
+	}()
+
+	// One thought holds the worker and one fills the queue
+	for i := 0; i < 2; i++ {
+		if err := pool.Submit(newTestThought("work")); err != nil {
+			t.Fatalf("unexpected submit error: %v", err)
+		}
+	}
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- pool.Submit(newTestThought("blocked")) }()
+	time.Sleep(20 * time.Millisecond)
+
+	shutdown := make(chan struct{})
+	go func() {
+		pool.Shutdown()
+		close(shutdown)
+	}()
+
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrPoolShutdown) {
+			t.Errorf("expected ErrPoolShutdown for the blocked submit, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Submit stayed blocked after Shutdown")
+	}
+
+	close(gate)
+	select {
+	case <-shutdown:
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not finish")
+	}
+}
@@ -1,9 +1,17 @@
 package cogito
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zoobzio/zyn"
 )
 
 func TestNew(t *testing.T) {
@@ -34,6 +42,106 @@ func TestNewWithTrace(t *testing.T) {
 	}
 }
 
+func TestGetOrCreateByTrace(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+
+	first, err := GetOrCreateByTrace(ctx, mem, "queued", "trace-retry")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.TraceID != "trace-retry" {
+		t.Errorf("expected trace ID %q, got %q", "trace-retry", first.TraceID)
+	}
+	if err := first.SetContent(ctx, "step", "done", "test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := GetOrCreateByTrace(ctx, mem, "queued", "trace-retry")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("expected existing thought %q, got %q", first.ID, second.ID)
+	}
+	if content, _ := second.GetContent("step"); content != "done" {
+		t.Errorf("expected existing notes, got %q", content)
+	}
+	if len(mem.thoughts) != 1 {
+		t.Errorf("expected 1 persisted thought, got %d", len(mem.thoughts))
+	}
+}
+
+// unreachableTraceMemory fails trace lookups the way an unavailable database would.
+type unreachableTraceMemory struct {
+	*mockMemory
+}
+
+func (m unreachableTraceMemory) GetThoughtByTraceID(_ context.Context, _ string) (*Thought, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestGetOrCreateByTraceLookupError(t *testing.T) {
+	mem := unreachableTraceMemory{newMockMemory()}
+
+	_, err := GetOrCreateByTrace(context.Background(), mem, "queued", "trace-outage")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the lookup error, got %v", err)
+	}
+	if len(mem.thoughts) != 0 {
+		t.Errorf("expected no thought created on a failed lookup, got %d", len(mem.thoughts))
+	}
+}
+
+func TestNewFromNotes(t *testing.T) {
+	ctx := context.Background()
+	mem := newMockMemory()
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	notes := []Note{
+		{ID: "external-1", Key: "ticket", Content: "Login broken", Source: "queue", Created: created},
+		{Key: "priority", Content: "high", Source: "queue", Metadata: map[string]string{"by": "triage"}},
+		{Key: "ticket", Content: "Login broken on mobile", Source: "queue"},
+	}
+
+	thought, err := NewFromNotes(ctx, mem, "rehydrated", notes, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if content, _ := thought.GetContent("ticket"); content != "Login broken on mobile" {
+		t.Errorf("expected latest ticket content, got %q", content)
+	}
+	if v, _ := thought.GetMetadata("priority", "by"); v != "triage" {
+		t.Errorf("expected metadata preserved, got %q", v)
+	}
+	first := thought.AllNotes()[0]
+	if !first.Created.Equal(created) {
+		t.Errorf("expected created time preserved, got %v", first.Created)
+	}
+	if first.ID == "external-1" || first.ThoughtID != thought.ID {
+		t.Errorf("expected note reassigned to thought, got ID %q ThoughtID %q", first.ID, first.ThoughtID)
+	}
+
+	if unpublished := thought.GetUnpublishedNotes(); len(unpublished) != 1 {
+		t.Errorf("expected 1 unpublished note, got %d", len(unpublished))
+	}
+
+	persisted, _ := mem.GetNotes(ctx, thought.ID)
+	if len(persisted) != 3 {
+		t.Errorf("expected 3 persisted notes, got %d", len(persisted))
+	}
+
+	// Published count is clamped
+	clamped, err := NewFromNotes(ctx, mem, "clamped", notes[:1], 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clamped.GetUnpublishedNotes()) != 0 {
+		t.Error("expected all notes published after clamping")
+	}
+}
+
 func TestAddNote(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -68,6 +176,44 @@ func TestAddNote(t *testing.T) {
 	}
 }
 
+func TestNoteSeq(t *testing.T) {
+	ctx := context.Background()
+	thought := newTestThought("test")
+	created := time.Now()
+	for _, key := range []string{"a", "b", "c"} {
+		thought.AddNote(ctx, Note{Key: key, Content: key, Source: "test", Created: created})
+	}
+
+	for i, note := range thought.AllNotes() {
+		if note.Seq != int64(i+1) {
+			t.Errorf("expected %s to have seq %d, got %d", note.Key, i+1, note.Seq)
+		}
+	}
+
+	// Restoring must not hand out a seq that was already used
+	cp := thought.Checkpoint()
+	thought.SetContent(ctx, "d", "d", "test")
+	thought.Restore(cp)
+	thought.SetContent(ctx, "e", "e", "test")
+	if note, _ := thought.GetNote("e"); note.Seq != 5 {
+		t.Errorf("expected seq 5 after restore, got %d", note.Seq)
+	}
+
+	// Hydrated notes keep their seq and advance the counter
+	reloaded := newTestThought("reloaded")
+	for _, note := range thought.AllNotes() {
+		reloaded.AddNoteWithoutPersist(note)
+	}
+	reloaded.SetContent(ctx, "f", "f", "test")
+	if note, _ := reloaded.GetNote("f"); note.Seq != 6 {
+		t.Errorf("expected seq 6 after hydration, got %d", note.Seq)
+	}
+
+	if note, _ := thought.Clone().GetNote("e"); note.Seq != 5 {
+		t.Errorf("expected clone to keep seq 5, got %d", note.Seq)
+	}
+}
+
 func TestSetContent(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -188,6 +334,141 @@ func TestGetMetadataErrors(t *testing.T) {
 	}
 }
 
+func TestGetContentMulti(t *testing.T) {
+	thought := newTestThought("test")
+	thought.SetContent(context.Background(), "ticket", "Login broken", "input")
+	thought.SetContent(context.Background(), "customer", "acme", "input")
+	thought.SetContent(context.Background(), "customer", "globex", "input")
+
+	contents, missing := thought.GetContentMulti("ticket", "history", "customer", "tier")
+
+	if len(contents) != 2 || contents["ticket"] != "Login broken" || contents["customer"] != "globex" {
+		t.Errorf("expected latest contents for ticket and customer, got %v", contents)
+	}
+	if len(missing) != 2 || missing[0] != "history" || missing[1] != "tier" {
+		t.Errorf("expected missing [history tier], got %v", missing)
+	}
+
+	contents, missing = thought.GetContentMulti("ticket")
+	if missing != nil || len(contents) != 1 {
+		t.Errorf("expected no missing keys, got %v", missing)
+	}
+}
+
+func TestNoteCountAndIsEmpty(t *testing.T) {
+	thought := newTestThought("test")
+	if !thought.IsEmpty() || thought.NoteCount() != 0 {
+		t.Fatalf("expected new thought to be empty, got %d notes", thought.NoteCount())
+	}
+
+	thought.SetContent(context.Background(), "a", "first", "test")
+	thought.SetContent(context.Background(), "a", "second", "test")
+
+	if thought.IsEmpty() {
+		t.Error("expected thought with notes not to be empty")
+	}
+	if thought.NoteCount() != 2 {
+		t.Errorf("expected 2 notes, got %d", thought.NoteCount())
+	}
+}
+
+func TestClearNotes(t *testing.T) {
+	thought := newTestThought("test")
+	thought.SetContent(context.Background(), "a", "first", "test")
+	thought.SetContent(context.Background(), "b", "second", "test")
+	thought.MarkNotesPublished()
+	thought.Session.Append("user", "hello")
+	thought.SetAttr("tenant", "acme")
+	id, traceID, memory := thought.ID, thought.TraceID, thought.Memory()
+
+	thought.ClearNotes()
+
+	if !thought.IsEmpty() {
+		t.Errorf("expected no notes, got %d", thought.NoteCount())
+	}
+	if thought.PublishedCount() != 0 {
+		t.Errorf("expected published count 0, got %d", thought.PublishedCount())
+	}
+	if _, err := thought.GetContent("a"); err == nil {
+		t.Error("expected cleared key to be absent from the index")
+	}
+	if thought.ID != id || thought.TraceID != traceID || thought.Memory() != memory {
+		t.Error("expected identity and memory to be kept")
+	}
+	if thought.Session.Len() != 1 {
+		t.Errorf("expected session to be kept, got %d messages", thought.Session.Len())
+	}
+	if v, ok := thought.GetAttr("tenant"); !ok || v != "acme" {
+		t.Error("expected attributes to be kept")
+	}
+
+	thought.SetContent(context.Background(), "a", "fresh", "test")
+	if content, _ := thought.GetContent("a"); content != "fresh" {
+		t.Errorf("expected fresh note after clearing, got %q", content)
+	}
+	if len(thought.GetUnpublishedNotes()) != 1 {
+		t.Errorf("expected the new note to be unpublished, got %d", len(thought.GetUnpublishedNotes()))
+	}
+}
+
+func TestWalk(t *testing.T) {
+	thought := newTestThought("test")
+	for _, key := range []string{"a", "b", "c"} {
+		thought.SetContent(context.Background(), key, "value "+key, "test")
+	}
+
+	var visited []string
+	thought.Walk(func(note Note) bool {
+		visited = append(visited, note.Key)
+		return true
+	})
+	if strings.Join(visited, ",") != "a,b,c" {
+		t.Errorf("expected all notes in order, got %v", visited)
+	}
+
+	// Early termination
+	visited = nil
+	thought.Walk(func(note Note) bool {
+		visited = append(visited, note.Key)
+		return note.Key != "b"
+	})
+	if strings.Join(visited, ",") != "a,b" {
+		t.Errorf("expected walk to stop at b, got %v", visited)
+	}
+}
+
+func TestGetReasoning(t *testing.T) {
+	thought := newTestThought("test")
+	ctx := context.Background()
+
+	// Sequential metadata fields, stopping at the first gap
+	thought.SetNote(ctx, "meta", "yes", "test", map[string]string{
+		"reasoning_0": "first",
+		"reasoning_1": "second",
+		"reasoning_3": "unreachable",
+	})
+	got := thought.GetReasoning("meta")
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("expected [first second], got %v", got)
+	}
+
+	// JSON response content
+	thought.SetContent(ctx, "decision", `{"decision": true, "confidence": 0.9, "reasoning": ["a", "b", "c"]}`, "decide")
+	got = thought.GetReasoning("decision")
+	if len(got) != 3 || got[2] != "c" {
+		t.Errorf("expected [a b c], got %v", got)
+	}
+
+	// No reasoning available
+	thought.SetContent(ctx, "plain", "not json", "test")
+	if got := thought.GetReasoning("plain"); got != nil {
+		t.Errorf("expected nil for note without reasoning, got %v", got)
+	}
+	if got := thought.GetReasoning("missing"); got != nil {
+		t.Errorf("expected nil for missing note, got %v", got)
+	}
+}
+
 func TestGetLatestNote(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -309,6 +590,103 @@ func TestGetInt(t *testing.T) {
 	}
 }
 
+func TestContentBytes(t *testing.T) {
+	thought := newTestThought("test")
+	data := []byte{0x00, 0xff, 'h', 'i', '\n'}
+
+	if err := thought.SetContentBytes(context.Background(), "blob", data, "test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := thought.GetContentBytes("blob")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("expected %v, got %v", data, got)
+	}
+	if encoding, _ := thought.GetMetadata("blob", "encoding"); encoding != "base64" {
+		t.Errorf("expected encoding metadata 'base64', got %q", encoding)
+	}
+
+	// Test content that is not base64
+	thought.SetContent(context.Background(), "text", "not base64!", "test")
+	if _, err := thought.GetContentBytes("text"); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	thought := newTestThought("test attrs")
+	ctx := context.Background()
+
+	if _, ok := thought.GetAttr("tenant"); ok {
+		t.Error("expected missing attribute")
+	}
+
+	thought.SetAttr("tenant", "acme")
+	thought.SetAttr("beta", true)
+	thought.SetContent(ctx, "input", "hello", "test")
+
+	if v, ok := thought.GetAttr("tenant"); !ok || v != "acme" {
+		t.Errorf("expected tenant 'acme', got %v (%v)", v, ok)
+	}
+
+	// Attributes are not notes and never reach LLM context
+	if len(thought.AllNotes()) != 1 {
+		t.Errorf("expected attributes to not create notes, got %d notes", len(thought.AllNotes()))
+	}
+	if strings.Contains(RenderNotesToContext(thought.GetUnpublishedNotes()), "acme") {
+		t.Error("expected attributes to be excluded from rendered context")
+	}
+
+	// Attributes travel with clones
+	clone := thought.Clone()
+	if v, ok := clone.GetAttr("beta"); !ok || v != true {
+		t.Errorf("expected clone to carry attributes, got %v (%v)", v, ok)
+	}
+	clone.SetAttr("tenant", "other")
+	if v, _ := thought.GetAttr("tenant"); v != "acme" {
+		t.Errorf("expected original attribute unchanged, got %v", v)
+	}
+}
+
+func TestSetParent(t *testing.T) {
+	ctx := context.Background()
+	memory := newMockMemory()
+	parent, _ := New(ctx, memory, "parent")
+	child, _ := New(ctx, memory, "child")
+
+	if err := child.SetParent(ctx, parent.ID); err != nil {
+		t.Fatalf("SetParent failed: %v", err)
+	}
+	children, _ := memory.GetChildThoughts(ctx, parent.ID)
+	if len(children) != 1 || children[0].ID != child.ID {
+		t.Errorf("expected child linked to parent, got %v", children)
+	}
+
+	if err := child.SetParent(ctx, child.ID); err == nil {
+		t.Error("expected error when a thought is its own parent")
+	}
+
+	// A failed update leaves the link as it was
+	child.SetMemory(newMockMemory())
+	if err := child.SetParent(ctx, ""); err == nil {
+		t.Fatal("expected error when the thought is not in memory")
+	}
+	if child.ParentID == nil || *child.ParentID != parent.ID {
+		t.Errorf("expected ParentID kept after failed update, got %v", child.ParentID)
+	}
+
+	child.SetMemory(memory)
+	if err := child.SetParent(ctx, ""); err != nil {
+		t.Fatalf("SetParent to clear failed: %v", err)
+	}
+	if child.ParentID != nil {
+		t.Errorf("expected ParentID cleared, got %q", *child.ParentID)
+	}
+}
+
 func TestClone(t *testing.T) {
 	original := newTestThought("test")
 
@@ -354,6 +732,108 @@ func TestClone(t *testing.T) {
 	}
 }
 
+func TestMarkNotesPublishedUpTo(t *testing.T) {
+	thought := newTestThought("test")
+	ctx := context.Background()
+
+	thought.SetContent(ctx, "a", "1", "test")
+	thought.SetContent(ctx, "b", "2", "test")
+	thought.SetContent(ctx, "c", "3", "test")
+
+	thought.MarkNotesPublishedUpTo(2)
+
+	if thought.PublishedCount() != 2 {
+		t.Errorf("expected published count 2, got %d", thought.PublishedCount())
+	}
+
+	unpublished := thought.GetUnpublishedNotes()
+	if len(unpublished) != 1 || unpublished[0].Key != "c" {
+		t.Errorf("expected only note c unpublished, got %v", unpublished)
+	}
+
+	// Counts beyond the number of notes are clamped
+	thought.MarkNotesPublishedUpTo(10)
+	if thought.PublishedCount() != 3 {
+		t.Errorf("expected published count clamped to 3, got %d", thought.PublishedCount())
+	}
+
+	// Negative counts are clamped to zero
+	thought.MarkNotesPublishedUpTo(-1)
+	if thought.PublishedCount() != 0 {
+		t.Errorf("expected published count clamped to 0, got %d", thought.PublishedCount())
+	}
+}
+
+func TestContextSize(t *testing.T) {
+	thought := newTestThought("test")
+	ctx := context.Background()
+
+	if thought.ContextSize() != 0 || thought.ContextSizeAll() != 0 {
+		t.Error("expected zero context size for empty thought")
+	}
+
+	thought.SetContent(ctx, "a", "one", "test")
+	thought.SetContent(ctx, "b", "two", "test")
+
+	all := thought.AllNotes()
+	if got, want := thought.ContextSize(), len(RenderNotesToContext(all)); got != want {
+		t.Errorf("expected context size %d, got %d", want, got)
+	}
+
+	thought.MarkNotesPublished()
+	thought.SetContent(ctx, "c", "three", "test")
+
+	if got, want := thought.ContextSize(), len(RenderNotesToContext(thought.GetUnpublishedNotes())); got != want {
+		t.Errorf("expected unpublished context size %d, got %d", want, got)
+	}
+	if got, want := thought.ContextSizeAll(), len(RenderNotesToContext(thought.AllNotes())); got != want {
+		t.Errorf("expected full context size %d, got %d", want, got)
+	}
+
+	// Multi-byte text counts characters, not bytes
+	thought.SetContent(ctx, "résumé", "Grüße, 世界", "test")
+	if got, want := thought.ContextSize(), utf8.RuneCountInString(RenderNotesToContext(thought.GetUnpublishedNotes())); got != want {
+		t.Errorf("expected context size of %d characters, got %d", want, got)
+	}
+}
+
+func TestCheckpointRestore(t *testing.T) {
+	thought := newTestThought("test")
+	ctx := context.Background()
+
+	thought.SetContent(ctx, "status", "draft", "test")
+	thought.MarkNotesPublished()
+	cp := thought.Checkpoint()
+
+	// Speculative work overwrites a key and adds a new one
+	thought.SetContent(ctx, "status", "speculative", "test")
+	thought.SetContent(ctx, "guess", "maybe", "test")
+	thought.MarkNotesPublished()
+
+	thought.Restore(cp)
+
+	if len(thought.AllNotes()) != 1 {
+		t.Errorf("expected 1 note after restore, got %d", len(thought.AllNotes()))
+	}
+	status, _ := thought.GetContent("status")
+	if status != "draft" {
+		t.Errorf("expected status 'draft' after restore, got %q", status)
+	}
+	if _, err := thought.GetContent("guess"); err == nil {
+		t.Error("expected speculative note to be discarded")
+	}
+	if thought.PublishedCount() != 1 {
+		t.Errorf("expected published count 1, got %d", thought.PublishedCount())
+	}
+
+	// Notes added after restore are indexed normally
+	thought.SetContent(ctx, "status", "final", "test")
+	status, _ = thought.GetContent("status")
+	if status != "final" {
+		t.Errorf("expected status 'final', got %q", status)
+	}
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	thought := newTestThought("test")
 
@@ -412,3 +892,83 @@ func TestConcurrentClone(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestTrimSession(t *testing.T) {
+	thought := newTestThought("test trim")
+	thought.Session.Append(zyn.RoleSystem, "You are helpful.")
+	for i := 0; i < 6; i++ {
+		thought.Session.Append(zyn.RoleUser, fmt.Sprintf("message %d", i))
+	}
+
+	if removed := thought.TrimSession(10); removed != 0 {
+		t.Errorf("expected nothing removed under the limit, got %d", removed)
+	}
+
+	if removed := thought.TrimSession(3); removed != 4 {
+		t.Errorf("expected 4 removed, got %d", removed)
+	}
+	messages := thought.Session.Messages()
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+	if messages[0].Role != zyn.RoleSystem {
+		t.Error("expected system message kept")
+	}
+	if messages[1].Content != "message 4" || messages[2].Content != "message 5" {
+		t.Errorf("expected newest messages kept, got %q and %q", messages[1].Content, messages[2].Content)
+	}
+
+	thought.TrimSession(0)
+	if thought.Session.Len() != 1 || thought.Session.Messages()[0].Role != zyn.RoleSystem {
+		t.Error("expected only the system message to survive a zero limit")
+	}
+}
+
+func TestTrimSessionKeepsTurnsWhole(t *testing.T) {
+	build := func() *Thought {
+		thought := newTestThought("test trim turns")
+		thought.Session.Append(zyn.RoleSystem, "You are helpful.")
+		for i := 0; i < 3; i++ {
+			thought.Session.Append(zyn.RoleUser, fmt.Sprintf("question %d", i))
+			thought.Session.Append(zyn.RoleAssistant, fmt.Sprintf("answer %d", i))
+		}
+		return thought
+	}
+
+	tests := []struct {
+		limit   int
+		removed int
+		first   string
+	}{
+		{limit: 6, removed: 2, first: "question 1"},
+		{limit: 5, removed: 2, first: "question 1"},
+		{limit: 4, removed: 4, first: "question 2"},
+		{limit: 3, removed: 4, first: "question 2"},
+		{limit: 2, removed: 6, first: ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("limit %d", tt.limit), func(t *testing.T) {
+			thought := build()
+			if removed := thought.TrimSession(tt.limit); removed != tt.removed {
+				t.Errorf("expected %d removed, got %d", tt.removed, removed)
+			}
+
+			messages := thought.Session.Messages()
+			if len(messages) > tt.limit || messages[0].Role != zyn.RoleSystem {
+				t.Fatalf("expected at most %d messages led by the system prompt, got %v", tt.limit, messages)
+			}
+			if tt.first == "" {
+				if len(messages) != 1 {
+					t.Errorf("expected only the system message, got %v", messages)
+				}
+				return
+			}
+			if messages[1].Role != zyn.RoleUser || messages[1].Content != tt.first {
+				t.Errorf("expected the kept history to start at %q, got %v", tt.first, messages[1])
+			}
+			if (len(messages)-1)%2 != 0 {
+				t.Errorf("expected whole question/answer turns, got %v", messages)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ func NewTruncate(key string) *Truncate {
 
 // Process implements pipz.Chainable[*Thought].
 func (tr *Truncate) Process(ctx context.Context, t *Thought) (*Thought, error) {
+	ctx = withThoughtTrace(ctx, t)
 	start := time.Now()
 
 	messageCount := t.Session.Len()
@@ -93,7 +94,7 @@ func (tr *Truncate) Process(ctx context.Context, t *Thought) (*Thought, error) {
 
 // emitFailed emits a step failed event.
 func (tr *Truncate) emitFailed(ctx context.Context, t *Thought, start time.Time, err error) {
-	capitan.Error(ctx, StepFailed,
+	emitStepFailed(ctx, err,
 		FieldTraceID.Field(t.TraceID),
 		FieldStepName.Field(tr.key),
 		FieldStepType.Field("truncate"),